@@ -2,63 +2,85 @@ package app
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
 	"gin-blog/config"
 	"gin-blog/controllers"
+	"gin-blog/repository"
 )
 
-func ConnectDatabase(config *config.AppConfig) (*gorm.DB, error) {
-	// Connect to the PostgreSQL database
-	dsn := fmt.Sprintf("host=%s dbname=%s user=%s password=%s sslmode=disable",
-		config.DB.Host, config.DB.Name, config.DB.User, config.DB.Password)
+// NewDatabaseHandler - Creates the Request Handlers operating on the Database
+func NewDatabaseHandler(db *gorm.DB) *controllers.Handler {
+	return controllers.NewHandler(repository.NewGormStorage(db))
+}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// NewMemoryHandler - Creates the Request Handlers operating on an In-Memory Storage
+func NewMemoryHandler() *controllers.Handler {
+	handler := controllers.NewHandler(repository.NewMemoryStorage())
 
-	if err != nil {
-		panic("Failed to connect to database")
-	}
-	return db, err
+	handler.Files = repository.NewMemoryFileStore()
+
+	return handler
 }
 
-func InitializeDatabase(db *gorm.DB) error {
+// NewFileStore - Creates the Media File Storage selected by the Configuration
+func NewFileStore(media *config.MediaConfig) (repository.FileStore, error) {
+	switch media.Storage {
+	case "local":
+		store, err := repository.NewLocalFileStore(media.Directory)
+
+		if err != nil {
+			return nil, err
+		}
 
-	// Create Users Structure
-	err := controllers.MigrateUsers(db)
+		return store, nil
+	case "s3":
+		store, err := repository.NewS3FileStore(media.S3.Endpoint, media.S3.Region, media.S3.Bucket, media.S3.AccessKey, media.S3.SecretKey)
 
-	if err == nil {
-		// Create Articles Structure
-		err = controllers.MigrateArticles(db)
+		if err != nil {
+			return nil, err
+		}
+
+		return store, nil
 	}
 
-	return err
+	return nil, fmt.Errorf("Media Storage '%s': Storage is unknown! Known Storages: local, s3", media.Storage)
 }
 
-func RegisterRoutes(config *config.AppConfig) *gin.Engine {
+func RegisterRoutes(config *config.AppConfig, handler *controllers.Handler) *gin.Engine {
 	router := gin.Default()
 
-	// Register User Routes
+	// Register Home Route
 	controllers.RegisterHomeRoute(router, config)
 	// Register User Routes
-	controllers.RegisterUserRoutes(router, config)
+	controllers.RegisterUserRoutes(router, config, handler)
 	// Register Article Routes
-	controllers.RegisterArticleRoutes(router, config)
+	controllers.RegisterArticleRoutes(router, config, handler)
+	// Register Tag and Category Routes
+	controllers.RegisterTaxonomyRoutes(router, config, handler)
+	// Register Feed Routes
+	controllers.RegisterFeedRoutes(router, config, handler)
+	// Register Media Routes
+	controllers.RegisterMediaRoutes(router, config, handler)
+	// Register Trash Routes
+	controllers.RegisterTrashRoutes(router, config, handler)
 	// Register Login Routes
-	controllers.RegisterLoginRoutes(router, config)
+	controllers.RegisterLoginRoutes(router, config, handler)
 
 	return router
 }
 
-func Start() error {
+// Start - Runs the Web Server
+// The Overrides of the Command Line take Precedence over the Configuration File
+// and the Environment.
+func Start(overrides config.Overrides) error {
 
 	var db *gorm.DB
 
-	appConfig, err := config.ReadConfigFile()
-
-	fmt.Printf("App - Start(): config: %#v; error: %#v\n", appConfig, err)
+	appConfig, err := config.LoadConfig(overrides)
 
 	if err != nil {
 		err = fmt.Errorf("Config is missing! Message: %v\n", err)
@@ -66,14 +88,26 @@ func Start() error {
 		return err
 	}
 
+	if dump, err := appConfig.Dump(); err == nil {
+		fmt.Printf("App - Start(): config:\n%s", dump)
+	}
+
+	if err = appConfig.Validate(); err != nil {
+		return err
+	}
+
 	controllers.PROJECT = appConfig.Project
 
 	if controllers.PROJECT == "" {
 		controllers.PROJECT = "Gin Blog API"
 	}
 
-	// Create the global Database Connection
-	db, err = ConnectDatabase(&appConfig)
+	// Stop the Server and the Background Jobs on SIGINT or SIGTERM
+	ctx, stop := ShutdownContext()
+	defer stop()
+
+	// Create the global Database Connection and wait for a slowly starting Database
+	db, err = ConnectDatabaseRetry(ctx, &appConfig)
 
 	if err != nil {
 		err = fmt.Errorf("Database Connection failed! Message: %v\n", err)
@@ -81,11 +115,52 @@ func Start() error {
 		return err
 	}
 
-	InitializeDatabase(db)
+	// Close the Database Connections after the Server was shut down
+	defer CloseDatabase(db)
+
+	if err = CheckDatabase(db); err != nil {
+		return err
+	}
+
+	// Search with the configured Text Search Configuration
+	repository.SEARCHLANGUAGE = appConfig.Search.Language
+
+	handler := NewDatabaseHandler(db)
 
-	router := RegisterRoutes(&appConfig)
+	if handler.Files, err = NewFileStore(&appConfig.Media); err != nil {
+		err = fmt.Errorf("Media Storage failed! Message: %v\n", err)
+
+		return err
+	}
+
+	// Index the Articles which are not indexed with this Configuration yet
+	if _, err = handler.Articles.ReindexSearch(); err != nil {
+		err = fmt.Errorf("Search Index failed! Message: %v\n", err)
+
+		return err
+	}
+
+	// Publish the scheduled Articles in the Background
+	go RunPublisher(ctx, handler.Articles, PUBLISHERINTERVAL)
+
+	// Purge the expired Trash in the Background
+	if retention, ok := appConfig.Trash.Retention(); ok {
+		go RunTrashPurger(ctx, handler, retention, TRASHPURGEINTERVAL)
+	}
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	server := NewServer(&appConfig.Server, router)
+
+	listener, err := net.Listen("tcp", server.Addr)
+
+	if err != nil {
+		err = fmt.Errorf("Server Address '%s' is not available! Message: %v\n", server.Addr, err)
+
+		return err
+	}
 
-	router.Run(":3000")
+	fmt.Printf("App - Start(): Listening on '%s' (TLS: %v)\n", listener.Addr(), appConfig.Server.UsesTLS())
 
-	return err
+	return Serve(ctx, server, listener, &appConfig.Server)
 }
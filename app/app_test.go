@@ -5,15 +5,25 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 
 	"gin-blog/config"
+	"gin-blog/controllers"
+	"gin-blog/migrations"
 	"gin-blog/model"
+	"gin-blog/repository"
 )
 
+// TESTSTORAGE - Environment Variable which selects the Storage of the Tests
+// "memory" (Default), "sqlite" for a SQLite Database in Memory or "sqlite-file" for a SQLite Database File
+const TESTSTORAGE string = "GINBLOG_TEST_STORAGE"
+
 // testUser - A editor user which will own the articles
 var testUser model.User = model.User{
 	Name:  "Test User No. 1",
@@ -22,63 +32,123 @@ var testUser model.User = model.User{
 	Email: "user-1@email.com",
 }
 
+// testPublishTime - Publish Time of the published test articles
+var testPublishTime time.Time = time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
 // testArticles - Articles that will be created as test data
 var testArticles = []model.Article{
 	{
-		UserID:  0,
-		Title:   "Test Article No. 1",
-		Slug:    "article-1",
-		Content: "Test Article No. 1 Content",
+		UserID:      0,
+		Title:       "Test Article No. 1",
+		Slug:        "article-1",
+		Content:     "Test Article No. 1 Content",
+		Status:      model.StatusPublished,
+		PublishedAt: &testPublishTime,
 	},
 	{
-		UserID:  0,
-		Title:   "Test Article No. 2",
-		Slug:    "article-2",
-		Content: "Test Article No. 2 Content",
+		UserID:      0,
+		Title:       "Test Article No. 2",
+		Slug:        "article-2",
+		Content:     "Test Article No. 2 Content",
+		Status:      model.StatusPublished,
+		PublishedAt: &testPublishTime,
 	},
 	{
-		UserID:  0,
-		Title:   "Test Article No. 3",
-		Slug:    "article-3",
-		Content: "Test Article No. 3 Content",
+		UserID:      0,
+		Title:       "Test Article No. 3",
+		Slug:        "article-3",
+		Content:     "Test Article No. 3 Content",
+		Status:      model.StatusPublished,
+		PublishedAt: &testPublishTime,
 	},
 }
 
 var resDisplayedArticles map[uint]*model.DisplayedArticle = make(map[uint]*model.DisplayedArticle)
 
-func TestDisplayArticles(t *testing.T) {
-	var appConfig config.AppConfig
-	var db *gorm.DB
-	var err error
+// readTestConfig - Reads the Application Configuration or falls back to a Test Configuration
+// The Tests run against an In-Memory Storage and do not require a configured Database
+func readTestConfig(t *testing.T) config.AppConfig {
+	appConfig, err := config.ReadConfigFile()
 
-	gin.SetMode(gin.TestMode)
+	if err != nil {
+		t.Logf("Application Configuration: Configuration is missing! Using Test Configuration. Message: %#v", err)
+
+		appConfig = config.AppConfig{
+			Component:   "test",
+			Project:     "Gin Blog",
+			Description: "Web Blog API with GoLang",
+			WebRoot:     "/",
+		}
+
+		appConfig.Auth.SetDefaults(appConfig.Project)
+	}
 
-	if appConfig, err = config.ReadConfigFile(); err != nil {
-		t.Fatalf("Application Configuration: Configuration is missing! Message: %#v", err)
+	return appConfig
+}
+
+// newTestHandler - Creates the Request Handlers on the Storage selected by GINBLOG_TEST_STORAGE
+// The SQLite Databases are migrated and closed at the End of the Test.
+func newTestHandler(t *testing.T) *controllers.Handler {
+	var appConfig config.AppConfig
+
+	switch storage := os.Getenv(TESTSTORAGE); storage {
+	case "", "memory":
+		return NewMemoryHandler()
+	case "sqlite":
+		appConfig.DB = config.DBConfig{Driver: config.DB_DRIVER_SQLITE, Name: config.DB_SQLITE_MEMORY}
+	case "sqlite-file":
+		appConfig.DB = config.DBConfig{Driver: config.DB_DRIVER_SQLITE, Name: filepath.Join(t.TempDir(), "blog.db")}
+	default:
+		t.Fatalf("Test Storage '%s': Storage is unknown! Use 'memory', 'sqlite' or 'sqlite-file'", storage)
 	}
 
-	if db, err = ConnectDatabase(&appConfig); err != nil {
-		t.Fatalf("Database Connection: Connection failed! Message: %#v", err)
+	appConfig.SetDefaults()
+
+	db, err := ConnectDatabase(&appConfig)
+
+	if err != nil {
+		t.Fatalf("Test Storage: Database could not be opened! Message: %v", err)
 	}
 
-	if err = InitializeDatabase(db); err != nil {
-		t.Fatalf("Database Setup: Setup failed! Message: %#v", err)
+	t.Cleanup(func() { CloseDatabase(db) })
+
+	if _, err = migrations.NewMigrator(db).Migrate(); err != nil {
+		t.Fatalf("Test Storage: Database could not be migrated! Message: %v", err)
 	}
 
-	router := RegisterRoutes(&appConfig)
+	handler := controllers.NewHandler(repository.NewGormStorage(db))
+
+	handler.Files = repository.NewMemoryFileStore()
+
+	return handler
+}
+
+func TestDisplayArticles(t *testing.T) {
+	var appConfig config.AppConfig
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
 
 	//-------------------------------------
 	// Create test data
 
-	// Create 1 test user
-	db.Create(&testUser)
+	// Create 1 test user which was deleted by a former test
+	testUser.Model = gorm.Model{}
+
+	handler.Users.Create(&testUser)
 
 	// Create 3 articles
 	for idx, article := range testArticles {
 		// Set the user id of the test user
 		article.UserID = testUser.ID
 
-		db.Create(&article)
+		handler.Articles.Create(&article)
 
 		// Set assigned article id
 		testArticles[idx].ID = article.ID
@@ -97,14 +167,20 @@ func TestDisplayArticles(t *testing.T) {
 
 	fmt.Printf("Request %s '%s ? %s' - Body:\n'%#v'\n", req.Method, req.URL.Path, req.URL.RawQuery, res.Body.String())
 
-	var displayedArticles []model.DisplayedArticle
+	var articleList controllers.ArticleListSuccess
 
-	err = json.Unmarshal(res.Body.Bytes(), &displayedArticles)
+	err = json.Unmarshal(res.Body.Bytes(), &articleList)
 
 	if err != nil {
 		t.Errorf("Request %s '%s ? %s': Response is invalid JSON! Message: %#v", req.Method, req.URL.Path, req.URL.RawQuery, err)
 	}
 
+	if articleList.Pagination.Total != int64(len(testArticles)) {
+		t.Errorf("Article List: Total '%d' but expected '%d'", articleList.Pagination.Total, len(testArticles))
+	}
+
+	displayedArticles := articleList.Articles
+
 	for idx, displayed := range displayedArticles {
 		// Build the Article Lookup Map
 		resDisplayedArticles[displayed.ID] = &displayedArticles[idx]
@@ -146,9 +222,234 @@ func TestDisplayArticles(t *testing.T) {
 
 	// Delete Test Articles
 	for _, article := range testArticles {
-		db.Delete(&article, article.ID)
+		handler.Articles.Delete(&article)
 	}
 
 	// Delete Test User
-	db.Delete(&testUser, testUser.ID)
+	handler.Users.Delete(&testUser)
+}
+
+func TestDisplayArticlesPages(t *testing.T) {
+	var appConfig config.AppConfig
+	var articleList controllers.ArticleListSuccess
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create test data
+
+	// Create 1 test user which was deleted by a former test
+	testUser.Model = gorm.Model{}
+
+	handler.Users.Create(&testUser)
+
+	// Create 3 articles
+	for idx, article := range testArticles {
+		// Set the user id of the test user
+		article.UserID = testUser.ID
+
+		handler.Articles.Create(&article)
+
+		// Set assigned article id
+		testArticles[idx].ID = article.ID
+	}
+
+	//-------------------------------------
+	// Test first Page sorted by Title
+
+	res := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", appConfig.WebRoot+"articles?per_page=2&sort=-title", nil)
+	router.ServeHTTP(res, req)
+
+	if res.Code != 200 {
+		t.Errorf("Request %s '%s ? %s': HTTP Status Code '%d'; expected 200", req.Method, req.URL.Path, req.URL.RawQuery, res.Code)
+	}
+
+	if err = json.Unmarshal(res.Body.Bytes(), &articleList); err != nil {
+		t.Errorf("Request %s '%s ? %s': Response is invalid JSON! Message: %#v", req.Method, req.URL.Path, req.URL.RawQuery, err)
+	}
+
+	if len(articleList.Articles) != 2 {
+		t.Fatalf("Article List: Count '%d' but expected '2'", len(articleList.Articles))
+	}
+
+	if articleList.Articles[0].ID != testArticles[2].ID || articleList.Articles[1].ID != testArticles[1].ID {
+		t.Errorf("Article List: Articles are not sorted by Title descending: %#v", articleList.Articles)
+	}
+
+	if articleList.Pagination.PageCount != 2 || articleList.Pagination.Next == "" || articleList.Pagination.Prev != "" {
+		t.Errorf("Article List: Pagination '%#v' is invalid for the first Page", articleList.Pagination)
+	}
+
+	//-------------------------------------
+	// Test next Page Link
+
+	res = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", articleList.Pagination.Next, nil)
+	router.ServeHTTP(res, req)
+
+	articleList = controllers.ArticleListSuccess{}
+
+	if err = json.Unmarshal(res.Body.Bytes(), &articleList); err != nil {
+		t.Errorf("Request %s '%s ? %s': Response is invalid JSON! Message: %#v", req.Method, req.URL.Path, req.URL.RawQuery, err)
+	}
+
+	if len(articleList.Articles) != 1 || articleList.Articles[0].ID != testArticles[0].ID {
+		t.Errorf("Article List: Articles '%#v' but expected only Article '%d'", articleList.Articles, testArticles[0].ID)
+	}
+
+	if articleList.Pagination.Current != 2 || articleList.Pagination.Next != "" || articleList.Pagination.Prev == "" {
+		t.Errorf("Article List: Pagination '%#v' is invalid for the last Page", articleList.Pagination)
+	}
+
+	//-------------------------------------
+	// Test Author Filter
+
+	res = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", appConfig.WebRoot+"articles?author=unknown-author", nil)
+	router.ServeHTTP(res, req)
+
+	articleList = controllers.ArticleListSuccess{}
+
+	if err = json.Unmarshal(res.Body.Bytes(), &articleList); err != nil {
+		t.Errorf("Request %s '%s ? %s': Response is invalid JSON! Message: %#v", req.Method, req.URL.Path, req.URL.RawQuery, err)
+	}
+
+	if articleList.Pagination.Total != 0 || len(articleList.Articles) != 0 {
+		t.Errorf("Article List: Unknown Author has Articles: %#v", articleList.Articles)
+	}
+
+	//-------------------------------------
+	// Test invalid Parameters
+
+	for _, rawQuery := range []string{"sort=content", "page=0", "page=9223372036854775807", "per_page=1000", "from=yesterday"} {
+		res = httptest.NewRecorder()
+		req, _ = http.NewRequest("GET", appConfig.WebRoot+"articles?"+rawQuery, nil)
+		router.ServeHTTP(res, req)
+
+		if res.Code != http.StatusUnprocessableEntity {
+			t.Errorf("Request %s '%s ? %s': HTTP Status Code '%d'; expected 422", req.Method, req.URL.Path, req.URL.RawQuery, res.Code)
+		}
+	}
+}
+
+func TestDisplayAuthorArticles(t *testing.T) {
+	var appConfig config.AppConfig
+	var articleList controllers.ArticleListSuccess
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create test data
+
+	// Create 2 test users
+	otherUser := model.User{Name: "Test User No. 2", Slug: "user-2", Login: "user-2", Email: "user-2@email.com"}
+
+	testUser.Model = gorm.Model{}
+
+	handler.Users.Create(&testUser)
+	handler.Users.Create(&otherUser)
+
+	// Create 3 articles of which the last belongs to the other user
+	for idx, article := range testArticles {
+		article.UserID = testUser.ID
+
+		if idx == len(testArticles)-1 {
+			article.UserID = otherUser.ID
+		}
+
+		handler.Articles.Create(&article)
+
+		// Set assigned article id
+		testArticles[idx].ID = article.ID
+	}
+
+	//-------------------------------------
+	// Test Articles by User ID and by Author Slug
+
+	for _, path := range []string{fmt.Sprintf("users/%d/articles", testUser.ID), "authors/" + testUser.Slug + "/articles"} {
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", appConfig.WebRoot+path, nil)
+		router.ServeHTTP(res, req)
+
+		if res.Code != 200 {
+			t.Errorf("Request %s '%s ? %s': HTTP Status Code '%d'; expected 200", req.Method, req.URL.Path, req.URL.RawQuery, res.Code)
+		}
+
+		articleList = controllers.ArticleListSuccess{}
+
+		if err = json.Unmarshal(res.Body.Bytes(), &articleList); err != nil {
+			t.Errorf("Request %s '%s ? %s': Response is invalid JSON! Message: %#v", req.Method, req.URL.Path, req.URL.RawQuery, err)
+		}
+
+		if articleList.Author == nil || articleList.Author.ID != testUser.ID || articleList.Author.Slug != testUser.Slug {
+			t.Errorf("Request %s '%s': Author '%#v' but expected '%s'", req.Method, req.URL.Path, articleList.Author, testUser.Slug)
+		}
+
+		if len(articleList.Articles) != len(testArticles)-1 {
+			t.Errorf("Request %s '%s': Count '%d' but expected '%d'", req.Method, req.URL.Path, len(articleList.Articles), len(testArticles)-1)
+		}
+
+		for _, displayed := range articleList.Articles {
+			if displayed.Author != testUser.Name || displayed.AuthorSlug != testUser.Slug {
+				t.Errorf("Article (%d) '%s': Author '%s' but expected '%s'", displayed.ID, displayed.Slug, displayed.AuthorSlug, testUser.Slug)
+			}
+		}
+	}
+
+	//-------------------------------------
+	// Test Authors in the Article List
+
+	res := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", appConfig.WebRoot+"articles", nil)
+	router.ServeHTTP(res, req)
+
+	articleList = controllers.ArticleListSuccess{}
+
+	if err = json.Unmarshal(res.Body.Bytes(), &articleList); err != nil {
+		t.Errorf("Request %s '%s ? %s': Response is invalid JSON! Message: %#v", req.Method, req.URL.Path, req.URL.RawQuery, err)
+	}
+
+	if articleList.Author != nil {
+		t.Errorf("Article List: Author '%#v' but expected none", articleList.Author)
+	}
+
+	for _, displayed := range articleList.Articles {
+		expectedSlug := testUser.Slug
+
+		if displayed.ID == testArticles[len(testArticles)-1].ID {
+			expectedSlug = otherUser.Slug
+		}
+
+		if displayed.AuthorSlug != expectedSlug {
+			t.Errorf("Article (%d) '%s': Author '%s' but expected '%s'", displayed.ID, displayed.Slug, displayed.AuthorSlug, expectedSlug)
+		}
+	}
+
+	//-------------------------------------
+	// Test unknown Authors
+
+	for _, path := range []string{"users/999/articles", "authors/unknown-author/articles"} {
+		res := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", appConfig.WebRoot+path, nil)
+		router.ServeHTTP(res, req)
+
+		if res.Code != http.StatusNotFound {
+			t.Errorf("Request %s '%s ? %s': HTTP Status Code '%d'; expected 404", req.Method, req.URL.Path, req.URL.RawQuery, res.Code)
+		}
+	}
 }
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -23,6 +24,7 @@ var testEditor model.User = model.User{
 	Login:    "editor-1",
 	Email:    "editor-1@email.com",
 	Password: "editor.pass",
+	Role:     model.RoleEditor,
 }
 
 // testArticle - Articles that will be created as test data
@@ -35,28 +37,19 @@ var testArticle = model.Article{
 
 func TestCreateArticle(t *testing.T) {
 	var appConfig config.AppConfig
-	var db *gorm.DB
 	var articleJSON []byte
 	var token string
 	var err error
 
 	gin.SetMode(gin.TestMode)
 
-	if appConfig, err = config.ReadConfigFile(); err != nil {
-		t.Fatalf("Application Configuration: Configuration is missing! Message: %#v", err)
-	}
+	appConfig = readTestConfig(t)
 
-	if db, err = ConnectDatabase(&appConfig); err != nil {
-		t.Fatalf("Database Connection: Connection failed! Message: %#v", err)
-	}
-
-	if err = InitializeDatabase(db); err != nil {
-		t.Fatalf("Articles Migration: Migration failed! Message: %#v", err)
-	}
+	handler := newTestHandler(t)
 
 	router := gin.Default()
 
-	controllers.RegisterArticleRoutes(router, &appConfig)
+	controllers.RegisterArticleRoutes(router, &appConfig, handler)
 
 	//-------------------------------------
 	// Create Editor User
@@ -64,10 +57,13 @@ func TestCreateArticle(t *testing.T) {
 	loginPassword := testEditor.Password
 
 	if !strings.HasPrefix(testEditor.Password, "*") {
-		testEditor.Password = model.EncryptPassword(testEditor.Password, model.ENCRYPTIONSALT)
+		testEditor.Password = model.EncryptPassword(testEditor.Password, appConfig.Auth.PasswordPepper)
 	}
 
-	db.Create(&testEditor)
+	// Reset Editor User
+	testEditor.Model = gorm.Model{}
+
+	handler.Users.Create(&testEditor)
 
 	testEditor.Password = loginPassword
 
@@ -76,7 +72,7 @@ func TestCreateArticle(t *testing.T) {
 	//-------------------------------------
 	// Test Article Create Route
 
-	token, err = loginUser(router, &testEditor, &appConfig, t)
+	token, err = loginUser(router, handler, &testEditor, &appConfig, t)
 
 	if err != nil {
 		t.Errorf("Login (%d) '%s': failed! Message: %#v", testEditor.ID, testEditor.Login, err)
@@ -104,7 +100,7 @@ func TestCreateArticle(t *testing.T) {
 
 	fmt.Printf("Request %s '%s ? %s' - Body:\n'%#v'\n", req.Method, req.URL.Path, req.URL.RawQuery, res.Body.String())
 
-	var createdArticle model.Article
+	var createdArticle model.DisplayedArticle
 
 	err = json.Unmarshal(res.Body.Bytes(), &createdArticle)
 
@@ -130,8 +126,562 @@ func TestCreateArticle(t *testing.T) {
 	// Clean Up test data
 
 	// Delete Test Article
-	db.Delete(&testArticle, testArticle.ID)
+	handler.Articles.Delete(&testArticle)
 
 	// Delete Test User
-	db.Delete(&testEditor, testEditor.ID)
+	handler.Users.Delete(&testEditor)
+}
+
+func TestArticlePermissions(t *testing.T) {
+	var appConfig config.AppConfig
+	var createdArticle model.DisplayedArticle
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Users with different Roles
+
+	roleUsers := map[model.Role]*model.User{}
+	tokens := map[string]string{}
+
+	for _, login := range []string{"admin", "editor", "author", "other-author", "reader"} {
+		role := model.Role(strings.TrimPrefix(login, "other-"))
+		user := model.User{Name: "Test " + login, Slug: login, Login: login, Email: login + "@email.com", Role: role}
+
+		if user.Password, err = model.HashPassword(login + ".pass"); err != nil {
+			t.Fatalf("User '%s': Password Hash failed! Message: %#v", login, err)
+		}
+
+		handler.Users.Create(&user)
+
+		user.Password = login + ".pass"
+
+		if _, ok := roleUsers[role]; !ok {
+			roleUsers[role] = &user
+		}
+
+		tokens[login] = requestLogin(router, &user, &appConfig, t).Token
+	}
+
+	newArticle := model.Article{Title: "Permission Article", Slug: "permission-article", Content: "Permission Article Content"}
+
+	//-------------------------------------
+	// Test Article Creation
+
+	if res := postJSON(router, appConfig.WebRoot+"articles", tokens["reader"], newArticle); res.Code != http.StatusForbidden {
+		t.Errorf("Create Article 'reader': HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	res := postJSON(router, appConfig.WebRoot+"articles", tokens["author"], newArticle)
+
+	if res.Code != 200 {
+		t.Fatalf("Create Article 'author': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	if err = json.Unmarshal(res.Body.Bytes(), &createdArticle); err != nil {
+		t.Errorf("Create Article 'author': Response is invalid JSON! Message: %#v", err)
+	}
+
+	articlePath := fmt.Sprintf("%sarticles/%d", appConfig.WebRoot, createdArticle.ID)
+
+	//-------------------------------------
+	// Test Article Ownership
+
+	update := model.Article{Title: "Permission Article - Updated"}
+
+	if res := sendJSON(router, "PUT", articlePath, tokens["other-author"], update); res.Code != http.StatusForbidden {
+		t.Errorf("Update Article 'other-author': HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	if res := sendJSON(router, "DELETE", articlePath, tokens["other-author"], nil); res.Code != http.StatusForbidden {
+		t.Errorf("Delete Article 'other-author': HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	reassign := model.Article{UserID: roleUsers[model.RoleReader].ID}
+
+	if res := sendJSON(router, "PUT", articlePath, tokens["author"], reassign); res.Code != http.StatusForbidden {
+		t.Errorf("Reassign Article 'author': HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	if res := sendJSON(router, "PUT", articlePath, tokens["author"], update); res.Code != 200 {
+		t.Errorf("Update Article 'author': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	if res := sendJSON(router, "PUT", articlePath, tokens["editor"], update); res.Code != 200 {
+		t.Errorf("Update Article 'editor': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	//-------------------------------------
+	// Test User Management
+
+	if res := sendJSON(router, "GET", appConfig.WebRoot+"users", tokens["author"], nil); res.Code != http.StatusForbidden {
+		t.Errorf("Display Users 'author': HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	authorPath := fmt.Sprintf("%susers/%d", appConfig.WebRoot, roleUsers[model.RoleAuthor].ID)
+
+	if res := sendJSON(router, "PUT", authorPath, tokens["author"], model.User{Role: model.RoleAdmin}); res.Code != http.StatusForbidden {
+		t.Errorf("Promote User 'author': HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	if res := sendJSON(router, "DELETE", authorPath, tokens["editor"], nil); res.Code != http.StatusForbidden {
+		t.Errorf("Delete User 'editor': HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	adminPath := fmt.Sprintf("%susers/%d", appConfig.WebRoot, roleUsers[model.RoleAdmin].ID)
+
+	if res := sendJSON(router, "DELETE", adminPath, tokens["admin"], nil); res.Code != http.StatusForbidden {
+		t.Errorf("Delete User 'admin': HTTP Status Code '%d' for the own Account; expected 403", res.Code)
+	}
+
+	if res := sendJSON(router, "DELETE", articlePath, tokens["author"], nil); res.Code != 200 {
+		t.Errorf("Delete Article 'author': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+}
+
+func TestArticleSlugs(t *testing.T) {
+	var appConfig config.AppConfig
+	var createdArticle model.DisplayedArticle
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Author
+
+	author := model.User{Name: "Test Slug Author", Slug: "slug-author", Login: "slug-author", Email: "slug-author@email.com", Role: model.RoleAuthor}
+
+	if author.Password, err = model.HashPassword("slug-author.pass"); err != nil {
+		t.Fatalf("User '%s': Password Hash failed! Message: %#v", author.Login, err)
+	}
+
+	handler.Users.Create(&author)
+
+	author.Password = "slug-author.pass"
+
+	token := requestLogin(router, &author, &appConfig, t).Token
+
+	//-------------------------------------
+	// Test generated Slugs
+
+	newArticle := model.Article{Title: "Über die Straße & das Café", Content: "Slug Article Content"}
+
+	for _, expectedSlug := range []string{"ueber-die-strasse-das-cafe", "ueber-die-strasse-das-cafe-2"} {
+		res := postJSON(router, appConfig.WebRoot+"articles", token, newArticle)
+
+		if res.Code != 200 {
+			t.Fatalf("Create Article '%s': HTTP Status Code '%d'; expected 200", newArticle.Title, res.Code)
+		}
+
+		if err = json.Unmarshal(res.Body.Bytes(), &createdArticle); err != nil {
+			t.Errorf("Create Article '%s': Response is invalid JSON! Message: %#v", newArticle.Title, err)
+		}
+
+		if createdArticle.Slug != expectedSlug {
+			t.Errorf("Create Article '%s': Slug '%s' but expected '%s'", newArticle.Title, createdArticle.Slug, expectedSlug)
+		}
+	}
+
+	newArticle.Title = "日本語"
+
+	if res := postJSON(router, appConfig.WebRoot+"articles", token, newArticle); res.Code != 200 {
+		t.Errorf("Create Article '%s': HTTP Status Code '%d'; expected 200", newArticle.Title, res.Code)
+	} else if json.Unmarshal(res.Body.Bytes(), &createdArticle); createdArticle.Slug != "article" {
+		t.Errorf("Create Article '%s': Slug '%s' but expected 'article'", newArticle.Title, createdArticle.Slug)
+	}
+
+	//-------------------------------------
+	// Test supplied Slugs
+
+	for _, slug := range []string{"ueber-die-strasse-das-cafe", "Not a Slug", "double--hyphen"} {
+		newArticle.Slug = slug
+
+		if res := postJSON(router, appConfig.WebRoot+"articles", token, newArticle); res.Code != http.StatusUnprocessableEntity {
+			t.Errorf("Create Article with Slug '%s': HTTP Status Code '%d'; expected 422", slug, res.Code)
+		}
+	}
+
+	articlePath := fmt.Sprintf("%sarticles/%d", appConfig.WebRoot, createdArticle.ID)
+
+	if res := sendJSON(router, "PUT", articlePath, token, model.Article{Slug: "ueber-die-strasse-das-cafe-2"}); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Update Article (%d): HTTP Status Code '%d' for a taken Slug; expected 422", createdArticle.ID, res.Code)
+	}
+
+	if res := sendJSON(router, "PUT", articlePath, token, model.Article{Slug: "japanese-article"}); res.Code != 200 {
+		t.Errorf("Update Article (%d): HTTP Status Code '%d'; expected 200", createdArticle.ID, res.Code)
+	}
+}
+
+func TestSlugRedirects(t *testing.T) {
+	var appConfig config.AppConfig
+	var createdArticle model.DisplayedArticle
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Test Data
+
+	author := model.User{Name: "Test Redirect Author", Slug: "redirect-author", Login: "redirect-author", Email: "redirect-author@email.com", Role: model.RoleAuthor}
+
+	if author.Password, err = model.HashPassword("redirect-author.pass"); err != nil {
+		t.Fatalf("User '%s': Password Hash failed! Message: %#v", author.Login, err)
+	}
+
+	handler.Users.Create(&author)
+
+	author.Password = "redirect-author.pass"
+
+	token := requestLogin(router, &author, &appConfig, t).Token
+
+	res := postJSON(router, appConfig.WebRoot+"articles", token, model.Article{Title: "Redirect Article", Slug: "first-slug", Content: "Redirect Article Content"})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &createdArticle); err != nil || res.Code != 200 {
+		t.Fatalf("Create Article 'first-slug': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	articlePath := fmt.Sprintf("%sarticles/%d", appConfig.WebRoot, createdArticle.ID)
+
+	//-------------------------------------
+	// Test Article Slug Routes
+
+	// Drafts are only visible to their Author
+	if res = sendJSON(router, "GET", appConfig.WebRoot+"articles/by-slug/first-slug", "", nil); res.Code != http.StatusNotFound {
+		t.Errorf("Display Article 'first-slug': HTTP Status Code '%d' for an anonymous Request; expected 404", res.Code)
+	}
+
+	if res = sendJSON(router, "GET", appConfig.WebRoot+"articles/by-slug/first-slug", token, nil); res.Code != 200 {
+		t.Errorf("Display Article 'first-slug': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	if res = sendJSON(router, "GET", appConfig.WebRoot+"articles/by-slug/unknown-slug", token, nil); res.Code != http.StatusNotFound {
+		t.Errorf("Display Article 'unknown-slug': HTTP Status Code '%d'; expected 404", res.Code)
+	}
+
+	if res = sendJSON(router, "PUT", articlePath, token, model.Article{Slug: "second-slug"}); res.Code != 200 {
+		t.Errorf("Update Article 'second-slug': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"articles/by-slug/first-slug?fields=id", token, nil)
+
+	if expected := appConfig.WebRoot + "articles/by-slug/second-slug?fields=id"; res.Code != http.StatusMovedPermanently || res.Header().Get("Location") != expected {
+		t.Errorf("Display Article 'first-slug': HTTP Status Code '%d' with Location '%s'; expected 301 to '%s'", res.Code, res.Header().Get("Location"), expected)
+	}
+
+	// The former Slug stays reserved for the Article
+	if res = postJSON(router, appConfig.WebRoot+"articles", token, model.Article{Title: "Other Article", Slug: "first-slug"}); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Create Article 'first-slug': HTTP Status Code '%d' for a former Slug; expected 422", res.Code)
+	}
+
+	if res = sendJSON(router, "PUT", articlePath, token, model.Article{Slug: "first-slug"}); res.Code != 200 {
+		t.Errorf("Update Article 'first-slug': HTTP Status Code '%d' for the own former Slug; expected 200", res.Code)
+	}
+
+	if res = sendJSON(router, "GET", appConfig.WebRoot+"articles/by-slug/first-slug", token, nil); res.Code != 200 {
+		t.Errorf("Display Article 'first-slug': HTTP Status Code '%d' after the Slug was taken again; expected 200", res.Code)
+	}
+
+	if res = sendJSON(router, "GET", appConfig.WebRoot+"articles/by-slug/second-slug", token, nil); res.Code != http.StatusMovedPermanently {
+		t.Errorf("Display Article 'second-slug': HTTP Status Code '%d'; expected 301", res.Code)
+	}
+
+	//-------------------------------------
+	// Test User Slug Routes
+
+	authorPath := fmt.Sprintf("%susers/%d", appConfig.WebRoot, author.ID)
+
+	if res = sendJSON(router, "PUT", authorPath, token, model.User{Slug: "renamed-author"}); res.Code != 200 {
+		t.Errorf("Update User 'renamed-author': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	if res = sendJSON(router, "GET", appConfig.WebRoot+"users/by-slug/renamed-author", "", nil); res.Code != 200 {
+		t.Errorf("Display User 'renamed-author': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"users/by-slug/redirect-author", "", nil)
+
+	if expected := appConfig.WebRoot + "users/by-slug/renamed-author"; res.Code != http.StatusMovedPermanently || res.Header().Get("Location") != expected {
+		t.Errorf("Display User 'redirect-author': HTTP Status Code '%d' with Location '%s'; expected 301 to '%s'", res.Code, res.Header().Get("Location"), expected)
+	}
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"authors/redirect-author/articles?page=1", "", nil)
+
+	if expected := appConfig.WebRoot + "authors/renamed-author/articles?page=1"; res.Code != http.StatusMovedPermanently || res.Header().Get("Location") != expected {
+		t.Errorf("Display Author Articles 'redirect-author': HTTP Status Code '%d' with Location '%s'; expected 301 to '%s'", res.Code, res.Header().Get("Location"), expected)
+	}
+}
+
+func TestArticleWorkflow(t *testing.T) {
+	var appConfig config.AppConfig
+	var article model.DisplayedArticle
+	var articleList controllers.ArticleListSuccess
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Users with different Roles
+
+	tokens := map[string]string{}
+
+	for _, login := range []string{"editor", "author"} {
+		user := model.User{Name: "Test " + login, Slug: login, Login: login, Email: login + "@email.com", Role: model.Role(login)}
+
+		if user.Password, err = model.HashPassword(login + ".pass"); err != nil {
+			t.Fatalf("User '%s': Password Hash failed! Message: %#v", login, err)
+		}
+
+		handler.Users.Create(&user)
+
+		user.Password = login + ".pass"
+
+		tokens[login] = requestLogin(router, &user, &appConfig, t).Token
+	}
+
+	//-------------------------------------
+	// Test Drafts
+
+	res := postJSON(router, appConfig.WebRoot+"articles", tokens["author"], model.Article{Title: "Workflow Article", Content: "Workflow Article Content"})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &article); err != nil || res.Code != 200 {
+		t.Fatalf("Create Article 'author': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if article.Status != model.StatusDraft || article.PublishTime != "" {
+		t.Errorf("Create Article 'author': Status '%s' published at '%s' but expected an unpublished Draft", article.Status, article.PublishTime)
+	}
+
+	articlePath := fmt.Sprintf("%sarticles/%d", appConfig.WebRoot, article.ID)
+
+	listCounts := map[string]int64{"": 0, "author": 1, "editor": 1}
+
+	for login, expected := range listCounts {
+		res = sendJSON(router, "GET", appConfig.WebRoot+"articles?status=draft", tokens[login], nil)
+
+		if err = json.Unmarshal(res.Body.Bytes(), &articleList); err != nil || articleList.Pagination.Total != expected {
+			t.Errorf("Display Articles '%s': Total '%d' Drafts but expected '%d'", login, articleList.Pagination.Total, expected)
+		}
+	}
+
+	if res = sendJSON(router, "GET", articlePath, "", nil); res.Code != http.StatusNotFound {
+		t.Errorf("Display Article (%d): HTTP Status Code '%d' for an anonymous Request; expected 404", article.ID, res.Code)
+	}
+
+	//-------------------------------------
+	// Test Review and Scheduling
+
+	if res = sendJSON(router, "PUT", articlePath, tokens["author"], model.Article{Status: model.StatusPublished}); res.Code != http.StatusForbidden {
+		t.Errorf("Publish Article 'author': HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	if res = sendJSON(router, "PUT", articlePath, tokens["author"], model.Article{Status: model.StatusReview}); res.Code != 200 {
+		t.Errorf("Review Article 'author': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	pastTime := time.Now().Add(-time.Hour)
+
+	if res = sendJSON(router, "PUT", articlePath, tokens["editor"], model.Article{Status: model.StatusScheduled, PublishedAt: &pastTime}); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Schedule Article 'editor': HTTP Status Code '%d' for a past Publish Time; expected 422", res.Code)
+	}
+
+	publishTime := time.Now().Add(time.Hour)
+
+	res = sendJSON(router, "PUT", articlePath, tokens["editor"], model.Article{Status: model.StatusPublished, PublishedAt: &publishTime})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &article); err != nil || res.Code != 200 || article.Status != model.StatusScheduled {
+		t.Errorf("Schedule Article 'editor': HTTP Status Code '%d'; Status '%s' but expected 'scheduled'", res.Code, article.Status)
+	}
+
+	if res = sendJSON(router, "GET", articlePath, "", nil); res.Code != http.StatusNotFound {
+		t.Errorf("Display Article (%d): HTTP Status Code '%d' before the Publish Time; expected 404", article.ID, res.Code)
+	}
+
+	//-------------------------------------
+	// Test Publisher
+
+	if published := PublishScheduled(handler.Articles, publishTime.Add(time.Minute)); published != 1 {
+		t.Errorf("Publisher: '%d' Articles were published but expected 1", published)
+	}
+
+	res = sendJSON(router, "GET", articlePath, "", nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &article); err != nil || res.Code != 200 || article.Status != model.StatusPublished {
+		t.Errorf("Display Article (%d): HTTP Status Code '%d'; Status '%s' but expected 'published'", article.ID, res.Code, article.Status)
+	}
+
+	if res = sendJSON(router, "PUT", articlePath, tokens["author"], model.Article{Status: model.StatusDraft}); res.Code != http.StatusForbidden {
+		t.Errorf("Withdraw Article 'author': HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	if res = sendJSON(router, "PUT", articlePath, tokens["author"], model.Article{Content: "Workflow Article Content - Updated"}); res.Code != 200 {
+		t.Errorf("Update Article 'author': HTTP Status Code '%d' for the Content of the published Article; expected 200", res.Code)
+	}
+}
+
+func TestArticleRevisions(t *testing.T) {
+	var appConfig config.AppConfig
+	var createdArticle model.DisplayedArticle
+	var restoredArticle model.DisplayedArticle
+	var revisionList controllers.RevisionListSuccess
+	var revision model.DisplayedRevision
+	var revisionDiff controllers.RevisionDiffSuccess
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Test Data
+
+	author := model.User{Name: "Test Revision Author", Slug: "revision-author", Login: "revision-author", Email: "revision-author@email.com", Role: model.RoleAuthor}
+	reader := model.User{Name: "Test Revision Reader", Slug: "revision-reader", Login: "revision-reader", Email: "revision-reader@email.com", Role: model.RoleReader}
+
+	for _, user := range []*model.User{&author, &reader} {
+		password := user.Login + ".pass"
+
+		if user.Password, err = model.HashPassword(password); err != nil {
+			t.Fatalf("User '%s': Password Hash failed! Message: %#v", user.Login, err)
+		}
+
+		handler.Users.Create(user)
+
+		user.Password = password
+	}
+
+	authorToken := requestLogin(router, &author, &appConfig, t).Token
+	readerToken := requestLogin(router, &reader, &appConfig, t).Token
+
+	res := postJSON(router, appConfig.WebRoot+"articles", authorToken, model.Article{Title: "Revision Article", Slug: "revision-first", Content: "First Line\nSecond Line\n"})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &createdArticle); err != nil || res.Code != 200 {
+		t.Fatalf("Create Article 'revision-first': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	articlePath := fmt.Sprintf("%sarticles/%d", appConfig.WebRoot, createdArticle.ID)
+
+	if res = sendJSON(router, "PUT", articlePath, authorToken, model.Article{Slug: "revision-second", Content: "First Line\nChanged Line\n"}); res.Code != 200 {
+		t.Fatalf("Update Article 'revision-second': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	//-------------------------------------
+	// Test Revision List
+
+	res = sendJSON(router, "GET", articlePath+"/revisions", authorToken, nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &revisionList); err != nil || res.Code != 200 {
+		t.Fatalf("Display Revisions: HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if len(revisionList.Revisions) != 2 {
+		t.Fatalf("Display Revisions: Revision Count '%d'; expected 2", len(revisionList.Revisions))
+	}
+
+	if first := revisionList.Revisions[0]; first.Number != 1 || first.Slug != "revision-first" || first.Editor != author.Name {
+		t.Errorf("Display Revisions: First Revision '%#v' does not match the created Article", first)
+	}
+
+	if res = sendJSON(router, "GET", articlePath+"/revisions", readerToken, nil); res.Code != http.StatusNotFound {
+		t.Errorf("Display Revisions: HTTP Status Code '%d' for a Reader of a Draft; expected 404", res.Code)
+	}
+
+	if res = sendJSON(router, "GET", articlePath+"/revisions", "", nil); res.Code != http.StatusUnauthorized {
+		t.Errorf("Display Revisions: HTTP Status Code '%d' for an anonymous Request; expected 401", res.Code)
+	}
+
+	//-------------------------------------
+	// Test Revision Diff
+
+	res = sendJSON(router, "GET", articlePath+"/revisions/2/diff", authorToken, nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &revisionDiff); err != nil || res.Code != 200 {
+		t.Fatalf("Display Revision Diff: HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	for _, line := range []string{"--- revision/1", "+++ revision/2", "-Slug: revision-first", "+Slug: revision-second", "-Second Line", "+Changed Line", " First Line"} {
+		if !strings.Contains(revisionDiff.Diff, line+"\n") {
+			t.Errorf("Display Revision Diff: Diff lacks the Line '%s':\n%s", line, revisionDiff.Diff)
+		}
+	}
+
+	if res = sendJSON(router, "GET", articlePath+"/revisions/2/diff?from=7", authorToken, nil); res.Code != http.StatusNotFound {
+		t.Errorf("Display Revision Diff: HTTP Status Code '%d' for an unknown Revision; expected 404", res.Code)
+	}
+
+	//-------------------------------------
+	// Test Diff and Content Limits
+
+	maxComparisons := model.DIFFMAXCOMPARISONS
+	model.DIFFMAXCOMPARISONS = 0
+
+	if res = sendJSON(router, "GET", articlePath+"/revisions/2/diff", authorToken, nil); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Display Revision Diff: HTTP Status Code '%d' for too many changed Lines; expected 422", res.Code)
+	}
+
+	model.DIFFMAXCOMPARISONS = maxComparisons
+
+	if res = sendJSON(router, "PUT", articlePath, authorToken, model.Article{Content: strings.Repeat("Line\n", model.MAXCONTENTSIZE/5+1)}); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Update Article: HTTP Status Code '%d' for too large Content; expected 422", res.Code)
+	}
+
+	//-------------------------------------
+	// Test Revision Restore
+
+	res = postJSON(router, articlePath+"/revisions/1/restore", authorToken, nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &restoredArticle); err != nil || res.Code != 200 {
+		t.Fatalf("Restore Revision 1: HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if restoredArticle.Slug != "revision-first" || restoredArticle.Content != "First Line\nSecond Line\n" {
+		t.Errorf("Restore Revision 1: Article '%#v' does not match the Revision", restoredArticle)
+	}
+
+	res = sendJSON(router, "GET", articlePath+"/revisions/3", authorToken, nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &revision); err != nil || res.Code != 200 {
+		t.Fatalf("Display Revision 3: HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if revision.RestoredFrom != 1 || revision.Slug != "revision-first" {
+		t.Errorf("Display Revision 3: Revision '%#v' was not restored from Revision 1", revision)
+	}
+
+	// Restoring the former Slug removes its Redirect
+	if res = sendJSON(router, "GET", appConfig.WebRoot+"articles/by-slug/revision-second", authorToken, nil); res.Code != http.StatusMovedPermanently {
+		t.Errorf("Display Article 'revision-second': HTTP Status Code '%d'; expected 301", res.Code)
+	}
+
+	if res = sendJSON(router, "GET", articlePath+"/revisions/1/diff?from=3", authorToken, nil); res.Code != 200 || !strings.Contains(res.Body.String(), `"Diff":""`) {
+		t.Errorf("Display Revision Diff: HTTP Status Code '%d'; expected 200 with an empty Diff of identical Revisions", res.Code)
+	}
 }
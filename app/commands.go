@@ -0,0 +1,215 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gin-blog/config"
+	"gin-blog/migrations"
+)
+
+// USAGE - Description of the Command Line Commands
+const USAGE string = `Usage: gin-blog [-<setting> value ...] [command]
+
+Commands:
+  serve                      Run the Web Server (default)
+  migrate [up]               Apply all pending Database Migrations
+  migrate rollback [steps]   Revert the last applied Migrations (default: 1)
+  migrate status             List the Migrations and whether they are applied
+  config [dump]              Print the effective Configuration with masked Secrets
+  config check [file]        Validate the Configuration without starting the Server
+
+Settings:
+  Each Setting of the Configuration File can be overridden by a Flag named
+  after its YAML Keys like -server.port 8080 or -database.host db.local
+  and by an Environment Variable like GINBLOG_SERVER_PORT.
+  The Variable GINBLOG_DATABASE_PASSWORD_FILE reads the Value from a File.
+`
+
+// Run - Dispatches the Command Line Arguments to the Command
+// The Flags in front of the Command override the Settings of the Configuration.
+func Run(args []string) error {
+	overrides, args, err := config.ParseFlags("gin-blog", args)
+
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Print(USAGE)
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("Command Line is invalid! Message: %v\n%s", err, USAGE)
+	}
+
+	if len(args) == 0 {
+		return Start(overrides)
+	}
+
+	switch args[0] {
+	case "serve":
+		return Start(overrides)
+	case "migrate":
+		return RunMigrate(args[1:], overrides)
+	case "config":
+		return RunConfig(args[1:], overrides)
+	case "help", "-h", "--help":
+		fmt.Print(USAGE)
+
+		return nil
+	}
+
+	return fmt.Errorf("Command '%s': Command is unknown!\n%s", args[0], USAGE)
+}
+
+// RunMigrate - Applies, reverts or lists the Database Migrations
+func RunMigrate(args []string, overrides config.Overrides) error {
+	var action string = "up"
+	var steps int = 1
+	var err error
+
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	if action == "rollback" && len(args) > 1 {
+		if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+			return fmt.Errorf("Command 'migrate rollback': Steps '%s' are invalid!", args[1])
+		}
+	}
+
+	if action != "up" && action != "rollback" && action != "status" {
+		return fmt.Errorf("Command 'migrate %s': Command is unknown!\n%s", action, USAGE)
+	}
+
+	db, err := openDatabase(overrides)
+
+	if err != nil {
+		return err
+	}
+
+	defer CloseDatabase(db)
+
+	migrator := migrations.NewMigrator(db)
+
+	switch action {
+	case "rollback":
+		reverted, err := migrator.Rollback(steps)
+
+		for _, migration := range reverted {
+			fmt.Printf("Migration '%s': reverted\n", migration.String())
+		}
+
+		if err == nil && len(reverted) == 0 {
+			fmt.Println("Migrations: No Migration is applied")
+		}
+
+		return err
+	case "status":
+		statuses, err := migrator.Status()
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+
+			if status.AppliedAt != nil {
+				appliedAt = "applied at " + status.AppliedAt.Format(time.RFC3339)
+			}
+
+			fmt.Printf("Migration '%s': %s\n", status.Migration.String(), appliedAt)
+		}
+
+		return err
+	}
+
+	applied, err := migrator.Migrate()
+
+	for _, migration := range applied {
+		fmt.Printf("Migration '%s': applied\n", migration.String())
+	}
+
+	if err == nil && len(applied) == 0 {
+		fmt.Println("Migrations: Database Schema is up to date")
+	}
+
+	return err
+}
+
+// RunConfig - Prints or validates the effective Configuration after all Overrides
+// The Secrets are masked so that the Output can be shared.
+func RunConfig(args []string, overrides config.Overrides) error {
+	var action string = "dump"
+
+	if len(args) > 0 {
+		action = args[0]
+	}
+
+	if action != "dump" && action != "check" {
+		return fmt.Errorf("Command 'config %s': Command is unknown!\n%s", action, USAGE)
+	}
+
+	if action == "check" && len(args) > 1 {
+		if overrides == nil {
+			overrides = make(config.Overrides)
+		}
+
+		overrides["config_file"] = args[1]
+	}
+
+	appConfig, err := config.LoadConfig(overrides)
+
+	if err != nil {
+		return fmt.Errorf("Config is invalid! Message: %v\n", err)
+	}
+
+	if action == "check" {
+		if err = appConfig.Validate(); err != nil {
+			return err
+		}
+
+		source := appConfig.ConfigFile
+
+		if source == "" {
+			source = "Environment"
+		}
+
+		fmt.Printf("Configuration '%s': Configuration is valid\n", source)
+
+		return nil
+	}
+
+	dump, err := appConfig.Dump()
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(dump)
+
+	return nil
+}
+
+// openDatabase - Connects to the configured Database
+func openDatabase(overrides config.Overrides) (*gorm.DB, error) {
+	appConfig, err := config.LoadConfig(overrides)
+
+	if err != nil {
+		return nil, fmt.Errorf("Config is missing! Message: %v\n", err)
+	}
+
+	if err = appConfig.DB.Validate(); err != nil {
+		return nil, err
+	}
+
+	db, err := ConnectDatabaseRetry(context.Background(), &appConfig)
+
+	if err != nil {
+		return nil, fmt.Errorf("Database Connection failed! Message: %v\n", err)
+	}
+
+	return db, nil
+}
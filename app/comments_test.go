@@ -0,0 +1,187 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/config"
+	"gin-blog/controllers"
+	"gin-blog/model"
+)
+
+func TestComments(t *testing.T) {
+	var appConfig config.AppConfig
+	var createdArticle model.DisplayedArticle
+	var comment model.DisplayedComment
+	var commentList controllers.CommentListSuccess
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Test Data
+
+	editor := model.User{Name: "Test Comment Editor", Slug: "comment-editor", Login: "comment-editor", Email: "comment-editor@email.com", Role: model.RoleEditor}
+	author := model.User{Name: "Test Comment Author", Slug: "comment-author", Login: "comment-author", Email: "comment-author@email.com", Role: model.RoleAuthor}
+	reader := model.User{Name: "Test Comment Reader", Slug: "comment-reader", Login: "comment-reader", Email: "comment-reader@email.com", Role: model.RoleReader}
+
+	for _, user := range []*model.User{&editor, &author, &reader} {
+		password := user.Login + ".pass"
+
+		if user.Password, err = model.HashPassword(password); err != nil {
+			t.Fatalf("User '%s': Password Hash failed! Message: %#v", user.Login, err)
+		}
+
+		handler.Users.Create(user)
+
+		user.Password = password
+	}
+
+	editorToken := requestLogin(router, &editor, &appConfig, t).Token
+	authorToken := requestLogin(router, &author, &appConfig, t).Token
+	readerToken := requestLogin(router, &reader, &appConfig, t).Token
+
+	res := postJSON(router, appConfig.WebRoot+"articles", authorToken, model.Article{Title: "Commented Article", Content: "Commented Article Content"})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &createdArticle); err != nil || res.Code != 200 {
+		t.Fatalf("Create Article 'Commented Article': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	articlePath := fmt.Sprintf("%sarticles/%d", appConfig.WebRoot, createdArticle.ID)
+	commentsPath := articlePath + "/comments"
+
+	// Drafts cannot be commented anonymously
+	if res = postJSON(router, commentsPath, "", model.CommentData{AuthorName: "Anonymous", Content: "Draft Comment"}); res.Code != http.StatusNotFound {
+		t.Errorf("Create Comment: HTTP Status Code '%d' for a Draft; expected 404", res.Code)
+	}
+
+	if res = sendJSON(router, "PUT", articlePath, editorToken, model.Article{Status: model.StatusPublished}); res.Code != 200 {
+		t.Fatalf("Update Article 'Commented Article': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	//-------------------------------------
+	// Test Comment Creation
+
+	if res = postJSON(router, commentsPath, "", model.CommentData{Content: "Nameless Comment"}); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Create Comment: HTTP Status Code '%d' for an anonymous Comment without Name; expected 422", res.Code)
+	}
+
+	res = postJSON(router, commentsPath, "", model.CommentData{AuthorName: "Anonymous Reader", AuthorEmail: "anonymous@email.com", Content: "First Comment"})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &comment); err != nil || res.Code != 200 {
+		t.Fatalf("Create Comment 'First Comment': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if comment.Status != model.CommentPending || comment.Author != "Anonymous Reader" {
+		t.Errorf("Create Comment 'First Comment': Comment '%#v' is not pending", comment)
+	}
+
+	firstID := comment.ID
+
+	if res = postJSON(router, commentsPath, "", model.CommentData{ParentID: &firstID, AuthorName: "Anonymous Reader", Content: "Early Reply"}); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Create Comment: HTTP Status Code '%d' for a Reply to a pending Comment; expected 422", res.Code)
+	}
+
+	// Replies of the Users whose Comments are approved at once need an approved Parent as well
+	if res = postJSON(router, commentsPath, authorToken, model.CommentData{ParentID: &firstID, Content: "Early Author Reply"}); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Create Comment: HTTP Status Code '%d' for an Author Reply to a pending Comment; expected 422", res.Code)
+	}
+
+	//-------------------------------------
+	// Test Moderation Queue
+
+	if res = sendJSON(router, "GET", commentsPath+"/moderation", readerToken, nil); res.Code != http.StatusForbidden {
+		t.Errorf("Display Moderation Queue: HTTP Status Code '%d' for a Reader; expected 403", res.Code)
+	}
+
+	res = sendJSON(router, "GET", commentsPath+"/moderation", authorToken, nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &commentList); err != nil || res.Code != 200 || commentList.CommentCount != 1 {
+		t.Fatalf("Display Moderation Queue: HTTP Status Code '%d'; Comment Count '%d'; expected 1 pending Comment", res.Code, commentList.CommentCount)
+	}
+
+	if res = sendJSON(router, "PUT", fmt.Sprintf("%s/%d", commentsPath, firstID), authorToken, model.CommentModeration{Status: "hidden"}); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Moderate Comment (ID: '%d'): HTTP Status Code '%d' for an invalid Status; expected 422", firstID, res.Code)
+	}
+
+	if res = sendJSON(router, "PUT", fmt.Sprintf("%s/%d", commentsPath, firstID), authorToken, model.CommentModeration{Status: model.CommentApproved}); res.Code != 200 {
+		t.Errorf("Moderate Comment (ID: '%d'): HTTP Status Code '%d'; expected 200", firstID, res.Code)
+	}
+
+	res = postJSON(router, commentsPath, readerToken, model.CommentData{ParentID: &firstID, Content: "Reader Reply"})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &comment); err != nil || res.Code != 200 || comment.Author != reader.Name {
+		t.Fatalf("Create Comment 'Reader Reply': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	replyID := comment.ID
+
+	if res = sendJSON(router, "PUT", fmt.Sprintf("%s/%d", commentsPath, replyID), editorToken, model.CommentModeration{Status: model.CommentApproved}); res.Code != 200 {
+		t.Errorf("Moderate Comment (ID: '%d'): HTTP Status Code '%d'; expected 200", replyID, res.Code)
+	}
+
+	// Comments of the Article Author are approved at once
+	res = postJSON(router, commentsPath, authorToken, model.CommentData{Content: "Author Comment"})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &comment); err != nil || res.Code != 200 || comment.Status != model.CommentApproved {
+		t.Errorf("Create Comment 'Author Comment': HTTP Status Code '%d'; Comment '%#v' is not approved", res.Code, comment)
+	}
+
+	//-------------------------------------
+	// Test Comment Tree
+
+	res = sendJSON(router, "GET", commentsPath, "", nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &commentList); err != nil || res.Code != 200 {
+		t.Fatalf("Display Comments: HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if commentList.CommentCount != 3 || len(commentList.Comments) != 2 || len(commentList.Comments[0].Replies) != 1 || commentList.Comments[0].Replies[0].ID != replyID {
+		t.Errorf("Display Comments: Comment Tree '%#v' does not match", commentList.Comments)
+	}
+
+	res = sendJSON(router, "GET", articlePath, "", nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &createdArticle); err != nil || createdArticle.CommentCount != 3 {
+		t.Errorf("Display Article (ID: '%d'): Comment Count '%d'; expected 3", createdArticle.ID, createdArticle.CommentCount)
+	}
+
+	if res = sendJSON(router, "PUT", fmt.Sprintf("%s/%d", commentsPath, replyID), authorToken, model.CommentModeration{Status: model.CommentSpam}); res.Code != 200 {
+		t.Errorf("Moderate Comment (ID: '%d'): HTTP Status Code '%d'; expected 200", replyID, res.Code)
+	}
+
+	res = sendJSON(router, "GET", commentsPath, "", nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &commentList); err != nil || commentList.CommentCount != 2 || len(commentList.Comments[0].Replies) != 0 {
+		t.Errorf("Display Comments: Comment Tree '%#v' still contains the Spam", commentList.Comments)
+	}
+
+	// Approved Replies to a Comment marked as Spam are lifted to the Top Level
+	authorCommentID := commentList.Comments[1].ID
+
+	res = postJSON(router, commentsPath, authorToken, model.CommentData{ParentID: &authorCommentID, Content: "Author Reply"})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &comment); err != nil || res.Code != 200 {
+		t.Fatalf("Create Comment 'Author Reply': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if res = sendJSON(router, "PUT", fmt.Sprintf("%s/%d", commentsPath, authorCommentID), editorToken, model.CommentModeration{Status: model.CommentSpam}); res.Code != 200 {
+		t.Errorf("Moderate Comment (ID: '%d'): HTTP Status Code '%d'; expected 200", authorCommentID, res.Code)
+	}
+
+	res = sendJSON(router, "GET", commentsPath, "", nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &commentList); err != nil || commentList.CommentCount != 2 || len(commentList.Comments) != 2 || commentList.Comments[1].ID != comment.ID {
+		t.Errorf("Display Comments: Comment Tree '%#v' does not contain the Reply to the Spam", commentList.Comments)
+	}
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/config"
+	"gin-blog/model"
+)
+
+func TestArticleContent(t *testing.T) {
+	var appConfig config.AppConfig
+	var article model.DisplayedArticle
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Test Data
+
+	author := model.User{Name: "Test Content Author", Slug: "content-author", Login: "content-author", Email: "content-author@email.com", Role: model.RoleAuthor}
+	password := author.Login + ".pass"
+
+	if author.Password, err = model.HashPassword(password); err != nil {
+		t.Fatalf("User '%s': Password Hash failed! Message: %#v", author.Login, err)
+	}
+
+	handler.Users.Create(&author)
+
+	author.Password = password
+
+	authorToken := requestLogin(router, &author, &appConfig, t).Token
+
+	//-------------------------------------
+	// Test Markdown Content
+
+	markdown := "# Introduction\n\nSome **bold** words and a [link](https://example.com) " +
+		"and a [trap](javascript:alert(1)).\n\n<script>alert('x')</script>\n\n" +
+		"## Details\n\n" + strings.Repeat("word ", 450) + "\n\n## Details\n\n<p onclick=\"alert(1)\">Clickable</p>\n"
+
+	res := postJSON(router, appConfig.WebRoot+"articles", authorToken, model.Article{Title: "Markdown Article", Content: markdown})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &article); err != nil || res.Code != 200 {
+		t.Fatalf("Create Article 'Markdown Article': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if article.Format != model.FormatMarkdown || article.Content != markdown {
+		t.Errorf("Create Article 'Markdown Article': Format '%s' or Source Content does not match", article.Format)
+	}
+
+	for _, expected := range []string{`<h1 id="introduction">Introduction</h1>`, `<strong>bold</strong>`, `<a href="https://example.com" rel="nofollow noopener">link</a>`, `<h2 id="details-2">Details</h2>`, `<p>Clickable</p>`} {
+		if !strings.Contains(article.HTML, expected) {
+			t.Errorf("Create Article 'Markdown Article': HTML does not contain '%s'", expected)
+		}
+	}
+
+	for _, unexpected := range []string{"<script", "javascript:", "onclick"} {
+		if strings.Contains(article.HTML, unexpected) {
+			t.Errorf("Create Article 'Markdown Article': HTML contains '%s'", unexpected)
+		}
+	}
+
+	if len(article.TOC) != 3 || article.TOC[1] != (model.TOCEntry{Level: 2, ID: "details", Title: "Details"}) {
+		t.Errorf("Create Article 'Markdown Article': Table of Contents '%#v' does not match", article.TOC)
+	}
+
+	if !strings.HasPrefix(article.Excerpt, "Introduction Some bold words and a link and a trap.") || !strings.HasSuffix(article.Excerpt, "…") {
+		t.Errorf("Create Article 'Markdown Article': Excerpt '%s' does not match", article.Excerpt)
+	}
+
+	if article.ReadingTime != 3 {
+		t.Errorf("Create Article 'Markdown Article': Reading Time '%d'; expected 3 Minutes", article.ReadingTime)
+	}
+
+	//-------------------------------------
+	// Test HTML and plain Content
+
+	res = postJSON(router, appConfig.WebRoot+"articles", authorToken, model.Article{Title: "Plain Article", Content: "First <b>Line</b>\nSecond Line\n\nNext Paragraph", Format: model.FormatPlain})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &article); err != nil || res.Code != 200 {
+		t.Fatalf("Create Article 'Plain Article': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if article.HTML != "<p>First &lt;b&gt;Line&lt;/b&gt;<br/>\nSecond Line</p>\n<p>Next Paragraph</p>\n" || article.ReadingTime != 1 {
+		t.Errorf("Create Article 'Plain Article': HTML '%s' does not match", article.HTML)
+	}
+
+	articlePath := fmt.Sprintf("%sarticles/%d", appConfig.WebRoot, article.ID)
+
+	res = sendJSON(router, "PUT", articlePath, authorToken, model.Article{Content: `<h2 style="color: red">Title</h2><img src="data:image/png;base64,AA" alt="Image"><iframe src="https://example.com"></iframe>`, Format: model.FormatHTML})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &article); err != nil || res.Code != 200 {
+		t.Fatalf("Update Article 'Plain Article': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if article.Format != model.FormatHTML || article.HTML != `<h2 id="title">Title</h2><img alt="Image"/>` {
+		t.Errorf("Update Article 'Plain Article': HTML '%s' does not match", article.HTML)
+	}
+
+	if res = sendJSON(router, "PUT", articlePath, authorToken, model.Article{Format: "latex"}); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Update Article 'Plain Article': HTTP Status Code '%d' for an invalid Format; expected 422", res.Code)
+	}
+}
@@ -0,0 +1,201 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"gin-blog/config"
+	"gin-blog/migrations"
+)
+
+// MYSQLTLSCONFIG - Name of the TLS Configuration of the MySQL Driver with the Root Certificate
+const MYSQLTLSCONFIG string = "gin-blog"
+
+// DBMAXRETRYDELAY - Longest Delay between two Connection Attempts at Startup
+var DBMAXRETRYDELAY time.Duration = 30 * time.Second
+
+// ConnectDatabase - Opens the Connection Pool of the configured Database
+func ConnectDatabase(config *config.AppConfig) (*gorm.DB, error) {
+	dialector, err := newDialector(&config.DB)
+
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{TranslateError: true})
+
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if db.Dialector.Name() == "sqlite" {
+		// SQLite allows only one Writer and forgets a Database in Memory with its last Connection
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetMaxIdleConns(1)
+
+		return db, nil
+	}
+
+	// Tune the Connection Pool
+	sqlDB.SetMaxOpenConns(int(config.DB.MaxOpenConns))
+	sqlDB.SetMaxIdleConns(int(config.DB.MaxIdleConns))
+	sqlDB.SetConnMaxLifetime(time.Duration(config.DB.ConnMaxLifetime) * time.Second)
+	sqlDB.SetConnMaxIdleTime(time.Duration(config.DB.ConnMaxIdleTime) * time.Second)
+
+	return db, nil
+}
+
+// newDialector - Creates the GORM Dialector of the configured Driver
+func newDialector(dbConfig *config.DBConfig) (gorm.Dialector, error) {
+	switch dbConfig.Driver {
+	case "", config.DB_DRIVER_POSTGRES:
+		return postgres.Open(dbConfig.DSN()), nil
+	case config.DB_DRIVER_MYSQL:
+		dsn, err := mysqlDSN(dbConfig)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return mysql.Open(dsn), nil
+	case config.DB_DRIVER_SQLITE:
+		return sqlite.Open(sqliteDSN(dbConfig)), nil
+	}
+
+	return nil, fmt.Errorf("Database Driver '%s': Driver is unknown!", dbConfig.Driver)
+}
+
+// mysqlDSN - Connection String of the MySQL Database
+// The SSL Modes of PostgreSQL are mapped onto the TLS Settings of the MySQL Driver.
+// The Root Certificate is registered as TLS Configuration "gin-blog".
+func mysqlDSN(dbConfig *config.DBConfig) (string, error) {
+	mysqlConfig := mysqldriver.NewConfig()
+
+	mysqlConfig.User = dbConfig.User
+	mysqlConfig.Passwd = dbConfig.Password
+	mysqlConfig.Net = "tcp"
+	mysqlConfig.Addr = net.JoinHostPort(dbConfig.Host, strconv.FormatUint(uint64(dbConfig.Port), 10))
+	mysqlConfig.DBName = dbConfig.Name
+	mysqlConfig.Params = map[string]string{"charset": "utf8mb4"}
+	mysqlConfig.ParseTime = true
+	mysqlConfig.Timeout = time.Duration(dbConfig.ConnectTimeout) * time.Second
+
+	switch dbConfig.SSLMode {
+	case "", "disable":
+		mysqlConfig.TLSConfig = "false"
+	case "allow", "prefer":
+		mysqlConfig.TLSConfig = "preferred"
+	case "require":
+		mysqlConfig.TLSConfig = "skip-verify"
+	default:
+		mysqlConfig.TLSConfig = "true"
+	}
+
+	if dbConfig.SSLRootCert != "" && mysqlConfig.TLSConfig == "true" {
+		rootCert, err := ioutil.ReadFile(dbConfig.SSLRootCert)
+
+		if err != nil {
+			return "", fmt.Errorf("Root Certificate '%s': File cannot be read! Message: %v", dbConfig.SSLRootCert, err)
+		}
+
+		rootCAs := x509.NewCertPool()
+
+		if !rootCAs.AppendCertsFromPEM(rootCert) {
+			return "", fmt.Errorf("Root Certificate '%s': File contains no Certificate!", dbConfig.SSLRootCert)
+		}
+
+		if err = mysqldriver.RegisterTLSConfig(MYSQLTLSCONFIG, &tls.Config{RootCAs: rootCAs, ServerName: dbConfig.Host}); err != nil {
+			return "", err
+		}
+
+		mysqlConfig.TLSConfig = MYSQLTLSCONFIG
+	}
+
+	return mysqlConfig.FormatDSN(), nil
+}
+
+// sqliteDSN - Connection String of the SQLite Database
+// The Foreign Keys are enforced and a locked Database is waited for up to the Connect Timeout.
+func sqliteDSN(dbConfig *config.DBConfig) string {
+	name := "file:" + dbConfig.Name
+
+	if dbConfig.Name == config.DB_SQLITE_MEMORY {
+		name = "file::memory:"
+	}
+
+	return fmt.Sprintf("%s?_foreign_keys=on&_busy_timeout=%d", name, dbConfig.ConnectTimeout*1000)
+}
+
+// ConnectDatabaseRetry - Connects to the Database and retries failed Connections
+// The Delay starts with the configured Retry Delay and doubles up to DBMAXRETRYDELAY
+// so that the Service waits for a slowly starting Database.
+func ConnectDatabaseRetry(ctx context.Context, config *config.AppConfig) (*gorm.DB, error) {
+	delay := time.Duration(config.DB.RetryDelay) * time.Second
+
+	for attempt := 0; ; attempt++ {
+		db, err := ConnectDatabase(config)
+
+		if err == nil || attempt >= config.DB.ConnectRetries {
+			return db, err
+		}
+
+		fmt.Printf("App - Database: Connection failed (Attempt %d of %d)! Retrying in %s. Message: %v\n",
+			attempt+1, config.DB.ConnectRetries+1, delay, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("Database Connection was cancelled! Message: %v", err)
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > DBMAXRETRYDELAY {
+			delay = DBMAXRETRYDELAY
+		}
+	}
+}
+
+// CloseDatabase - Closes the Connection Pool of the Database
+func CloseDatabase(db *gorm.DB) {
+	sqlDB, err := db.DB()
+
+	if err == nil {
+		err = sqlDB.Close()
+	}
+
+	if err != nil {
+		fmt.Printf("App - Database: Connections could not be closed! Message: %v\n", err)
+	}
+}
+
+// CheckDatabase - Refuses to operate on a Database with pending Migrations
+func CheckDatabase(db *gorm.DB) error {
+	pending, err := migrations.NewMigrator(db).Pending()
+
+	if err != nil {
+		return fmt.Errorf("Database Schema could not be checked! Message: %v", err)
+	}
+
+	if len(pending) != 0 {
+		return fmt.Errorf("Database Schema is outdated: %d Migrations are pending! Run the Command 'migrate' first", len(pending))
+	}
+
+	return nil
+}
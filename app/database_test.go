@@ -0,0 +1,206 @@
+package app
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gin-blog/config"
+	"gin-blog/migrations"
+	"gin-blog/model"
+	"gin-blog/repository"
+)
+
+func TestMigrations(t *testing.T) {
+	appConfig, err := config.ReadConfigFile()
+
+	if err != nil {
+		t.Skipf("Database Migrations: Configuration is missing! Message: %v", err)
+	}
+
+	if err = appConfig.DB.Validate(); err != nil {
+		t.Skipf("Database Migrations: Database is not configured! Message: %v", err)
+	}
+
+	db, err := ConnectDatabase(&appConfig)
+
+	if err != nil {
+		t.Skipf("Database Migrations: Database is not available! Message: %v", err)
+	}
+
+	migrator := migrations.NewMigrator(db)
+
+	//-------------------------------------
+	// Test Migrate
+
+	if _, err = migrator.Migrate(); err != nil {
+		t.Fatalf("Database Migrations: Migrate failed! Message: %v", err)
+	}
+
+	if err = CheckDatabase(db); err != nil {
+		t.Errorf("Database Migrations: %v", err)
+	}
+
+	//-------------------------------------
+	// Test Rollback and Status
+
+	reverted, err := migrator.Rollback(1)
+
+	if err != nil || len(reverted) != 1 {
+		t.Fatalf("Database Migrations: Rollback reverted %d Migrations; expected 1. Message: %v", len(reverted), err)
+	}
+
+	pending, err := migrator.Pending()
+
+	if err != nil || len(pending) != 1 || pending[0].Version != reverted[0].Version {
+		t.Errorf("Database Migrations: Pending %v but expected '%s'. Message: %v", pending, reverted[0].String(), err)
+	}
+
+	if err = CheckDatabase(db); err == nil {
+		t.Errorf("Database Migrations: Database Check succeeded with a pending Migration")
+	}
+
+	//-------------------------------------
+	// Restore the Database Schema
+
+	if applied, err := migrator.Migrate(); err != nil || len(applied) != 1 {
+		t.Errorf("Database Migrations: Migrate applied %d Migrations; expected 1. Message: %v", len(applied), err)
+	}
+}
+
+func TestConnectDatabaseRetry(t *testing.T) {
+	// Reserve a Port where no Database is listening
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatalf("Database Retry: Listener could not be created! Message: %#v", err)
+	}
+
+	port := uint(listener.Addr().(*net.TCPAddr).Port)
+
+	listener.Close()
+
+	appConfig := config.AppConfig{DB: config.DBConfig{Host: "127.0.0.1", Port: port, Name: "blog", User: "blog", RetryDelay: 60}}
+	appConfig.SetDefaults()
+
+	//-------------------------------------
+	// Test cancelled Retry
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+
+	if _, err = ConnectDatabaseRetry(ctx, &appConfig); err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Errorf("Database Retry: Error '%v' but expected a cancelled Connection", err)
+	}
+
+	if elapsed := time.Since(started); elapsed > 10*time.Second {
+		t.Errorf("Database Retry: Cancellation took %s", elapsed)
+	}
+
+	//-------------------------------------
+	// Test without Retries
+
+	appConfig.DB.ConnectRetries = -1
+
+	if _, err = ConnectDatabaseRetry(context.Background(), &appConfig); err == nil || strings.Contains(err.Error(), "cancelled") {
+		t.Errorf("Database Retry: Error '%v' but expected the failed Connection", err)
+	}
+}
+
+func TestSQLiteMigrations(t *testing.T) {
+	appConfig := config.AppConfig{DB: config.DBConfig{Driver: config.DB_DRIVER_SQLITE, Name: filepath.Join(t.TempDir(), "blog.db")}}
+	appConfig.SetDefaults()
+
+	db, err := ConnectDatabase(&appConfig)
+
+	if err != nil {
+		t.Fatalf("SQLite Migrations: Database could not be opened! Message: %v", err)
+	}
+
+	defer CloseDatabase(db)
+
+	migrator := migrations.NewMigrator(db)
+
+	//-------------------------------------
+	// Test Migrate
+
+	if applied, err := migrator.Migrate(); err != nil || len(applied) != len(migrations.MIGRATIONS) {
+		t.Fatalf("SQLite Migrations: Migrate applied %d Migrations; expected %d. Message: %v", len(applied), len(migrations.MIGRATIONS), err)
+	}
+
+	if err = CheckDatabase(db); err != nil {
+		t.Errorf("SQLite Migrations: %v", err)
+	}
+
+	//-------------------------------------
+	// Test Rollback of all Migrations
+
+	if reverted, err := migrator.Rollback(len(migrations.MIGRATIONS)); err != nil || len(reverted) != len(migrations.MIGRATIONS) {
+		t.Fatalf("SQLite Migrations: Rollback reverted %d Migrations; expected %d. Message: %v", len(reverted), len(migrations.MIGRATIONS), err)
+	}
+
+	//-------------------------------------
+	// Test Migrate of the empty Database
+
+	if applied, err := migrator.Migrate(); err != nil || len(applied) != len(migrations.MIGRATIONS) {
+		t.Errorf("SQLite Migrations: Migrate applied %d Migrations; expected %d. Message: %v", len(applied), len(migrations.MIGRATIONS), err)
+	}
+}
+
+func TestSQLiteTransaction(t *testing.T) {
+	appConfig := config.AppConfig{DB: config.DBConfig{Driver: config.DB_DRIVER_SQLITE, Name: config.DB_SQLITE_MEMORY}}
+	appConfig.SetDefaults()
+
+	db, err := ConnectDatabase(&appConfig)
+
+	if err != nil {
+		t.Fatalf("SQLite Transaction: Database could not be opened! Message: %v", err)
+	}
+
+	defer CloseDatabase(db)
+
+	if _, err = migrations.NewMigrator(db).Migrate(); err != nil {
+		t.Fatalf("SQLite Transaction: Database could not be migrated! Message: %v", err)
+	}
+
+	storage := repository.NewGormStorage(db)
+
+	//-------------------------------------
+	// Test Rollback of a failed Operation
+
+	err = storage.Transaction(func(tx repository.Storage) error {
+		if err := tx.Tags.Create(&model.Tag{Name: "Reverted", Slug: "reverted"}); err != nil {
+			return err
+		}
+
+		return &repository.ConflictError{Message: "Article (Slug: 'reverted'): Slug is already taken!"}
+	})
+
+	if !repository.IsConflict(err) {
+		t.Errorf("SQLite Transaction: Error '%v' of the Operation is not returned", err)
+	}
+
+	if _, err = storage.Tags.GetBySlug("reverted"); !repository.IsNotFound(err) {
+		t.Errorf("SQLite Transaction: Tag 'reverted' of the failed Operation is kept. Message: %v", err)
+	}
+
+	//-------------------------------------
+	// Test Commit of a successful Operation
+
+	err = storage.Transaction(func(tx repository.Storage) error {
+		return tx.Tags.Create(&model.Tag{Name: "Committed", Slug: "committed"})
+	})
+
+	if err != nil {
+		t.Fatalf("SQLite Transaction: Operation failed! Message: %v", err)
+	}
+
+	if _, err = storage.Tags.GetBySlug("committed"); err != nil {
+		t.Errorf("SQLite Transaction: Tag 'committed' of the successful Operation is missing. Message: %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package app
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/config"
+	"gin-blog/model"
+)
+
+func TestFeeds(t *testing.T) {
+	var appConfig config.AppConfig
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Test Data
+
+	editor := model.User{Name: "Test Feed Editor", Slug: "feed-editor", Login: "feed-editor", Email: "feed-editor@email.com", Role: model.RoleEditor}
+	password := editor.Login + ".pass"
+
+	if editor.Password, err = model.HashPassword(password); err != nil {
+		t.Fatalf("User '%s': Password Hash failed! Message: %#v", editor.Login, err)
+	}
+
+	handler.Users.Create(&editor)
+
+	editor.Password = password
+
+	editorToken := requestLogin(router, &editor, &appConfig, t).Token
+
+	for _, article := range []model.Article{
+		{Title: "Published Feed Article", Content: "# Heading\n\nPublished *Content*", Status: model.StatusPublished, TagNames: []string{"Go"}},
+		{Title: "Second Feed Article", Content: "Second Content", Status: model.StatusPublished},
+		{Title: "Draft Feed Article", Content: "Draft Content", TagNames: []string{"Go"}},
+	} {
+		if res := postJSON(router, appConfig.WebRoot+"articles", editorToken, article); res.Code != 200 {
+			t.Fatalf("Create Article '%s': HTTP Status Code '%d'; expected 200", article.Title, res.Code)
+		}
+	}
+
+	//-------------------------------------
+	// Test Feed Formats
+
+	var rss struct {
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Title   string `xml:"title"`
+				Link    string `xml:"link"`
+				Content string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+
+	res := requestFeed(router, appConfig.WebRoot+"feed.rss", nil)
+
+	if err = xml.Unmarshal(res.Body.Bytes(), &rss); err != nil || res.Code != 200 || !strings.HasPrefix(res.Header().Get("Content-Type"), "application/rss+xml") {
+		t.Fatalf("Display Feed 'feed.rss': HTTP Status Code '%d'; Response is invalid RSS! Message: %#v", res.Code, err)
+	}
+
+	// The latest published Article comes first
+	if len(rss.Channel.Items) != 2 || rss.Channel.Items[0].Title != "Second Feed Article" || rss.Channel.Items[1].Link != "http://example.com"+appConfig.WebRoot+"articles/by-slug/published-feed-article" {
+		t.Errorf("Display Feed 'feed.rss': Items '%#v' do not match the published Articles", rss.Channel.Items)
+	}
+
+	if !strings.Contains(rss.Channel.Items[1].Content, "<em>Content</em>") {
+		t.Errorf("Display Feed 'feed.rss': Item Content '%s' is not rendered", rss.Channel.Items[1].Content)
+	}
+
+	var atom struct {
+		Entries []struct {
+			Title  string `xml:"title"`
+			Author string `xml:"author>name"`
+		} `xml:"entry"`
+	}
+
+	res = requestFeed(router, appConfig.WebRoot+"authors/feed-editor/feed.atom?sort=title", nil)
+
+	if err = xml.Unmarshal(res.Body.Bytes(), &atom); err != nil || res.Code != 200 || !strings.HasPrefix(res.Header().Get("Content-Type"), "application/atom+xml") {
+		t.Fatalf("Display Feed 'feed.atom': HTTP Status Code '%d'; Response is invalid Atom! Message: %#v", res.Code, err)
+	}
+
+	if len(atom.Entries) != 2 || atom.Entries[0].Title != "Published Feed Article" || atom.Entries[0].Author != editor.Name {
+		t.Errorf("Display Feed 'feed.atom': Entries '%#v' do not match the Articles of the Author", atom.Entries)
+	}
+
+	var jsonFeed struct {
+		Version string `json:"version"`
+		Items   []struct {
+			Title string   `json:"title"`
+			Tags  []string `json:"tags"`
+		} `json:"items"`
+	}
+
+	res = requestFeed(router, appConfig.WebRoot+"tags/go/feed.json", nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &jsonFeed); err != nil || res.Code != 200 || jsonFeed.Version != model.JSONFEEDVERSION {
+		t.Fatalf("Display Feed 'feed.json': HTTP Status Code '%d'; Response is invalid JSON Feed! Message: %#v", res.Code, err)
+	}
+
+	if len(jsonFeed.Items) != 1 || jsonFeed.Items[0].Title != "Published Feed Article" || len(jsonFeed.Items[0].Tags) != 1 {
+		t.Errorf("Display Feed 'feed.json': Items '%#v' do not match the published Articles of the Tag", jsonFeed.Items)
+	}
+
+	if res = requestFeed(router, appConfig.WebRoot+"tags/unknown/feed.json", nil); res.Code != http.StatusNotFound {
+		t.Errorf("Display Feed 'feed.json': HTTP Status Code '%d' for an unknown Tag; expected 404", res.Code)
+	}
+
+	//-------------------------------------
+	// Test Conditional Requests
+
+	res = requestFeed(router, appConfig.WebRoot+"feed.json", nil)
+
+	etag := res.Header().Get("ETag")
+	lastModified := res.Header().Get("Last-Modified")
+
+	if etag == "" || lastModified == "" {
+		t.Fatalf("Display Feed 'feed.json': ETag '%s' or Last-Modified '%s' is missing", etag, lastModified)
+	}
+
+	if res = requestFeed(router, appConfig.WebRoot+"feed.json", map[string]string{"If-None-Match": etag}); res.Code != http.StatusNotModified || res.Body.Len() != 0 {
+		t.Errorf("Display Feed 'feed.json': HTTP Status Code '%d' for the current ETag; expected 304", res.Code)
+	}
+
+	if res = requestFeed(router, appConfig.WebRoot+"feed.json", map[string]string{"If-None-Match": `"outdated"`}); res.Code != 200 {
+		t.Errorf("Display Feed 'feed.json': HTTP Status Code '%d' for an outdated ETag; expected 200", res.Code)
+	}
+
+	if res = requestFeed(router, appConfig.WebRoot+"feed.json", map[string]string{"If-Modified-Since": lastModified}); res.Code != http.StatusNotModified {
+		t.Errorf("Display Feed 'feed.json': HTTP Status Code '%d' for the current Modification Time; expected 304", res.Code)
+	}
+
+	if res = requestFeed(router, appConfig.WebRoot+"feed.json", map[string]string{"If-Modified-Since": "Mon, 02 Jan 2006 15:04:05 GMT"}); res.Code != 200 {
+		t.Errorf("Display Feed 'feed.json': HTTP Status Code '%d' for an earlier Modification Time; expected 200", res.Code)
+	}
+}
+
+func requestFeed(router *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	res := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", path, nil)
+
+	for name, value := range headers {
+		req.Header.Add(name, value)
+	}
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
@@ -27,53 +27,47 @@ var testLoginUser model.User = model.User{
 
 func TestLogin(t *testing.T) {
 	var appConfig config.AppConfig
-	var db *gorm.DB
 	var authUser *model.User
 	var token string
 	var err error
 
 	gin.SetMode(gin.TestMode)
 
-	if appConfig, err = config.ReadConfigFile(); err != nil {
-		t.Fatalf("Application Configuration: Configuration is missing! Message: %#v", err)
-	}
+	appConfig = readTestConfig(t)
 
-	if db, err = ConnectDatabase(&appConfig); err != nil {
-		t.Fatalf("Database Connection: Connection failed! Message: %#v", err)
-	}
-
-	if err = controllers.MigrateArticles(db); err != nil {
-		t.Fatalf("Articles Migration: Migration failed! Message: %#v", err)
-	}
+	handler := newTestHandler(t)
 
 	router := gin.Default()
 
-	//controllers.RegisterLoginRoutes(router, &appConfig)
+	//controllers.RegisterLoginRoutes(router, &appConfig, handler)
 
 	//-------------------------------------
 	// Create Login User
 
+	// Reset Login User
+	testLoginUser.Model = gorm.Model{}
+
 	loginPassword := testLoginUser.Password
 
 	if !strings.HasPrefix(testLoginUser.Password, "*") {
-		testLoginUser.Password = model.EncryptPassword(testLoginUser.Password, model.ENCRYPTIONSALT)
+		testLoginUser.Password = model.EncryptPassword(testLoginUser.Password, appConfig.Auth.PasswordPepper)
 	}
 
-	db.Create(&testLoginUser)
+	handler.Users.Create(&testLoginUser)
 
 	testLoginUser.Password = loginPassword
 
 	//-------------------------------------
 	// Test Article Create Route
 
-	token, err = loginUser(router, &testLoginUser, &appConfig, t)
+	token, err = loginUser(router, handler, &testLoginUser, &appConfig, t)
 
 	if err != nil {
 		t.Errorf("Login (%d) '%s': failed! Message: %#v", testLoginUser.ID, testLoginUser.Login, err)
 	}
 
 	if token != "" {
-		authUser, err = controllers.ValidateToken(token)
+		authUser, err = handler.ValidateToken(token)
 
 		if err != nil {
 			t.Errorf("Login (%d) '%s': Token is invalid! Message: %#v", testLoginUser.ID, testLoginUser.Login, err)
@@ -98,16 +92,237 @@ func TestLogin(t *testing.T) {
 	// Clean Up test data
 
 	// Delete Test User
-	db.Delete(&testLoginUser, testLoginUser.ID)
+	handler.Users.Delete(&testLoginUser)
+}
+
+func TestLoginRehash(t *testing.T) {
+	var appConfig config.AppConfig
+	var storedUser *model.User
+	var token string
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	//-------------------------------------
+	// Create Login User with a Legacy Password Hash
+
+	legacyUser := testLoginUser
+	legacyUser.Model = gorm.Model{}
+	legacyUser.Password = model.EncryptPassword(testLoginUser.Password, appConfig.Auth.PasswordPepper)
+
+	handler.Users.Create(&legacyUser)
+
+	legacyUser.Password = testLoginUser.Password
+
+	//-------------------------------------
+	// Test Login with the Legacy Hash
+
+	token, err = loginUser(gin.New(), handler, &legacyUser, &appConfig, t)
+
+	if err != nil || token == "" {
+		t.Errorf("Login (%d) '%s': Legacy Login failed! Message: %#v", legacyUser.ID, legacyUser.Login, err)
+	}
+
+	if storedUser, err = handler.Users.GetByID(legacyUser.ID); storedUser == nil || err != nil {
+		t.Fatalf("User (%d) '%s': User is missing! Message: %#v", legacyUser.ID, legacyUser.Login, err)
+	}
+
+	if !strings.HasPrefix(storedUser.Password, "$argon2id$") {
+		t.Errorf("User (%d) '%s': Password Hash '%s' was not renewed", legacyUser.ID, legacyUser.Login, storedUser.Password)
+	}
+
+	//-------------------------------------
+	// Test Login with the renewed Hash
+
+	token, err = loginUser(gin.New(), handler, &legacyUser, &appConfig, t)
+
+	if err != nil || token == "" {
+		t.Errorf("Login (%d) '%s': Login with renewed Hash failed! Message: %#v", legacyUser.ID, legacyUser.Login, err)
+	}
+
+	//-------------------------------------
+	// Test Login with a wrong Password
+
+	if storedUser.Auth(legacyUser.Login, legacyUser.Password+"-wrong", appConfig.Auth.PasswordPepper) {
+		t.Errorf("User (%d) '%s': Login with wrong Password succeeded", legacyUser.ID, legacyUser.Login)
+	}
+}
+
+func TestRefreshLogout(t *testing.T) {
+	var appConfig config.AppConfig
+	var login controllers.LoginSuccess
+	var refreshed controllers.LoginSuccess
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := gin.New()
+
+	//-------------------------------------
+	// Create Login User
+
+	sessionUser := testLoginUser
+	sessionUser.Model = gorm.Model{}
+
+	if sessionUser.Password, err = model.HashPassword(testLoginUser.Password); err != nil {
+		t.Fatalf("User '%s': Password Hash failed! Message: %#v", sessionUser.Login, err)
+	}
+
+	handler.Users.Create(&sessionUser)
+
+	sessionUser.Password = testLoginUser.Password
+
+	login = loginSession(router, handler, &sessionUser, &appConfig, t)
+
+	if login.Token == "" || login.RefreshToken == "" {
+		t.Fatalf("Login (%d) '%s': Tokens are missing: %#v", sessionUser.ID, sessionUser.Login, login)
+	}
+
+	//-------------------------------------
+	// Test Token Refresh
+
+	res := postJSON(router, appConfig.WebRoot+"login/refresh", "", controllers.RefreshRequest{RefreshToken: login.RefreshToken})
+
+	if res.Code != 200 {
+		t.Errorf("Refresh '%s': HTTP Status Code '%d'; expected 200", sessionUser.Login, res.Code)
+	}
+
+	if err = json.Unmarshal(res.Body.Bytes(), &refreshed); err != nil {
+		t.Errorf("Refresh '%s': Response is invalid JSON! Message: %#v", sessionUser.Login, err)
+	}
+
+	if refreshed.Token == "" || refreshed.RefreshToken == "" || refreshed.RefreshToken == login.RefreshToken {
+		t.Errorf("Refresh '%s': Tokens were not renewed: %#v", sessionUser.Login, refreshed)
+	}
+
+	if _, err = handler.ValidateToken(refreshed.Token); err != nil {
+		t.Errorf("Refresh '%s': Refreshed Token is invalid! Message: %#v", sessionUser.Login, err)
+	}
+
+	// A rotated Refresh Token cannot be used again
+	res = postJSON(router, appConfig.WebRoot+"login/refresh", "", controllers.RefreshRequest{RefreshToken: login.RefreshToken})
+
+	if res.Code != http.StatusUnauthorized {
+		t.Errorf("Refresh '%s': HTTP Status Code '%d' for a rotated Token; expected 401", sessionUser.Login, res.Code)
+	}
+
+	//-------------------------------------
+	// Test Logout
+
+	res = postJSON(router, appConfig.WebRoot+"logout", refreshed.Token, nil)
+
+	if res.Code != 200 {
+		t.Errorf("Logout '%s': HTTP Status Code '%d'; expected 200", sessionUser.Login, res.Code)
+	}
+
+	if _, err = handler.ValidateToken(refreshed.Token); err == nil {
+		t.Errorf("Logout '%s': Access Token is still valid", sessionUser.Login)
+	}
+
+	res = postJSON(router, appConfig.WebRoot+"login/refresh", "", controllers.RefreshRequest{RefreshToken: refreshed.RefreshToken})
+
+	if res.Code != http.StatusUnauthorized {
+		t.Errorf("Logout '%s': HTTP Status Code '%d' for a revoked Refresh Token; expected 401", sessionUser.Login, res.Code)
+	}
+
+	res = postJSON(router, appConfig.WebRoot+"logout", refreshed.Token, nil)
+
+	if res.Code != http.StatusUnauthorized {
+		t.Errorf("Logout '%s': HTTP Status Code '%d' for a revoked Access Token; expected 401", sessionUser.Login, res.Code)
+	}
 }
 
-func loginUser(router *gin.Engine, user *model.User, appConfig *config.AppConfig, t *testing.T) (string, error) {
+func TestPasswordHashes(t *testing.T) {
+	var appConfig config.AppConfig
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	// Without the Recovery Middleware a Panic fails the Test
+	router := gin.New()
+
+	controllers.RegisterUserRoutes(router, &appConfig, handler)
+
+	//-------------------------------------
+	// Create Login User
+
+	hashUser := model.User{Name: "Test Hash No. 1", Slug: "hash-1", Login: "hash-1", Email: "hash-1@email.com"}
+
+	if hashUser.Password, err = model.HashPassword("hash-1.pass"); err != nil {
+		t.Fatalf("User '%s': Password Hash failed! Message: %#v", hashUser.Login, err)
+	}
+
+	handler.Users.Create(&hashUser)
+
+	hashUser.Password = "hash-1.pass"
+
+	login := loginSession(router, handler, &hashUser, &appConfig, t)
+
+	//-------------------------------------
+	// Test Password which looks like a Hash
+
+	forgedHashes := []string{
+		"$argon2id$v=19$m=65536,t=0,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5",
+		"$argon2id$v=19$m=65536,t=1,p=0$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5",
+		"$argon2id$v=19$m=4294967295,t=1,p=1$c2FsdHNhbHQ$a2V5a2V5a2V5a2V5a2V5a2V5a2V5a2V5",
+		"$argon2id$v=19$m=65536,t=1,p=1$c2FsdHNhbHQ$",
+		"*",
+	}
+
+	for _, forged := range forgedHashes {
+		res := sendJSON(router, "PUT", fmt.Sprintf("%susers/%d", appConfig.WebRoot, hashUser.ID), login.Token, map[string]string{"password": forged})
+
+		if res.Code != http.StatusOK {
+			t.Errorf("Update User '%s': HTTP Status Code '%d'; expected 200", hashUser.Login, res.Code)
+		}
+
+		storedUser, err := handler.Users.GetByID(hashUser.ID)
+
+		if err != nil {
+			t.Fatalf("User (%d) '%s': User is missing! Message: %#v", hashUser.ID, hashUser.Login, err)
+		}
+
+		if valid, _, err := model.VerifyPassword(storedUser.Password, forged, ""); storedUser.Password == forged || !valid || err != nil {
+			t.Errorf("Update User '%s': Password '%s' was not hashed: '%s'", hashUser.Login, forged, storedUser.Password)
+		}
+	}
+
+	//-------------------------------------
+	// Test Login with a stored Hash of invalid Parameters
+
+	for _, forged := range forgedHashes[:4] {
+		storedUser, _ := handler.Users.GetByID(hashUser.ID)
+		storedUser.Password = forged
+
+		handler.Users.Save(storedUser)
+
+		res := postJSON(router, appConfig.WebRoot+"login", "", model.Login{Login: hashUser.Login, Password: "hash-1.pass"})
+
+		if res.Code != http.StatusUnauthorized {
+			t.Errorf("Login '%s' with Hash '%s': HTTP Status Code '%d'; expected 401", hashUser.Login, forged, res.Code)
+		}
+	}
+}
+
+func loginUser(router *gin.Engine, handler *controllers.Handler, user *model.User, appConfig *config.AppConfig, t *testing.T) (string, error) {
 	var loginJSON []byte
 	var err error
 
-	controllers.RegisterLoginRoutes(router, appConfig)
+	controllers.RegisterLoginRoutes(router, appConfig, handler)
 
-	login := model.Login{user.Login, user.Password}
+	login := model.Login{Login: user.Login, Password: user.Password}
 
 	loginJSON, err = json.Marshal(&login)
 
@@ -136,3 +351,50 @@ func loginUser(router *gin.Engine, user *model.User, appConfig *config.AppConfig
 
 	return loginResponse.Token, err
 }
+
+func loginSession(router *gin.Engine, handler *controllers.Handler, user *model.User, appConfig *config.AppConfig, t *testing.T) controllers.LoginSuccess {
+	controllers.RegisterLoginRoutes(router, appConfig, handler)
+
+	return requestLogin(router, user, appConfig, t)
+}
+
+// requestLogin - Logs the User in on a Router which has the Login Routes registered
+func requestLogin(router *gin.Engine, user *model.User, appConfig *config.AppConfig, t *testing.T) controllers.LoginSuccess {
+	var loginResponse controllers.LoginSuccess
+
+	res := postJSON(router, appConfig.WebRoot+"login", "", model.Login{Login: user.Login, Password: user.Password})
+
+	if res.Code != 200 {
+		t.Errorf("Login '%s': HTTP Status Code '%d'; expected 200", user.Login, res.Code)
+	}
+
+	if err := json.Unmarshal(res.Body.Bytes(), &loginResponse); err != nil {
+		t.Errorf("Login '%s': Response is invalid JSON! Message: %#v", user.Login, err)
+	}
+
+	return loginResponse
+}
+
+func postJSON(router *gin.Engine, path string, token string, payload interface{}) *httptest.ResponseRecorder {
+	return sendJSON(router, "POST", path, token, payload)
+}
+
+func sendJSON(router *gin.Engine, method string, path string, token string, payload interface{}) *httptest.ResponseRecorder {
+	var body []byte
+
+	if payload != nil {
+		body, _ = json.Marshal(payload)
+	}
+
+	res := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, strings.NewReader(string(body)))
+	req.Header.Add("Content-Type", "application/json")
+
+	if token != "" {
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
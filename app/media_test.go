@@ -0,0 +1,359 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/config"
+	"gin-blog/controllers"
+	"gin-blog/model"
+	"gin-blog/repository"
+)
+
+func TestMedia(t *testing.T) {
+	var appConfig config.AppConfig
+	var createdArticle model.DisplayedArticle
+	var media model.DisplayedMedia
+	var mediaList controllers.MediaListSuccess
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+	appConfig.Media.MaxSize = 16
+
+	handler := newTestHandler(t)
+	files := handler.Files.(*repository.MemoryFileStore)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Test Data
+
+	author := model.User{Name: "Test Media Author", Slug: "media-author", Login: "media-author", Email: "media-author@email.com", Role: model.RoleAuthor}
+	other := model.User{Name: "Test Media Other", Slug: "media-other", Login: "media-other", Email: "media-other@email.com", Role: model.RoleAuthor}
+	reader := model.User{Name: "Test Media Reader", Slug: "media-reader", Login: "media-reader", Email: "media-reader@email.com", Role: model.RoleReader}
+
+	for _, user := range []*model.User{&author, &other, &reader} {
+		password := user.Login + ".pass"
+
+		if user.Password, err = model.HashPassword(password); err != nil {
+			t.Fatalf("User '%s': Password Hash failed! Message: %#v", user.Login, err)
+		}
+
+		handler.Users.Create(user)
+
+		user.Password = password
+	}
+
+	authorToken := requestLogin(router, &author, &appConfig, t).Token
+	otherToken := requestLogin(router, &other, &appConfig, t).Token
+	readerToken := requestLogin(router, &reader, &appConfig, t).Token
+
+	res := postJSON(router, appConfig.WebRoot+"articles", authorToken, model.Article{Title: "Media Draft", Content: "Gallery"})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &createdArticle); err != nil || res.Code != 200 {
+		t.Fatalf("Create Article: HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	picture := newTestImage(t, 640, 480)
+
+	//-------------------------------------
+	// Test Upload
+
+	if res = postMedia(router, appConfig.WebRoot+"media", readerToken, "picture.png", picture, nil); res.Code != http.StatusForbidden {
+		t.Errorf("Upload Media as Reader: HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	if res = postMedia(router, appConfig.WebRoot+"media", authorToken, "notes.txt", []byte("Plain Text Notes"), nil); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Upload Text File: HTTP Status Code '%d'; expected 422", res.Code)
+	}
+
+	oversized := append([]byte("%PDF-1.4\n"), bytes.Repeat([]byte("0"), 20*1024)...)
+
+	if res = postMedia(router, appConfig.WebRoot+"media", authorToken, "large.pdf", oversized, nil); res.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Upload oversized File: HTTP Status Code '%d'; expected 413", res.Code)
+	}
+
+	if res = postMedia(router, appConfig.WebRoot+"media", otherToken, "picture.png", picture,
+		map[string]string{"article_id": fmt.Sprint(createdArticle.ID)}); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Upload Media to a foreign Draft: HTTP Status Code '%d'; expected 422", res.Code)
+	}
+
+	if files.Count() != 0 {
+		t.Errorf("Upload Media: %d Files were stored for refused Uploads", files.Count())
+	}
+
+	res = postMedia(router, appConfig.WebRoot+"media", authorToken, "../picture.png", picture,
+		map[string]string{"article_id": fmt.Sprint(createdArticle.ID)})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &media); err != nil || res.Code != 200 {
+		t.Fatalf("Upload Media: HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if media.ContentType != "image/png" || media.FileName != "picture.png" || media.Width != 640 || media.Height != 480 ||
+		media.ArticleID == nil || *media.ArticleID != createdArticle.ID || media.ThumbnailURL == "" {
+		t.Errorf("Upload Media: Media '%#v' does not match", media)
+	}
+
+	if files.Count() != 2 {
+		t.Errorf("Upload Media: %d Files were stored; expected File and Thumbnail", files.Count())
+	}
+
+	//-------------------------------------
+	// Test Download
+
+	res = sendJSON(router, "GET", media.URL, authorToken, nil)
+
+	if res.Code != 200 || !bytes.Equal(res.Body.Bytes(), picture) {
+		t.Errorf("Download Media: HTTP Status Code '%d'; File does not match", res.Code)
+	}
+
+	if res.Header().Get("Content-Type") != "image/png" || res.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Errorf("Download Media: Headers '%#v' do not match", res.Header())
+	}
+
+	res = sendJSON(router, "GET", media.ThumbnailURL, authorToken, nil)
+
+	if res.Code != 200 {
+		t.Fatalf("Download Thumbnail: HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	if thumbnail, err := png.DecodeConfig(bytes.NewReader(res.Body.Bytes())); err != nil ||
+		thumbnail.Width != model.THUMBNAILSIZE || thumbnail.Height != model.THUMBNAILSIZE*3/4 {
+		t.Errorf("Download Thumbnail: Thumbnail '%#v' does not match! Message: %#v", thumbnail, err)
+	}
+
+	// The Media of the Draft are hidden like the Draft
+	if res = sendJSON(router, "GET", media.URL, "", nil); res.Code != http.StatusNotFound {
+		t.Errorf("Download Media of a Draft: HTTP Status Code '%d'; expected 404", res.Code)
+	}
+
+	//-------------------------------------
+	// Test Media Lists
+
+	res = sendJSON(router, "GET", fmt.Sprintf("%sarticles/%d/media", appConfig.WebRoot, createdArticle.ID), authorToken, nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &mediaList); err != nil || res.Code != 200 || len(mediaList.Media) != 1 {
+		t.Errorf("Article Media: HTTP Status Code '%d'; Media '%#v' do not match", res.Code, mediaList.Media)
+	}
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"media", otherToken, nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &mediaList); err != nil || res.Code != 200 || len(mediaList.Media) != 0 {
+		t.Errorf("User Media: HTTP Status Code '%d'; Media '%#v' do not match", res.Code, mediaList.Media)
+	}
+
+	//-------------------------------------
+	// Test Delete
+
+	mediaPath := fmt.Sprintf("%smedia/%d", appConfig.WebRoot, media.ID)
+
+	if res = sendJSON(router, "DELETE", mediaPath, otherToken, nil); res.Code != http.StatusForbidden {
+		t.Errorf("Delete foreign Media: HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	if res = sendJSON(router, "DELETE", mediaPath, authorToken, nil); res.Code != 200 {
+		t.Errorf("Delete Media: HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	if res = sendJSON(router, "GET", mediaPath, authorToken, nil); res.Code != http.StatusNotFound {
+		t.Errorf("Deleted Media: HTTP Status Code '%d'; expected 404", res.Code)
+	}
+
+	if files.Count() != 0 {
+		t.Errorf("Delete Media: %d Files were kept", files.Count())
+	}
+
+	//-------------------------------------
+	// Test Media of a trashed Article
+
+	article, err := handler.Articles.GetByID(createdArticle.ID)
+
+	if err != nil {
+		t.Fatalf("Article (ID: '%d'): Article cannot be loaded! Message: %v", createdArticle.ID, err)
+	}
+
+	article.Status = model.StatusPublished
+	handler.Articles.Save(article)
+
+	res = postMedia(router, appConfig.WebRoot+"media", authorToken, "trashed.png", picture,
+		map[string]string{"article_id": fmt.Sprint(createdArticle.ID)})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &media); err != nil || res.Code != 200 {
+		t.Fatalf("Upload Media: HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if res = sendJSON(router, "GET", media.URL, "", nil); res.Code != 200 {
+		t.Errorf("Download Media of a published Article: HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	if res = sendJSON(router, "DELETE", fmt.Sprintf("%sarticles/%d", appConfig.WebRoot, createdArticle.ID), authorToken, nil); res.Code != 200 {
+		t.Fatalf("Delete Article: HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	mediaPath = fmt.Sprintf("%smedia/%d", appConfig.WebRoot, media.ID)
+
+	for _, path := range []string{mediaPath, media.URL, media.ThumbnailURL} {
+		if res = sendJSON(router, "GET", path, "", nil); res.Code != http.StatusNotFound {
+			t.Errorf("Media of a trashed Article '%s': HTTP Status Code '%d' for an anonymous Request; expected 404", path, res.Code)
+		}
+
+		if res = sendJSON(router, "GET", path, otherToken, nil); res.Code != http.StatusNotFound {
+			t.Errorf("Media of a trashed Article '%s': HTTP Status Code '%d' for another Author; expected 404", path, res.Code)
+		}
+	}
+
+	if res = sendJSON(router, "GET", media.URL, authorToken, nil); res.Code != 200 {
+		t.Errorf("Media of a trashed Article: HTTP Status Code '%d' for its Author; expected 200", res.Code)
+	}
+
+	// Purging the Article removes its Media instead of leaving them unattached
+	if err = handler.PurgeArticle(article); err != nil {
+		t.Fatalf("Purge Article (ID: '%d'): Article was not purged! Message: %v", article.ID, err)
+	}
+
+	if res = sendJSON(router, "GET", mediaPath, authorToken, nil); res.Code != http.StatusNotFound || files.Count() != 0 {
+		t.Errorf("Media of a purged Article: HTTP Status Code '%d'; %d Files were kept", res.Code, files.Count())
+	}
+}
+
+func TestS3FileStore(t *testing.T) {
+	var mutex sync.Mutex
+
+	objects := make(map[string][]byte)
+
+	// Stand-in for the S3 API which checks the signed Request Headers
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		checksum := sha256.Sum256(body)
+
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=test-key/") ||
+			r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(checksum[:]) || r.Header.Get("X-Amz-Date") == "" {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch r.Method {
+		case "PUT":
+			objects[r.URL.Path] = body
+		case "GET":
+			if data, ok := objects[r.URL.Path]; ok {
+				w.Write(data)
+			} else {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case "DELETE":
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	defer server.Close()
+
+	store, err := NewFileStore(&config.MediaConfig{
+		Storage: "s3",
+		S3:      config.S3Config{Endpoint: server.URL, Region: "us-east-1", Bucket: "blog", AccessKey: "test-key", SecretKey: "test-secret"},
+	})
+
+	if err != nil {
+		t.Fatalf("S3 Storage: Storage could not be created! Message: %#v", err)
+	}
+
+	if err = store.Put("ab/picture.png", []byte("picture"), "image/png"); err != nil {
+		t.Fatalf("S3 Put: Upload failed! Message: %#v", err)
+	}
+
+	if _, ok := objects["/blog/ab/picture.png"]; !ok {
+		t.Errorf("S3 Put: Objects '%#v' do not contain the File", objects)
+	}
+
+	file, err := store.Open("ab/picture.png")
+
+	if err != nil {
+		t.Fatalf("S3 Open: Download failed! Message: %#v", err)
+	}
+
+	data, _ := ioutil.ReadAll(file)
+
+	file.Close()
+
+	if string(data) != "picture" {
+		t.Errorf("S3 Open: File '%s' does not match", data)
+	}
+
+	if err = store.Delete("ab/picture.png"); err != nil {
+		t.Errorf("S3 Delete: Deletion failed! Message: %#v", err)
+	}
+
+	if _, err = store.Open("ab/picture.png"); !repository.IsNotFound(err) {
+		t.Errorf("S3 Open: Deleted File was found! Message: %#v", err)
+	}
+
+	if _, err = NewFileStore(&config.MediaConfig{Storage: "ftp"}); err == nil {
+		t.Errorf("Media Storage 'ftp': Unknown Storage was accepted")
+	}
+}
+
+func postMedia(router *gin.Engine, path string, token string, fileName string, data []byte, fields map[string]string) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+
+	form := multipart.NewWriter(&body)
+
+	for name, value := range fields {
+		form.WriteField(name, value)
+	}
+
+	part, _ := form.CreateFormFile("file", fileName)
+	part.Write(data)
+	form.Close()
+
+	res := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, &body)
+	req.Header.Add("Content-Type", form.FormDataContentType())
+
+	if token != "" {
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	router.ServeHTTP(res, req)
+
+	return res
+}
+
+func newTestImage(t *testing.T, width int, height int) []byte {
+	var encoded bytes.Buffer
+
+	picture := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			picture.SetRGBA(x, y, color.RGBA{uint8(x / 64 * 25), uint8(y / 48 * 25), 128, 255})
+		}
+	}
+
+	if err := png.Encode(&encoded, picture); err != nil {
+		t.Fatalf("Test Image: Encoding failed! Message: %#v", err)
+	}
+
+	return encoded.Bytes()
+}
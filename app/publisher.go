@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gin-blog/repository"
+)
+
+// PUBLISHERINTERVAL - Time between two Runs of the Article Publisher
+var PUBLISHERINTERVAL time.Duration = time.Minute
+
+// RunPublisher - Publishes the scheduled Articles in every Interval until the Context is cancelled
+func RunPublisher(ctx context.Context, articles repository.ArticleRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		PublishScheduled(articles, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PublishScheduled - Publishes the scheduled Articles whose Publish Time has come
+func PublishScheduled(articles repository.ArticleRepository, now time.Time) int64 {
+	published, err := articles.PublishScheduled(now)
+
+	if err != nil {
+		fmt.Printf("App - Publisher: Scheduled Articles could not be published! Message: %v\n", err)
+	}
+
+	if published != 0 {
+		fmt.Printf("App - Publisher: %d scheduled Articles were published\n", published)
+	}
+
+	return published
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/config"
+	"gin-blog/controllers"
+	"gin-blog/model"
+)
+
+func TestSearchArticles(t *testing.T) {
+	var appConfig config.AppConfig
+	var search controllers.SearchSuccess
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Test Data
+
+	editor := model.User{Name: "Test Search Editor", Slug: "search-editor", Login: "search-editor", Email: "search-editor@email.com", Role: model.RoleEditor}
+	password := editor.Login + ".pass"
+
+	if editor.Password, err = model.HashPassword(password); err != nil {
+		t.Fatalf("User '%s': Password Hash failed! Message: %#v", editor.Login, err)
+	}
+
+	handler.Users.Create(&editor)
+
+	editor.Password = password
+
+	editorToken := requestLogin(router, &editor, &appConfig, t).Token
+
+	for _, article := range []model.Article{
+		{Title: "Gardening Basics", Content: "Tomatoes need <b>sun</b> and water.", Status: model.StatusPublished},
+		{Title: "Tomatoes", Content: "Growing tomatoes in pots on the balcony.", Status: model.StatusPublished},
+		{Title: "Cooking", Content: "A sauce of fresh tomatoes.", Status: model.StatusPublished},
+		{Title: "Secret Tomatoes", Content: "Draft about tomatoes."},
+	} {
+		if res := postJSON(router, appConfig.WebRoot+"articles", editorToken, article); res.Code != 200 {
+			t.Fatalf("Create Article '%s': HTTP Status Code '%d'; expected 200", article.Title, res.Code)
+		}
+	}
+
+	//-------------------------------------
+	// Test Search
+
+	if res := sendJSON(router, "GET", appConfig.WebRoot+"search", "", nil); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Search: HTTP Status Code '%d' without Search Terms; expected 422", res.Code)
+	}
+
+	res := sendJSON(router, "GET", appConfig.WebRoot+"search?q=tomatoes&per_page=2", "", nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &search); err != nil || res.Code != 200 {
+		t.Fatalf("Search 'tomatoes': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	// The Draft is not visible and the Title Match ranks first
+	if search.Pagination.Total != 3 || len(search.Results) != 2 || search.Results[0].Article.Title != "Tomatoes" {
+		t.Fatalf("Search 'tomatoes': Results '%#v' do not match", search.Results)
+	}
+
+	if !strings.Contains(search.Results[0].Snippet, "<mark>tomatoes</mark>") {
+		t.Errorf("Search 'tomatoes': Snippet '%s' is not highlighted", search.Results[0].Snippet)
+	}
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"search?q=tomatoes+sun", "", nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &search); err != nil || len(search.Results) != 1 {
+		t.Fatalf("Search 'tomatoes sun': Results '%#v' do not match", search.Results)
+	}
+
+	if search.Results[0].Snippet != "<mark>Tomatoes</mark> need <mark>&lt;b&gt;sun&lt;/b&gt;</mark> and water." {
+		t.Errorf("Search 'tomatoes sun': Snippet '%s' is not escaped", search.Results[0].Snippet)
+	}
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"search?q=tomatoes", editorToken, nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &search); err != nil || search.Pagination.Total != 4 {
+		t.Errorf("Search 'tomatoes': Total '%d' for an Editor; expected 4", search.Pagination.Total)
+	}
+}
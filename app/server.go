@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"gin-blog/config"
+)
+
+// NewServer - Creates the Web Server of the Router with the configured Address and Timeouts
+func NewServer(serverConfig *config.ServerConfig, router http.Handler) *http.Server {
+	server := &http.Server{
+		Addr:         serverConfig.Address(),
+		Handler:      router,
+		ReadTimeout:  time.Duration(serverConfig.ReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(serverConfig.WriteTimeout) * time.Second,
+		IdleTimeout:  time.Duration(serverConfig.IdleTimeout) * time.Second,
+	}
+
+	if serverConfig.UsesTLS() {
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	return server
+}
+
+// Serve - Runs the Web Server on the Listener until the Context is cancelled
+// The running Requests are given the Shutdown Grace Period to complete before
+// the remaining Connections are closed.
+func Serve(ctx context.Context, server *http.Server, listener net.Listener, serverConfig *config.ServerConfig) error {
+	served := make(chan error, 1)
+
+	go func() {
+		if serverConfig.UsesTLS() {
+			served <- server.ServeTLS(listener, serverConfig.CertFile, serverConfig.KeyFile)
+		} else {
+			served <- server.Serve(listener)
+		}
+	}()
+
+	select {
+	case err := <-served:
+		return fmt.Errorf("Server on '%s' failed! Message: %v", listener.Addr(), err)
+	case <-ctx.Done():
+	}
+
+	fmt.Printf("App - Server: Shutting down. Waiting up to %d Seconds for the running Requests\n", serverConfig.ShutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(serverConfig.ShutdownTimeout)*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		server.Close()
+
+		return fmt.Errorf("Server Shutdown failed! Message: %v", err)
+	}
+
+	if err := <-served; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// ShutdownContext - Creates a Context which is cancelled on SIGINT or SIGTERM
+func ShutdownContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	signals := make(chan os.Signal, 1)
+
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case received := <-signals:
+			fmt.Printf("App - Server: Signal '%s' received\n", received)
+		case <-ctx.Done():
+		}
+
+		signal.Stop(signals)
+		cancel()
+	}()
+
+	return ctx, cancel
+}
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gin-blog/config"
+)
+
+func TestServerShutdown(t *testing.T) {
+	serverConfig := config.ServerConfig{Host: "127.0.0.1"}
+	serverConfig.SetDefaults("")
+
+	started := make(chan bool)
+
+	router := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("drained"))
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatalf("Server: Listener could not be created! Message: %#v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+
+	go func() {
+		served <- Serve(ctx, NewServer(&serverConfig, router), listener, &serverConfig)
+	}()
+
+	//-------------------------------------
+	// Test Draining of running Requests
+
+	responded := make(chan string, 1)
+
+	go func() {
+		res, err := http.Get("http://" + listener.Addr().String() + "/")
+
+		if err != nil {
+			responded <- err.Error()
+
+			return
+		}
+
+		body, _ := ioutil.ReadAll(res.Body)
+
+		res.Body.Close()
+
+		responded <- string(body)
+	}()
+
+	<-started
+	cancel()
+
+	if body := <-responded; body != "drained" {
+		t.Errorf("Server Shutdown: Running Request was answered with '%s'; expected 'drained'", body)
+	}
+
+	if err = <-served; err != nil {
+		t.Errorf("Server Shutdown: Server failed! Message: %#v", err)
+	}
+
+	if _, err = net.DialTimeout("tcp", listener.Addr().String(), time.Second); err == nil {
+		t.Errorf("Server Shutdown: Server still accepts Connections")
+	}
+}
+
+func TestServerTLS(t *testing.T) {
+	certDirectory, err := ioutil.TempDir("", "gin-blog")
+
+	if err != nil {
+		t.Fatalf("TLS: Directory cannot be created! Message: %#v", err)
+	}
+
+	defer os.RemoveAll(certDirectory)
+
+	writeTestCertificate(t, certDirectory)
+
+	serverConfig := config.ServerConfig{Host: "127.0.0.1", CertFile: "server.crt", KeyFile: "server.key"}
+	serverConfig.SetDefaults(certDirectory)
+
+	router := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("secure"))
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatalf("Server: Listener could not be created! Message: %#v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+
+	go func() {
+		served <- Serve(ctx, NewServer(&serverConfig, router), listener, &serverConfig)
+	}()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+
+	res, err := client.Get("https://" + listener.Addr().String() + "/")
+
+	if err != nil {
+		t.Fatalf("TLS Request: Request failed! Message: %#v", err)
+	}
+
+	body, _ := ioutil.ReadAll(res.Body)
+
+	res.Body.Close()
+
+	if res.TLS == nil || string(body) != "secure" {
+		t.Errorf("TLS Request: Response '%s' was not sent with TLS", body)
+	}
+
+	cancel()
+
+	if err = <-served; err != nil {
+		t.Errorf("Server Shutdown: Server failed! Message: %#v", err)
+	}
+}
+
+// writeTestCertificate - Creates a self-signed Certificate for 127.0.0.1 in the Directory
+func writeTestCertificate(t *testing.T, directory string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatalf("TLS: Key could not be created! Message: %#v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gin-blog-test"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	certificate, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+
+	if err != nil {
+		t.Fatalf("TLS: Certificate could not be created! Message: %#v", err)
+	}
+
+	keyData, err := x509.MarshalECPrivateKey(key)
+
+	if err != nil {
+		t.Fatalf("TLS: Key could not be encoded! Message: %#v", err)
+	}
+
+	ioutil.WriteFile(filepath.Join(directory, "server.crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate}), 0600)
+	ioutil.WriteFile(filepath.Join(directory, "server.key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyData}), 0600)
+}
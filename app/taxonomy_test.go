@@ -0,0 +1,210 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/config"
+	"gin-blog/controllers"
+	"gin-blog/model"
+)
+
+func TestTaxonomy(t *testing.T) {
+	var appConfig config.AppConfig
+	var createdArticle model.DisplayedArticle
+	var category model.DisplayedCategory
+	var categoryList controllers.CategoryListSuccess
+	var tagList controllers.TagListSuccess
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Test Data
+
+	editor := model.User{Name: "Test Taxonomy Editor", Slug: "taxonomy-editor", Login: "taxonomy-editor", Email: "taxonomy-editor@email.com", Role: model.RoleEditor}
+	author := model.User{Name: "Test Taxonomy Author", Slug: "taxonomy-author", Login: "taxonomy-author", Email: "taxonomy-author@email.com", Role: model.RoleAuthor}
+
+	for _, user := range []*model.User{&editor, &author} {
+		password := user.Login + ".pass"
+
+		if user.Password, err = model.HashPassword(password); err != nil {
+			t.Fatalf("User '%s': Password Hash failed! Message: %#v", user.Login, err)
+		}
+
+		handler.Users.Create(user)
+
+		user.Password = password
+	}
+
+	editorToken := requestLogin(router, &editor, &appConfig, t).Token
+	authorToken := requestLogin(router, &author, &appConfig, t).Token
+
+	//-------------------------------------
+	// Test Category Management
+
+	if res := postJSON(router, appConfig.WebRoot+"categories", authorToken, model.CategoryData{Name: "Tutorials"}); res.Code != http.StatusForbidden {
+		t.Errorf("Create Category 'Tutorials': HTTP Status Code '%d' for an Author; expected 403", res.Code)
+	}
+
+	if res := postJSON(router, appConfig.WebRoot+"categories", editorToken, model.CategoryData{Name: "Tutorials"}); res.Code != 200 {
+		t.Fatalf("Create Category 'Tutorials': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	parentSlug := "tutorials"
+
+	res := postJSON(router, appConfig.WebRoot+"categories", editorToken, model.CategoryData{Name: "Go Basics", Parent: &parentSlug})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &category); err != nil || res.Code != 200 {
+		t.Fatalf("Create Category 'Go Basics': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if category.Slug != "go-basics" || fmt.Sprint(category.Path) != "[tutorials go-basics]" {
+		t.Errorf("Create Category 'Go Basics': Category '%#v' is not nested into 'tutorials'", category)
+	}
+
+	childSlug := "go-basics"
+
+	if res = sendJSON(router, "PUT", appConfig.WebRoot+"categories/tutorials", editorToken, model.CategoryData{Parent: &childSlug}); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Update Category 'tutorials': HTTP Status Code '%d' for a Subcategory as Parent; expected 422", res.Code)
+	}
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"categories", "", nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &categoryList); err != nil || res.Code != 200 {
+		t.Fatalf("Display Categories: HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if len(categoryList.Categories) != 1 || len(categoryList.Categories[0].Children) != 1 || categoryList.Categories[0].Children[0].Slug != "go-basics" {
+		t.Errorf("Display Categories: Category Tree '%#v' does not match", categoryList.Categories)
+	}
+
+	//-------------------------------------
+	// Test Tag Assignment
+
+	articles := []struct {
+		token    string
+		article  model.Article
+		category *string
+	}{
+		{editorToken, model.Article{Title: "Tagged Article One", Status: model.StatusPublished, TagNames: []string{"Go", "Web", "go"}}, &childSlug},
+		{editorToken, model.Article{Title: "Tagged Article Two", Status: model.StatusPublished, TagNames: []string{"Go"}}, nil},
+		{authorToken, model.Article{Title: "Tagged Draft", TagNames: []string{"Go", "Secret"}}, &parentSlug},
+	}
+
+	var articleIDs []uint
+
+	for _, test := range articles {
+		test.article.CategorySlug = test.category
+
+		res = postJSON(router, appConfig.WebRoot+"articles", test.token, test.article)
+
+		if err = json.Unmarshal(res.Body.Bytes(), &createdArticle); err != nil || res.Code != 200 {
+			t.Fatalf("Create Article '%s': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", test.article.Title, res.Code, err)
+		}
+
+		articleIDs = append(articleIDs, createdArticle.ID)
+	}
+
+	if len(createdArticle.Tags) != 2 || createdArticle.Tags[0].Slug != "go" || createdArticle.CategorySlug != "tutorials" {
+		t.Errorf("Create Article 'Tagged Draft': Tags '%#v' or Category '%s' do not match", createdArticle.Tags, createdArticle.CategorySlug)
+	}
+
+	unknownSlug := "unknown-category"
+
+	if res = sendJSON(router, "PUT", fmt.Sprintf("%sarticles/%d", appConfig.WebRoot, articleIDs[1]), editorToken, model.Article{CategorySlug: &unknownSlug}); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Update Article (ID: '%d'): HTTP Status Code '%d' for an unknown Category; expected 422", articleIDs[1], res.Code)
+	}
+
+	//-------------------------------------
+	// Test Article Filters
+
+	filters := map[string]int{
+		"articles?tag=go":                         2,
+		"articles?tag=web":                        1,
+		"articles?tag=unknown":                    0,
+		"articles?category=tutorials":             1,
+		"articles?category=go-basics":             1,
+		"articles?tag=go&category=go-basics":      1,
+		"authors/taxonomy-author/articles?tag=go": 0,
+	}
+
+	for path, expected := range filters {
+		var articleList controllers.ArticleListSuccess
+
+		res = sendJSON(router, "GET", appConfig.WebRoot+path, "", nil)
+
+		if err = json.Unmarshal(res.Body.Bytes(), &articleList); err != nil || res.Code != 200 {
+			t.Fatalf("Display Articles '%s': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", path, res.Code, err)
+		}
+
+		if len(articleList.Articles) != expected {
+			t.Errorf("Display Articles '%s': Article Count '%d'; expected %d", path, len(articleList.Articles), expected)
+		}
+	}
+
+	// Authors see their own Drafts
+	var authorList controllers.ArticleListSuccess
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"articles?category=tutorials", authorToken, nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &authorList); err != nil || len(authorList.Articles) != 2 {
+		t.Errorf("Display Articles 'category=tutorials': HTTP Status Code '%d'; Article Count '%d' for the Author; expected 2", res.Code, len(authorList.Articles))
+	}
+
+	//-------------------------------------
+	// Test Tag Cloud
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"tags", "", nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &tagList); err != nil || res.Code != 200 {
+		t.Fatalf("Display Tags: HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	counts := make(map[string]int64)
+
+	for _, tag := range tagList.Tags {
+		counts[tag.Slug] = tag.ArticleCount
+	}
+
+	if counts["go"] != 2 || counts["web"] != 1 || counts["secret"] != 0 || len(tagList.Tags) != 3 {
+		t.Errorf("Display Tags: Tag Counts '%#v' do not match the published Articles", counts)
+	}
+
+	//-------------------------------------
+	// Test Tag and Category Removal
+
+	if res = sendJSON(router, "DELETE", appConfig.WebRoot+"categories/go-basics", editorToken, nil); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Delete Category 'go-basics': HTTP Status Code '%d' with Articles; expected 422", res.Code)
+	}
+
+	if res = sendJSON(router, "DELETE", appConfig.WebRoot+"tags/web", editorToken, nil); res.Code != 200 {
+		t.Errorf("Delete Tag 'web': HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	emptySlug := ""
+
+	res = sendJSON(router, "PUT", fmt.Sprintf("%sarticles/%d", appConfig.WebRoot, articleIDs[0]), editorToken, model.Article{TagNames: []string{}, CategorySlug: &emptySlug})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &createdArticle); err != nil || res.Code != 200 {
+		t.Fatalf("Update Article (ID: '%d'): HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", articleIDs[0], res.Code, err)
+	}
+
+	if len(createdArticle.Tags) != 0 || createdArticle.CategorySlug != "" {
+		t.Errorf("Update Article (ID: '%d'): Tags '%#v' or Category '%s' were not removed", articleIDs[0], createdArticle.Tags, createdArticle.CategorySlug)
+	}
+
+	if res = sendJSON(router, "DELETE", appConfig.WebRoot+"categories/go-basics", editorToken, nil); res.Code != 200 {
+		t.Errorf("Delete Category 'go-basics': HTTP Status Code '%d' without Articles; expected 200", res.Code)
+	}
+}
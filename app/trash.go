@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gin-blog/controllers"
+	"gin-blog/repository"
+)
+
+// TRASHPURGEINTERVAL - Time between two Runs of the Trash Purger
+var TRASHPURGEINTERVAL time.Duration = time.Hour
+
+// RunTrashPurger - Purges the Entities which are longer in the Trash than the Retention
+// in every Interval until the Context is cancelled
+func RunTrashPurger(ctx context.Context, handler *controllers.Handler, retention time.Duration, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		PurgeTrash(handler, time.Now().Add(-retention))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeTrash - Purges the Articles and Users which were deleted before the Time
+// The Articles are purged first so that their Authors can be purged in the same Run.
+// Users who still have Articles are kept.
+func PurgeTrash(handler *controllers.Handler, before time.Time) int64 {
+	var purged int64
+
+	articles, err := handler.Articles.ListDeleted(before)
+
+	if err != nil {
+		fmt.Printf("App - Trash Purger: Deleted Articles could not be listed! Message: %v\n", err)
+	}
+
+	for idx := range articles {
+		if err = handler.PurgeArticle(&articles[idx]); err != nil {
+			fmt.Printf("App - Trash Purger: Article (ID: '%d') could not be purged! Message: %v\n", articles[idx].ID, err)
+
+			continue
+		}
+
+		purged++
+	}
+
+	users, err := handler.Users.ListDeleted(before)
+
+	if err != nil {
+		fmt.Printf("App - Trash Purger: Deleted Users could not be listed! Message: %v\n", err)
+	}
+
+	for idx := range users {
+		if err = handler.PurgeUser(&users[idx]); err != nil {
+			if !repository.IsConflict(err) {
+				fmt.Printf("App - Trash Purger: User (ID: '%d') could not be purged! Message: %v\n", users[idx].ID, err)
+			}
+
+			continue
+		}
+
+		purged++
+	}
+
+	if purged != 0 {
+		fmt.Printf("App - Trash Purger: %d deleted Entities were purged\n", purged)
+	}
+
+	return purged
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/config"
+	"gin-blog/controllers"
+	"gin-blog/model"
+)
+
+func TestTrash(t *testing.T) {
+	var appConfig config.AppConfig
+	var createdArticle model.DisplayedArticle
+	var trash controllers.TrashSuccess
+	var err error
+
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+	appConfig.Trash.RetentionDays = 7
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Test Data
+
+	admin := model.User{Name: "Test Trash Admin", Slug: "trash-admin", Login: "trash-admin", Email: "trash-admin@email.com", Role: model.RoleAdmin}
+	author := model.User{Name: "Test Trash Author", Slug: "trash-author", Login: "trash-author", Email: "trash-author@email.com", Role: model.RoleAuthor}
+	other := model.User{Name: "Test Trash Other", Slug: "trash-other", Login: "trash-other", Email: "trash-other@email.com", Role: model.RoleAuthor}
+
+	for _, user := range []*model.User{&admin, &author, &other} {
+		password := user.Login + ".pass"
+
+		if user.Password, err = model.HashPassword(password); err != nil {
+			t.Fatalf("User '%s': Password Hash failed! Message: %#v", user.Login, err)
+		}
+
+		handler.Users.Create(user)
+
+		user.Password = password
+	}
+
+	adminToken := requestLogin(router, &admin, &appConfig, t).Token
+	authorToken := requestLogin(router, &author, &appConfig, t).Token
+	otherToken := requestLogin(router, &other, &appConfig, t).Token
+
+	res := postJSON(router, appConfig.WebRoot+"articles", authorToken, model.Article{Title: "Trash Article", Content: "Soon deleted"})
+
+	if err = json.Unmarshal(res.Body.Bytes(), &createdArticle); err != nil || res.Code != 200 {
+		t.Fatalf("Create Article: HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	articlePath := fmt.Sprintf("%sarticles/%d", appConfig.WebRoot, createdArticle.ID)
+
+	//-------------------------------------
+	// Test Article Trash
+
+	if res = sendJSON(router, "DELETE", articlePath, authorToken, nil); res.Code != 200 {
+		t.Fatalf("Delete Article: HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"trash", authorToken, nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &trash); err != nil || res.Code != 200 {
+		t.Fatalf("Trash: HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if len(trash.Articles) != 1 || trash.Articles[0].ID != createdArticle.ID || trash.Articles[0].PurgeTime == "" || len(trash.Users) != 0 {
+		t.Errorf("Trash of the Author: Trash '%#v' does not match", trash)
+	}
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"trash", otherToken, nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &trash); err != nil || len(trash.Articles) != 0 {
+		t.Errorf("Trash of another Author: Trash '%#v' contains foreign Articles", trash)
+	}
+
+	if res = sendJSON(router, "POST", articlePath+"/restore", otherToken, nil); res.Code != http.StatusForbidden {
+		t.Errorf("Restore foreign Article: HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	if res = sendJSON(router, "POST", articlePath+"/restore", authorToken, nil); res.Code != 200 {
+		t.Errorf("Restore Article: HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	if res = sendJSON(router, "GET", articlePath, authorToken, nil); res.Code != 200 {
+		t.Errorf("Restored Article: HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	if res = sendJSON(router, "POST", articlePath+"/restore", authorToken, nil); res.Code != http.StatusNotFound {
+		t.Errorf("Restore active Article: HTTP Status Code '%d'; expected 404", res.Code)
+	}
+
+	//-------------------------------------
+	// Test Purge
+
+	trashPath := fmt.Sprintf("%strash/articles/%d", appConfig.WebRoot, createdArticle.ID)
+
+	if res = sendJSON(router, "DELETE", trashPath, adminToken, nil); res.Code != http.StatusNotFound {
+		t.Errorf("Purge active Article: HTTP Status Code '%d'; expected 404", res.Code)
+	}
+
+	sendJSON(router, "DELETE", articlePath, authorToken, nil)
+
+	if res = sendJSON(router, "DELETE", trashPath, authorToken, nil); res.Code != http.StatusForbidden {
+		t.Errorf("Purge Article as Author: HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	if res = sendJSON(router, "DELETE", trashPath, adminToken, nil); res.Code != 200 {
+		t.Errorf("Purge Article: HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	if res = sendJSON(router, "POST", articlePath+"/restore", authorToken, nil); res.Code != http.StatusNotFound {
+		t.Errorf("Restore purged Article: HTTP Status Code '%d'; expected 404", res.Code)
+	}
+
+	//-------------------------------------
+	// Test User Trash
+
+	userPath := fmt.Sprintf("%susers/%d", appConfig.WebRoot, other.ID)
+
+	if res = sendJSON(router, "DELETE", userPath, adminToken, nil); res.Code != 200 {
+		t.Fatalf("Delete User: HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"trash", adminToken, nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &trash); err != nil || len(trash.Users) != 1 || trash.Users[0].ID != other.ID {
+		t.Errorf("Trash of the Admin: Trash '%#v' does not match", trash)
+	}
+
+	if res = sendJSON(router, "POST", userPath+"/restore", authorToken, nil); res.Code != http.StatusForbidden {
+		t.Errorf("Restore User as Author: HTTP Status Code '%d'; expected 403", res.Code)
+	}
+
+	if res = sendJSON(router, "POST", userPath+"/restore", adminToken, nil); res.Code != 200 {
+		t.Errorf("Restore User: HTTP Status Code '%d'; expected 200", res.Code)
+	}
+
+	requestLogin(router, &other, &appConfig, t)
+
+	//-------------------------------------
+	// Test Retention
+
+	sendJSON(router, "DELETE", userPath, adminToken, nil)
+	sendJSON(router, "DELETE", fmt.Sprintf("%susers/%d", appConfig.WebRoot, author.ID), adminToken, nil)
+
+	// Users who still have Articles are kept in the Trash together with their Media
+	handler.Articles.Create(&model.Article{Title: "Kept Article", Slug: "kept-article", Content: "Kept", UserID: author.ID})
+	handler.Media.Create(&model.Media{UserID: author.ID, FileName: "kept.png", StorageKey: "kept.png"})
+	handler.Media.Create(&model.Media{UserID: other.ID, FileName: "purged.png", StorageKey: "purged.png"})
+
+	if purged := PurgeTrash(handler, time.Now().Add(-time.Hour)); purged != 0 {
+		t.Errorf("Purge Trash: %d recently deleted Entities were purged", purged)
+	}
+
+	if purged := PurgeTrash(handler, time.Now().Add(time.Second)); purged != 1 {
+		t.Errorf("Purge Trash: %d Entities were purged; expected 1", purged)
+	}
+
+	if _, err = handler.Users.GetDeletedByID(other.ID); err == nil {
+		t.Errorf("Purge Trash: User (ID: '%d') is still in the Trash", other.ID)
+	}
+
+	if _, err = handler.Users.GetDeletedByID(author.ID); err != nil {
+		t.Errorf("Purge Trash: User (ID: '%d') with Articles was purged", author.ID)
+	}
+
+	if media, err := handler.Media.GetByUserID(author.ID); err != nil || len(media) != 1 {
+		t.Errorf("Purge Trash: Media of the User (ID: '%d') with Articles were removed", author.ID)
+	}
+
+	if media, err := handler.Media.GetByUserID(other.ID); err != nil || len(media) != 0 {
+		t.Errorf("Purge Trash: Media of the purged User (ID: '%d') were kept", other.ID)
+	}
+}
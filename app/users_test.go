@@ -23,6 +23,7 @@ var testAdmin model.User = model.User{
 	Login:    "admin-1",
 	Email:    "admin-1@email.com",
 	Password: "admin.pass",
+	Role:     model.RoleAdmin,
 }
 
 // testUsers - Users that will be created as test data
@@ -47,51 +48,42 @@ var testUsers = []model.User{
 	},
 }
 
-var resListUsers map[uint]*model.User = make(map[uint]*model.User)
+var resListUsers map[uint]*model.DisplayedAccount = make(map[uint]*model.DisplayedAccount)
 
 func TestDisplayUsers(t *testing.T) {
 	var appConfig config.AppConfig
-	var db *gorm.DB
 	var token string
 	var err error
 
 	gin.SetMode(gin.TestMode)
 
-	if appConfig, err = config.ReadConfigFile(); err != nil {
-		t.Fatalf("Application Configuration: Configuration is missing! Message: %#v", err)
-	}
+	appConfig = readTestConfig(t)
 
-	if db, err = ConnectDatabase(&appConfig); err != nil {
-		t.Fatalf("Database Connection: Connection failed! Message: %#v", err)
-	}
-
-	if err = controllers.MigrateUsers(db); err != nil {
-		t.Fatalf("Users Migration: Migration failed! Message: %#v", err)
-	}
+	handler := newTestHandler(t)
 
 	router := gin.Default()
 
-	controllers.RegisterUserRoutes(router, &appConfig)
+	controllers.RegisterUserRoutes(router, &appConfig, handler)
 
 	//-------------------------------------
 	// Create Admin User
 
-	// Reset Admin User ID
-	testAdmin.ID = 0
+	// Reset Admin User
+	testAdmin.Model = gorm.Model{}
 
 	loginPassword := testAdmin.Password
 
 	if !strings.HasPrefix(testAdmin.Password, "*") {
-		testAdmin.Password = model.EncryptPassword(testAdmin.Password, model.ENCRYPTIONSALT)
+		testAdmin.Password = model.EncryptPassword(testAdmin.Password, appConfig.Auth.PasswordPepper)
 	}
 
-	createRestoreUser(db, &testAdmin)
+	createRestoreUser(handler, &testAdmin)
 
 	testAdmin.Password = loginPassword
 
 	// Create 3 Users
 	for idx, user := range testUsers {
-		db.Create(&user)
+		handler.Users.Create(&user)
 
 		// Set assigned user id
 		testUsers[idx].ID = user.ID
@@ -100,7 +92,7 @@ func TestDisplayUsers(t *testing.T) {
 	//-------------------------------------
 	// Get Admin Login Token
 
-	token, err = loginUser(router, &testAdmin, &appConfig, t)
+	token, err = loginUser(router, handler, &testAdmin, &appConfig, t)
 
 	if err != nil {
 		t.Errorf("Login (%d) '%s': failed! Message: %#v", testAdmin.ID, testAdmin.Login, err)
@@ -124,7 +116,7 @@ func TestDisplayUsers(t *testing.T) {
 
 	fmt.Printf("Request %s '%s ? %s' - Body:\n'%#v'\n", req.Method, req.URL.Path, req.URL.RawQuery, res.Body.String())
 
-	var resUsers []model.User
+	var resUsers []model.DisplayedAccount
 
 	err = json.Unmarshal(res.Body.Bytes(), &resUsers)
 
@@ -157,7 +149,7 @@ func TestDisplayUsers(t *testing.T) {
 
 	fmt.Printf("Request %s '%s ? %s' - Body:\n'%#v'\n", req.Method, req.URL.Path, req.URL.RawQuery, res.Body.String())
 
-	var resUser model.User
+	var resUser model.DisplayedAccount
 
 	err = json.Unmarshal(res.Body.Bytes(), &resUser)
 
@@ -174,16 +166,15 @@ func TestDisplayUsers(t *testing.T) {
 
 	// Delete Test Articles
 	for _, user := range testUsers {
-		db.Delete(&user, user.ID)
+		handler.Users.Delete(&user)
 	}
 
 	// Delete Test Admin
-	db.Delete(&testAdmin, testAdmin.ID)
+	handler.Users.Delete(&testAdmin)
 }
 
 func TestCreateUser(t *testing.T) {
 	var appConfig config.AppConfig
-	var db *gorm.DB
 	var testUser *model.User = &testUsers[0]
 	var userJSON []byte
 	var token string
@@ -191,45 +182,37 @@ func TestCreateUser(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 
-	if appConfig, err = config.ReadConfigFile(); err != nil {
-		t.Fatalf("Application Configuration: Configuration is missing! Message: %#v", err)
-	}
-
-	if db, err = ConnectDatabase(&appConfig); err != nil {
-		t.Fatalf("Database Connection: Connection failed! Message: %#v", err)
-	}
+	appConfig = readTestConfig(t)
 
-	if err = controllers.MigrateUsers(db); err != nil {
-		t.Fatalf("Users Migration: Migration failed! Message: %#v", err)
-	}
+	handler := newTestHandler(t)
 
 	router := gin.Default()
 
-	controllers.RegisterUserRoutes(router, &appConfig)
+	controllers.RegisterUserRoutes(router, &appConfig, handler)
 
 	//-------------------------------------
 	// Create Admin User
 
-	// Reset Admin User ID
-	testAdmin.ID = 0
+	// Reset Admin User
+	testAdmin.Model = gorm.Model{}
 
-	// Reset Test User ID
-	testUser.ID = 0
+	// Reset Test User
+	testUser.Model = gorm.Model{}
 
 	loginPassword := testAdmin.Password
 
 	if !strings.HasPrefix(testAdmin.Password, "*") {
-		testAdmin.Password = model.EncryptPassword(testAdmin.Password, model.ENCRYPTIONSALT)
+		testAdmin.Password = model.EncryptPassword(testAdmin.Password, appConfig.Auth.PasswordPepper)
 	}
 
-	createRestoreUser(db, &testAdmin)
+	createRestoreUser(handler, &testAdmin)
 
 	testAdmin.Password = loginPassword
 
 	//-------------------------------------
 	// Test User Create Route
 
-	token, err = loginUser(router, &testAdmin, &appConfig, t)
+	token, err = loginUser(router, handler, &testAdmin, &appConfig, t)
 
 	if err != nil {
 		t.Errorf("Login (%d) '%s': failed! Message: %#v", testAdmin.ID, testAdmin.Login, err)
@@ -257,7 +240,7 @@ func TestCreateUser(t *testing.T) {
 
 	fmt.Printf("Request %s '%s ? %s' - Body:\n'%#v'\n", req.Method, req.URL.Path, req.URL.RawQuery, res.Body.String())
 
-	var createdUser model.User
+	var createdUser model.DisplayedAccount
 
 	err = json.Unmarshal(res.Body.Bytes(), &createdUser)
 
@@ -279,19 +262,31 @@ func TestCreateUser(t *testing.T) {
 		t.Errorf("Create User: Name '%s' but expected '%s'", createdUser.Name, testUser.Name)
 	}
 
+	//-------------------------------------
+	// Test Slug Conflict
+
+	if res = postJSON(router, appConfig.WebRoot+"users", token, testUser); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Create User: HTTP Status Code '%d' for the taken Slug '%s'; expected 422", res.Code, testUser.Slug)
+	}
+
+	if res = postJSON(router, appConfig.WebRoot+"users", token, model.UserData{Name: testUser.Name, Login: "user-1-copy"}); res.Code != 200 {
+		t.Errorf("Create User: HTTP Status Code '%d' for a generated Slug; expected 200", res.Code)
+	} else if json.Unmarshal(res.Body.Bytes(), &createdUser); createdUser.Slug != "test-user-no-1" {
+		t.Errorf("Create User: Slug '%s' but expected 'test-user-no-1'", createdUser.Slug)
+	}
+
 	//-------------------------------------
 	// Clean Up test data
 
 	// Delete Test User
-	db.Delete(&testUser, testUser.ID)
+	handler.Users.Delete(testUser)
 
 	// Delete Test Admin
-	db.Delete(&testAdmin, testAdmin.ID)
+	handler.Users.Delete(&testAdmin)
 }
 
 func TestUpdateUser(t *testing.T) {
 	var appConfig config.AppConfig
-	var db *gorm.DB
 	var testUser *model.User = &testUsers[1]
 	var userJSON []byte
 	var token string
@@ -299,43 +294,35 @@ func TestUpdateUser(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 
-	if appConfig, err = config.ReadConfigFile(); err != nil {
-		t.Fatalf("Application Configuration: Configuration is missing! Message: %#v", err)
-	}
+	appConfig = readTestConfig(t)
 
-	if db, err = ConnectDatabase(&appConfig); err != nil {
-		t.Fatalf("Database Connection: Connection failed! Message: %#v", err)
-	}
-
-	if err = controllers.MigrateUsers(db); err != nil {
-		t.Fatalf("Users Migration: Migration failed! Message: %#v", err)
-	}
+	handler := newTestHandler(t)
 
 	router := gin.Default()
 
-	controllers.RegisterUserRoutes(router, &appConfig)
+	controllers.RegisterUserRoutes(router, &appConfig, handler)
 
 	//-------------------------------------
 	// Create Test Data
 
-	// Reset Admin User ID
-	testAdmin.ID = 0
+	// Reset Admin User
+	testAdmin.Model = gorm.Model{}
 
-	// Reset Test User ID
-	testUser.ID = 0
+	// Reset Test User
+	testUser.Model = gorm.Model{}
 
 	loginPassword := testAdmin.Password
 
 	if !strings.HasPrefix(testAdmin.Password, "*") {
-		testAdmin.Password = model.EncryptPassword(testAdmin.Password, model.ENCRYPTIONSALT)
+		testAdmin.Password = model.EncryptPassword(testAdmin.Password, appConfig.Auth.PasswordPepper)
 	}
 
-	createRestoreUser(db, &testAdmin)
+	createRestoreUser(handler, &testAdmin)
 
 	testAdmin.Password = loginPassword
 
 	// Create Test User
-	db.Create(&testUser)
+	handler.Users.Create(testUser)
 
 	// Change Test User
 	testUser.Slug += "-updated"
@@ -344,7 +331,7 @@ func TestUpdateUser(t *testing.T) {
 	//-------------------------------------
 	// Test User Update Route
 
-	token, err = loginUser(router, &testAdmin, &appConfig, t)
+	token, err = loginUser(router, handler, &testAdmin, &appConfig, t)
 
 	if err != nil {
 		t.Errorf("Login (%d) '%s': failed! Message: %#v", testAdmin.ID, testAdmin.Login, err)
@@ -372,7 +359,7 @@ func TestUpdateUser(t *testing.T) {
 
 	fmt.Printf("Request %s '%s ? %s' - Body:\n'%#v'\n", req.Method, req.URL.Path, req.URL.RawQuery, res.Body.String())
 
-	var updatedUser model.User
+	var updatedUser model.DisplayedAccount
 
 	err = json.Unmarshal(res.Body.Bytes(), &updatedUser)
 
@@ -392,15 +379,14 @@ func TestUpdateUser(t *testing.T) {
 	// Clean Up test data
 
 	// Delete Test User
-	db.Delete(&testUser, testUser.ID)
+	handler.Users.Delete(testUser)
 
 	// Delete Test Admin
-	db.Delete(&testAdmin, testAdmin.ID)
+	handler.Users.Delete(&testAdmin)
 }
 
 func TestDeleteUser(t *testing.T) {
 	var appConfig config.AppConfig
-	var db *gorm.DB
 	var testUser *model.User = &testUsers[2]
 	var userJSON []byte
 	var token string
@@ -408,43 +394,35 @@ func TestDeleteUser(t *testing.T) {
 
 	gin.SetMode(gin.TestMode)
 
-	if appConfig, err = config.ReadConfigFile(); err != nil {
-		t.Fatalf("Application Configuration: Configuration is missing! Message: %#v\n", err)
-	}
-
-	if db, err = ConnectDatabase(&appConfig); err != nil {
-		t.Fatalf("Database Connection: Connection failed! Message: %#v\n", err)
-	}
+	appConfig = readTestConfig(t)
 
-	if err = controllers.MigrateUsers(db); err != nil {
-		t.Fatalf("Users Migration: Migration failed! Message: %#v\n", err)
-	}
+	handler := newTestHandler(t)
 
 	router := gin.Default()
 
-	controllers.RegisterUserRoutes(router, &appConfig)
+	controllers.RegisterUserRoutes(router, &appConfig, handler)
 
 	//-------------------------------------
 	// Create Test Data
 
-	// Reset Admin User ID
-	testAdmin.ID = 0
+	// Reset Admin User
+	testAdmin.Model = gorm.Model{}
 
-	// Reset Test User ID
-	testUser.ID = 0
+	// Reset Test User
+	testUser.Model = gorm.Model{}
 
 	loginPassword := testAdmin.Password
 
 	if !strings.HasPrefix(testAdmin.Password, "*") {
-		testAdmin.Password = model.EncryptPassword(testAdmin.Password, model.ENCRYPTIONSALT)
+		testAdmin.Password = model.EncryptPassword(testAdmin.Password, appConfig.Auth.PasswordPepper)
 	}
 
-	createRestoreUser(db, &testAdmin)
+	createRestoreUser(handler, &testAdmin)
 
 	testAdmin.Password = loginPassword
 
 	// Create Test User
-	db.Create(&testUser)
+	handler.Users.Create(testUser)
 
 	// Change Test User
 	testUser.Slug += "-updated"
@@ -453,7 +431,7 @@ func TestDeleteUser(t *testing.T) {
 	//-------------------------------------
 	// Test User Update Route
 
-	token, err = loginUser(router, &testAdmin, &appConfig, t)
+	token, err = loginUser(router, handler, &testAdmin, &appConfig, t)
 
 	if err != nil {
 		t.Errorf("Login (%d) '%s': failed! Message: %#v", testAdmin.ID, testAdmin.Login, err)
@@ -503,38 +481,131 @@ func TestDeleteUser(t *testing.T) {
 	// Clean Up test data
 
 	// Delete Test User
-	db.Delete(&testUser, testUser.ID)
+	handler.Users.Delete(testUser)
 
 	// Delete Test Admin
-	db.Delete(&testAdmin, testAdmin.ID)
+	handler.Users.Delete(&testAdmin)
 }
 
-func createRestoreUser(db *gorm.DB, searchUser *model.User) {
-	var resUser *model.User
+func TestUserFields(t *testing.T) {
+	var appConfig config.AppConfig
+	var displayed map[string]interface{}
 	var err error
 
-	if controllers.DATABASE == nil {
-		controllers.DATABASE = db
+	gin.SetMode(gin.TestMode)
+
+	appConfig = readTestConfig(t)
+
+	handler := newTestHandler(t)
+
+	router := RegisterRoutes(&appConfig, handler)
+
+	//-------------------------------------
+	// Create Test Data
+
+	tokens := map[string]string{}
+
+	for _, login := range []string{"admin", "editor"} {
+		user := model.User{Name: "Test " + login, Slug: login, Login: login, Email: login + "@email.com", Role: model.Role(login)}
+
+		if user.Password, err = model.HashPassword(login + ".pass"); err != nil {
+			t.Fatalf("User '%s': Password Hash failed! Message: %#v", login, err)
+		}
+
+		handler.Users.Create(&user)
+
+		user.Password = login + ".pass"
+
+		tokens[login] = requestLogin(router, &user, &appConfig, t).Token
 	}
 
-	if resUser, err = controllers.GetUserByLogin(searchUser.Login); resUser == nil || err != nil {
-		var restore model.User
+	newUser := model.UserData{Name: "Test Fields", Slug: "fields", Login: "fields", Email: "fields@email.com", Password: "fields.pass"}
 
-		db.Unscoped().Where("login = ?", searchUser.Login).Where("deleted_at IS NOT NULL").Find(&restore)
+	res := postJSON(router, appConfig.WebRoot+"users", tokens["admin"], newUser)
 
-		fmt.Printf("Login '%s / %s': Restore: %#v\n", searchUser.Login, searchUser.Name, restore)
+	if res.Code != 200 {
+		t.Fatalf("Create User 'fields': HTTP Status Code '%d'; expected 200", res.Code)
+	}
 
-		if restore.ID != 0 {
-			// Deleted account become new user
-			resUser = &restore
+	if err = json.Unmarshal(res.Body.Bytes(), &displayed); err != nil {
+		t.Fatalf("Create User 'fields': Response is invalid JSON! Message: %#v", err)
+	}
+
+	//-------------------------------------
+	// Test Sensitive Fields
 
-			// Re-enable user account
-			db.Model(&restore).Unscoped().Where("id = ?", restore.ID).Update("deleted_at", nil)
+	for _, field := range []string{"password", "Password", "DeletedAt", "Articles"} {
+		if _, ok := displayed[field]; ok {
+			t.Errorf("Create User 'fields': Field '%s' must not be in Response!", field)
 		}
 	}
 
-	if resUser == nil {
-		db.Create(searchUser)
+	if displayed["login"] != newUser.Login {
+		t.Errorf("Create User 'fields': Login '%v' but expected '%s'", displayed["login"], newUser.Login)
+	}
+
+	userPath := fmt.Sprintf("%susers/%v", appConfig.WebRoot, displayed["id"])
+
+	res = sendJSON(router, "GET", userPath, tokens["editor"], nil)
+
+	displayed = nil
+
+	if err = json.Unmarshal(res.Body.Bytes(), &displayed); err != nil {
+		t.Errorf("Display User 'editor': Response is invalid JSON! Message: %#v", err)
+	}
+
+	if _, ok := displayed["login"]; ok || res.Code != 200 {
+		t.Errorf("Display User 'editor': HTTP Status Code '%d'; Login must only be shown to Administrators", res.Code)
+	}
+
+	//-------------------------------------
+	// Test Field Selection
+
+	res = sendJSON(router, "GET", userPath+"?fields=id,name", tokens["admin"], nil)
+
+	displayed = nil
+
+	if err = json.Unmarshal(res.Body.Bytes(), &displayed); err != nil {
+		t.Errorf("Display User 'fields': Response is invalid JSON! Message: %#v", err)
+	}
+
+	if len(displayed) != 2 || displayed["name"] != newUser.Name {
+		t.Errorf("Display User 'fields': Fields '%v' but expected 'id' and 'name'", displayed)
+	}
+
+	if res = sendJSON(router, "GET", userPath+"?fields=id,password", tokens["admin"], nil); res.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Display User 'fields': HTTP Status Code '%d' for the Field 'password'; expected 422", res.Code)
+	}
+
+	var articleList map[string]interface{}
+
+	handler.Articles.Create(&model.Article{UserID: 1, Title: "Fields Article", Slug: "fields-article", Content: "Fields Article Content", Status: model.StatusPublished, PublishedAt: &testPublishTime})
+
+	res = sendJSON(router, "GET", appConfig.WebRoot+"articles?fields=title", "", nil)
+
+	if err = json.Unmarshal(res.Body.Bytes(), &articleList); err != nil || res.Code != 200 {
+		t.Errorf("Display Articles 'fields': HTTP Status Code '%d'; Response is invalid JSON! Message: %#v", res.Code, err)
+	}
+
+	if _, ok := articleList["Pagination"]; !ok {
+		t.Errorf("Display Articles 'fields': Envelope must keep the Pagination!")
+	}
+
+	if articles, ok := articleList["Articles"].([]interface{}); !ok || len(articles) != 1 {
+		t.Errorf("Display Articles 'fields': Articles '%v' but expected 1 Article", articleList["Articles"])
+	} else if article, ok := articles[0].(map[string]interface{}); !ok || len(article) != 1 || article["title"] != "Fields Article" {
+		t.Errorf("Display Articles 'fields': Article '%v' but expected only the 'title'", articles[0])
+	}
+}
+
+func createRestoreUser(handler *controllers.Handler, searchUser *model.User) {
+	var resUser *model.User
+	var err error
+
+	if resUser, err = handler.Users.GetByLogin(searchUser.Login); resUser == nil || err != nil {
+		fmt.Printf("Login '%s / %s': Create: %#v\n", searchUser.Login, searchUser.Name, err)
+
+		handler.Users.Create(searchUser)
 
 		resUser = searchUser
 	}
@@ -4,25 +4,115 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"gopkg.in/yaml.v3"
 )
 
 type (
+	//==========================================================================
+	// Structure ServerConfig Declaration
+
+	// ServerConfig - Structure for the Web Server Configuration
+	// The Timeouts and the Shutdown Grace Period are given in Seconds.
+	// The Server uses TLS when the Certificate and the Key File are given
+	ServerConfig struct {
+		Host            string `yaml:"host"`
+		Port            uint   `yaml:"port"`
+		ReadTimeout     uint   `yaml:"read_timeout"`
+		WriteTimeout    uint   `yaml:"write_timeout"`
+		IdleTimeout     uint   `yaml:"idle_timeout"`
+		ShutdownTimeout uint   `yaml:"shutdown_timeout"`
+		CertFile        string `yaml:"cert_file"`
+		KeyFile         string `yaml:"key_file"`
+	}
+
 	//==========================================================================
 	// Structure DBConfig Declaration
 
 	// DBConfig - Structure for the Database Configuration
+	// The Driver selects PostgreSQL, MySQL or SQLite where the Name of a SQLite Database
+	// is its File or ":memory:". The SSL Mode and the Root Certificate secure the Connection to the PostgreSQL Server.
+	// The Timeouts and Lifetimes are given in Seconds. The Connection is retried at Startup
+	// with a doubling Delay. A negative Number of Retries connects only once
 	DBConfig struct {
-		Host     string `yaml:"host"`
-		Name     string `yaml:"name"`
-		User     string `yaml:"user"`
-		Password string `yaml:"password"`
+		Driver          string `yaml:"driver"`
+		Host            string `yaml:"host"`
+		Port            uint   `yaml:"port"`
+		Name            string `yaml:"name"`
+		User            string `yaml:"user"`
+		Password        string `yaml:"password" secret:"true"`
+		SSLMode         string `yaml:"ssl_mode"`
+		SSLRootCert     string `yaml:"ssl_root_cert"`
+		Schema          string `yaml:"schema"`
+		ApplicationName string `yaml:"application_name"`
+		ConnectTimeout  uint   `yaml:"connect_timeout"`
+		MaxOpenConns    uint   `yaml:"max_open_conns"`
+		MaxIdleConns    uint   `yaml:"max_idle_conns"`
+		ConnMaxLifetime uint   `yaml:"conn_max_lifetime"`
+		ConnMaxIdleTime uint   `yaml:"conn_max_idle_time"`
+		ConnectRetries  int    `yaml:"connect_retries"`
+		RetryDelay      uint   `yaml:"retry_delay"`
+	}
+
+	//==========================================================================
+	// Structure AuthConfig Declaration
+
+	// AuthConfig - Structure for the Authentication Configuration
+	// The Session and Refresh Expiry are given in Minutes
+	AuthConfig struct {
+		SigningKey     string `yaml:"signing_key" secret:"true"`
+		KeyFile        string `yaml:"key_file"`
+		SessionExpiry  uint   `yaml:"session_expiry"`
+		RefreshExpiry  uint   `yaml:"refresh_expiry"`
+		Issuer         string `yaml:"issuer"`
+		PasswordPepper string `yaml:"password_pepper" secret:"true"`
+	}
+
+	//==========================================================================
+	// Structure SearchConfig Declaration
+
+	// SearchConfig - Structure for the Article Search Configuration
+	// The Language names the PostgreSQL Text Search Configuration
+	SearchConfig struct {
+		Language string `yaml:"language"`
+	}
+
+	//==========================================================================
+	// Structure MediaConfig Declaration
+
+	// MediaConfig - Structure for the Media Upload Configuration
+	// The Storage is either "local" with the Directory or "s3" with the S3 Settings.
+	// The Maximum Size is given in Kilobytes
+	MediaConfig struct {
+		Storage   string   `yaml:"storage"`
+		Directory string   `yaml:"directory"`
+		MaxSize   uint     `yaml:"max_size"`
+		S3        S3Config `yaml:"s3"`
+	}
+
+	// S3Config - Structure for the S3-compatible Object Storage
+	S3Config struct {
+		Endpoint  string `yaml:"endpoint"`
+		Region    string `yaml:"region"`
+		Bucket    string `yaml:"bucket"`
+		AccessKey string `yaml:"access_key"`
+		SecretKey string `yaml:"secret_key" secret:"true"`
+	}
+
+	//==========================================================================
+	// Structure TrashConfig Declaration
+
+	// TrashConfig - Structure for the Trash of the deleted Articles and Users
+	// The Retention is given in Days. A negative Retention keeps the Trash forever
+	TrashConfig struct {
+		RetentionDays int `yaml:"retention_days"`
 	}
 
 	//==========================================================================
@@ -30,13 +120,18 @@ type (
 
 	// AppConfig - Structure for the Application Configuration
 	AppConfig struct {
-		Component     string   `yaml:"component"`
-		Project       string   `yaml:"project"`
-		Description   string   `yaml:"description"`
-		WebRoot       string   `yaml:"web_root"`
-		MainDirectory string   `yaml:"main_directory"`
-		ConfigFile    string   `yaml:"config_file"`
-		DB            DBConfig `yaml:"database"`
+		Component     string       `yaml:"component"`
+		Project       string       `yaml:"project"`
+		Description   string       `yaml:"description"`
+		WebRoot       string       `yaml:"web_root"`
+		MainDirectory string       `yaml:"main_directory"`
+		ConfigFile    string       `yaml:"config_file"`
+		Server        ServerConfig `yaml:"server"`
+		DB            DBConfig     `yaml:"database"`
+		Auth          AuthConfig   `yaml:"auth"`
+		Search        SearchConfig `yaml:"search"`
+		Media         MediaConfig  `yaml:"media"`
+		Trash         TrashConfig  `yaml:"trash"`
 	}
 )
 
@@ -45,6 +140,99 @@ type (
 // for the corresponding operational environment
 const CONFIG_FILE string = ".env"
 
+// DEFAULT_SIGNING_KEY - Publicly known Token Signing Key which is only accepted in Debug Mode
+const DEFAULT_SIGNING_KEY string = "gin-blog"
+
+// DEFAULT_SERVER_PORT - Port which the Web Server listens on
+const DEFAULT_SERVER_PORT uint = 3000
+
+// DEFAULT_READ_TIMEOUT - Time in Seconds to read a Request with its Body
+const DEFAULT_READ_TIMEOUT uint = 30
+
+// DEFAULT_WRITE_TIMEOUT - Time in Seconds to write a Response
+const DEFAULT_WRITE_TIMEOUT uint = 60
+
+// DEFAULT_IDLE_TIMEOUT - Time in Seconds which idle Keep-Alive Connections are kept open
+const DEFAULT_IDLE_TIMEOUT uint = 120
+
+// DEFAULT_SHUTDOWN_TIMEOUT - Grace Period in Seconds for the running Requests at Shutdown
+const DEFAULT_SHUTDOWN_TIMEOUT uint = 30
+
+// DB_DRIVER_POSTGRES - Driver of the PostgreSQL Database
+const DB_DRIVER_POSTGRES string = "postgres"
+
+// DB_DRIVER_MYSQL - Driver of the MySQL Database
+const DB_DRIVER_MYSQL string = "mysql"
+
+// DB_DRIVER_SQLITE - Driver of the SQLite Database
+const DB_DRIVER_SQLITE string = "sqlite"
+
+// DB_SQLITE_MEMORY - Name of the SQLite Database which is kept in Memory
+const DB_SQLITE_MEMORY string = ":memory:"
+
+// DEFAULT_DB_DRIVER - Driver of the Database
+const DEFAULT_DB_DRIVER string = DB_DRIVER_POSTGRES
+
+// DEFAULT_DB_PORT - Port of the PostgreSQL Server
+const DEFAULT_DB_PORT uint = 5432
+
+// DEFAULT_MYSQL_PORT - Port of the MySQL Server
+const DEFAULT_MYSQL_PORT uint = 3306
+
+// DEFAULT_DB_SSL_MODE - SSL Mode of the Database Connection
+const DEFAULT_DB_SSL_MODE string = "disable"
+
+// DEFAULT_DB_CONNECT_TIMEOUT - Time in Seconds to establish a Database Connection
+const DEFAULT_DB_CONNECT_TIMEOUT uint = 10
+
+// DEFAULT_DB_MAX_OPEN_CONNS - Largest Number of open Database Connections
+const DEFAULT_DB_MAX_OPEN_CONNS uint = 20
+
+// DEFAULT_DB_MAX_IDLE_CONNS - Largest Number of idle Database Connections kept in the Pool
+const DEFAULT_DB_MAX_IDLE_CONNS uint = 5
+
+// DEFAULT_DB_CONN_MAX_LIFETIME - Time in Seconds after which a Database Connection is renewed
+const DEFAULT_DB_CONN_MAX_LIFETIME uint = 30 * 60
+
+// DEFAULT_DB_CONN_MAX_IDLE_TIME - Time in Seconds after which an idle Database Connection is closed
+const DEFAULT_DB_CONN_MAX_IDLE_TIME uint = 5 * 60
+
+// DEFAULT_DB_CONNECT_RETRIES - Number of Connection Retries at Startup
+const DEFAULT_DB_CONNECT_RETRIES int = 5
+
+// DEFAULT_DB_RETRY_DELAY - Time in Seconds before the first Connection Retry
+const DEFAULT_DB_RETRY_DELAY uint = 1
+
+// DB_SSL_MODES - SSL Modes which the PostgreSQL Driver accepts
+var DB_SSL_MODES = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// DEFAULT_PASSWORD_PEPPER - Pepper of the Legacy Password Hashes
+const DEFAULT_PASSWORD_PEPPER string = "gin-blog"
+
+// DEFAULT_SESSION_EXPIRY - Validity of an Access Token in Minutes
+const DEFAULT_SESSION_EXPIRY uint = 20
+
+// DEFAULT_REFRESH_EXPIRY - Validity of a Refresh Token in Minutes (7 Days)
+const DEFAULT_REFRESH_EXPIRY uint = 7 * 24 * 60
+
+// DEFAULT_SEARCH_LANGUAGE - Text Search Configuration of the Article Search
+const DEFAULT_SEARCH_LANGUAGE string = "english"
+
+// DEFAULT_MEDIA_STORAGE - Storage Backend of the uploaded Media
+const DEFAULT_MEDIA_STORAGE string = "local"
+
+// DEFAULT_MEDIA_DIRECTORY - Directory of the local Media Storage relative to the Main Directory
+const DEFAULT_MEDIA_DIRECTORY string = "media"
+
+// DEFAULT_MEDIA_MAX_SIZE - Largest Upload Size in Kilobytes (10 MB)
+const DEFAULT_MEDIA_MAX_SIZE uint = 10 * 1024
+
+// DEFAULT_TRASH_RETENTION - Days which deleted Articles and Users are kept in the Trash
+const DEFAULT_TRASH_RETENTION int = 30
+
+// DEFAULT_S3_REGION - Region of the S3-compatible Object Storage
+const DEFAULT_S3_REGION string = "us-east-1"
+
 func existsFile(path string) bool {
 	_, err := os.Stat(path)
 	return !errors.Is(err, os.ErrNotExist)
@@ -96,8 +284,9 @@ func findConfigFile(directory string) (string, error) {
 	return configFile, err
 }
 
-func ReadConfigFile() (AppConfig, error) {
-	var config AppConfig
+// searchConfigFile - Looks for the Configuration File in the Working Directory,
+// the Directory of the Executable and the Home Directory and their Parents
+func searchConfigFile() (string, error) {
 	var homeDir string
 	var currentDir string
 	var exePath string
@@ -110,7 +299,7 @@ func ReadConfigFile() (AppConfig, error) {
 	exePath, err = os.Executable()
 
 	if err != nil {
-		return config, err
+		return "", err
 	}
 
 	exePath, err = filepath.Abs(exePath)
@@ -120,7 +309,7 @@ func ReadConfigFile() (AppConfig, error) {
 	fmt.Printf("App - ReadConfigFile(): exe: %s\n", exePath)
 
 	if err != nil {
-		return config, err
+		return "", err
 	}
 
 	exeDir := path.Dir(exePath)
@@ -143,22 +332,256 @@ func ReadConfigFile() (AppConfig, error) {
 		fmt.Printf("App - ReadConfigFile(): config: %s; error: %#v\n", configFile, err)
 	}
 
-	if configFile != "" && err == nil {
-		var configData []byte
+	return configFile, err
+}
 
-		// Load the file; returns []byte
-		if configData, err = ioutil.ReadFile(configFile); err != nil {
-			return config, err
-		}
+// ReadConfigFile - Loads the Configuration from the Configuration File and the Environment
+// without any Command Line Overrides
+func ReadConfigFile() (AppConfig, error) {
+	return LoadConfig(nil)
+}
+
+// SetDefaults - Fills the unset Database Settings with their Default Values
+// The Application Name defaults to the Project and a relative Root Certificate
+// is looked up within the Main Directory
+func (db *DBConfig) SetDefaults(mainDirectory string, project string) {
+	if db.Driver == "" {
+		db.Driver = DEFAULT_DB_DRIVER
+	}
+
+	if db.Port == 0 && db.Driver == DB_DRIVER_POSTGRES {
+		db.Port = DEFAULT_DB_PORT
+	}
+
+	if db.Port == 0 && db.Driver == DB_DRIVER_MYSQL {
+		db.Port = DEFAULT_MYSQL_PORT
+	}
+
+	// The SQLite Database File is placed within the Main Directory
+	if db.Driver == DB_DRIVER_SQLITE && db.Name != "" && db.Name != DB_SQLITE_MEMORY &&
+		!filepath.IsAbs(db.Name) && mainDirectory != "" {
+		db.Name = filepath.Join(mainDirectory, db.Name)
+	}
+
+	if db.SSLMode == "" {
+		db.SSLMode = DEFAULT_DB_SSL_MODE
+	}
+
+	if db.SSLRootCert != "" && !filepath.IsAbs(db.SSLRootCert) && mainDirectory != "" {
+		db.SSLRootCert = filepath.Join(mainDirectory, db.SSLRootCert)
+	}
+
+	if db.ApplicationName == "" {
+		db.ApplicationName = project
+	}
+
+	if db.ConnectTimeout == 0 {
+		db.ConnectTimeout = DEFAULT_DB_CONNECT_TIMEOUT
+	}
+
+	if db.MaxOpenConns == 0 {
+		db.MaxOpenConns = DEFAULT_DB_MAX_OPEN_CONNS
+	}
+
+	if db.MaxIdleConns == 0 {
+		db.MaxIdleConns = DEFAULT_DB_MAX_IDLE_CONNS
+	}
+
+	if db.ConnMaxLifetime == 0 {
+		db.ConnMaxLifetime = DEFAULT_DB_CONN_MAX_LIFETIME
+	}
+
+	if db.ConnMaxIdleTime == 0 {
+		db.ConnMaxIdleTime = DEFAULT_DB_CONN_MAX_IDLE_TIME
+	}
 
-		// Unmarshal our input YAML file into empty Car (var c)
-		if err = yaml.Unmarshal(configData, &config); err != nil {
-			return config, err
+	if db.ConnectRetries == 0 {
+		db.ConnectRetries = DEFAULT_DB_CONNECT_RETRIES
+	}
+
+	if db.RetryDelay == 0 {
+		db.RetryDelay = DEFAULT_DB_RETRY_DELAY
+	}
+}
+
+// DSN - Connection String of the PostgreSQL Database in the Keyword/Value Format
+// The Schema is set as "search_path" of the Connections
+func (db *DBConfig) DSN() string {
+	settings := []string{
+		"host=" + dsnValue(db.Host),
+		"port=" + strconv.FormatUint(uint64(db.Port), 10),
+		"dbname=" + dsnValue(db.Name),
+		"user=" + dsnValue(db.User),
+		"password=" + dsnValue(db.Password),
+		"sslmode=" + dsnValue(db.SSLMode),
+		"connect_timeout=" + strconv.FormatUint(uint64(db.ConnectTimeout), 10),
+	}
+
+	if db.SSLRootCert != "" {
+		settings = append(settings, "sslrootcert="+dsnValue(db.SSLRootCert))
+	}
+
+	if db.Schema != "" {
+		settings = append(settings, "search_path="+dsnValue(db.Schema))
+	}
+
+	if db.ApplicationName != "" {
+		settings = append(settings, "application_name="+dsnValue(db.ApplicationName))
+	}
+
+	return strings.Join(settings, " ")
+}
+
+// dsnValue - Quotes the Value for the Connection String
+func dsnValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+
+	return "'" + value + "'"
+}
+
+// SetDefaults - Fills the unset Server Settings with their Default Values
+// Relative TLS Files are looked up within the Main Directory
+func (server *ServerConfig) SetDefaults(mainDirectory string) {
+	if server.Port == 0 {
+		server.Port = DEFAULT_SERVER_PORT
+	}
+
+	if server.ReadTimeout == 0 {
+		server.ReadTimeout = DEFAULT_READ_TIMEOUT
+	}
+
+	if server.WriteTimeout == 0 {
+		server.WriteTimeout = DEFAULT_WRITE_TIMEOUT
+	}
+
+	if server.IdleTimeout == 0 {
+		server.IdleTimeout = DEFAULT_IDLE_TIMEOUT
+	}
+
+	if server.ShutdownTimeout == 0 {
+		server.ShutdownTimeout = DEFAULT_SHUTDOWN_TIMEOUT
+	}
+
+	for _, file := range []*string{&server.CertFile, &server.KeyFile} {
+		if *file != "" && !filepath.IsAbs(*file) && mainDirectory != "" {
+			*file = filepath.Join(mainDirectory, *file)
 		}
+	}
+}
 
-		config.MainDirectory = path.Dir(configFile)
-		config.ConfigFile = configFile
+// Address - Host and Port which the Web Server listens on
+// An empty Host listens on all Interfaces
+func (server *ServerConfig) Address() string {
+	return net.JoinHostPort(server.Host, strconv.FormatUint(uint64(server.Port), 10))
+}
+
+// UsesTLS - Checks whether the Web Server serves HTTPS
+func (server *ServerConfig) UsesTLS() bool {
+	return server.CertFile != "" || server.KeyFile != ""
+}
+
+// SetDefaults - Fills the unset Search Settings with their Default Values
+func (search *SearchConfig) SetDefaults() {
+	if search.Language == "" {
+		search.Language = DEFAULT_SEARCH_LANGUAGE
+	}
+}
+
+// SetDefaults - Fills the unset Media Settings with their Default Values
+// A relative Directory is placed within the Main Directory
+func (media *MediaConfig) SetDefaults(mainDirectory string) {
+	if media.Storage == "" {
+		media.Storage = DEFAULT_MEDIA_STORAGE
 	}
 
-	return config, err
+	if media.Directory == "" {
+		media.Directory = DEFAULT_MEDIA_DIRECTORY
+	}
+
+	if !filepath.IsAbs(media.Directory) && mainDirectory != "" {
+		media.Directory = filepath.Join(mainDirectory, media.Directory)
+	}
+
+	if media.MaxSize == 0 {
+		media.MaxSize = DEFAULT_MEDIA_MAX_SIZE
+	}
+
+	if media.S3.Region == "" {
+		media.S3.Region = DEFAULT_S3_REGION
+	}
+}
+
+// SetDefaults - Fills the unset Trash Settings with their Default Values
+func (trash *TrashConfig) SetDefaults() {
+	if trash.RetentionDays == 0 {
+		trash.RetentionDays = DEFAULT_TRASH_RETENTION
+	}
+}
+
+// Retention - Time which the deleted Entities are kept in the Trash
+// It reports false when the Trash is kept forever
+func (trash *TrashConfig) Retention() (time.Duration, bool) {
+	if trash.RetentionDays < 0 {
+		return 0, false
+	}
+
+	days := trash.RetentionDays
+
+	if days == 0 {
+		days = DEFAULT_TRASH_RETENTION
+	}
+
+	return time.Duration(days) * 24 * time.Hour, true
+}
+
+// ReadKeyFile - Loads the Signing Key from the Key File
+// A relative Key File is looked up within the Main Directory
+func (auth *AuthConfig) ReadKeyFile(mainDirectory string) error {
+	if auth.KeyFile == "" {
+		return nil
+	}
+
+	keyFile := auth.KeyFile
+
+	if !filepath.IsAbs(keyFile) && mainDirectory != "" {
+		keyFile = filepath.Join(mainDirectory, keyFile)
+	}
+
+	keyData, err := ioutil.ReadFile(keyFile)
+
+	if err != nil {
+		return fmt.Errorf("Key File '%s': File cannot be read! Message: %v", keyFile, err)
+	}
+
+	auth.SigningKey = strings.TrimSpace(string(keyData))
+
+	if auth.SigningKey == "" {
+		return fmt.Errorf("Key File '%s': File is empty!", keyFile)
+	}
+
+	return nil
+}
+
+// SetDefaults - Fills the unset Authentication Settings with their Default Values
+func (auth *AuthConfig) SetDefaults(project string) {
+	if auth.SigningKey == "" {
+		auth.SigningKey = DEFAULT_SIGNING_KEY
+	}
+
+	if auth.PasswordPepper == "" {
+		auth.PasswordPepper = DEFAULT_PASSWORD_PEPPER
+	}
+
+	if auth.SessionExpiry == 0 {
+		auth.SessionExpiry = DEFAULT_SESSION_EXPIRY
+	}
+
+	if auth.RefreshExpiry == 0 {
+		auth.RefreshExpiry = DEFAULT_REFRESH_EXPIRY
+	}
+
+	if auth.Issuer == "" {
+		auth.Issuer = project
+	}
 }
@@ -0,0 +1,407 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAuthConfig(t *testing.T) {
+	var appConfig AppConfig
+	var err error
+
+	defer gin.SetMode(gin.Mode())
+
+	//-------------------------------------
+	// Test Environment Overrides
+
+	os.Setenv("GINBLOG_AUTH_SIGNING_KEY", "environment-key")
+	os.Setenv("GINBLOG_AUTH_SESSION_EXPIRY", "45")
+
+	defer os.Unsetenv("GINBLOG_AUTH_SIGNING_KEY")
+	defer os.Unsetenv("GINBLOG_AUTH_SESSION_EXPIRY")
+
+	if err = appConfig.ReadEnvironment(); err != nil {
+		t.Errorf("Auth Configuration: Environment is invalid! Message: %#v", err)
+	}
+
+	auth := appConfig.Auth
+	auth.SetDefaults("Gin Blog")
+
+	if auth.SigningKey != "environment-key" {
+		t.Errorf("Auth Configuration: Signing Key '%s' but expected 'environment-key'", auth.SigningKey)
+	}
+
+	if auth.SessionExpiry != 45 {
+		t.Errorf("Auth Configuration: Session Expiry '%d' but expected '45'", auth.SessionExpiry)
+	}
+
+	if auth.Issuer != "Gin Blog" || auth.PasswordPepper != DEFAULT_PASSWORD_PEPPER {
+		t.Errorf("Auth Configuration: Defaults are not set: %#v", auth)
+	}
+
+	gin.SetMode(gin.ReleaseMode)
+
+	if err = validateAuth(auth); err != nil {
+		t.Errorf("Auth Configuration: Signing Key is refused! Message: %v", err)
+	}
+
+	//-------------------------------------
+	// Test Key File
+
+	keyDirectory, err := ioutil.TempDir("", "gin-blog")
+
+	if err != nil {
+		t.Fatalf("Key File: Directory cannot be created! Message: %#v", err)
+	}
+
+	defer os.RemoveAll(keyDirectory)
+
+	if err = ioutil.WriteFile(filepath.Join(keyDirectory, "signing.key"), []byte("file-key\n"), 0600); err != nil {
+		t.Fatalf("Key File: File cannot be written! Message: %#v", err)
+	}
+
+	auth = AuthConfig{KeyFile: "signing.key"}
+
+	if err = auth.ReadKeyFile(keyDirectory); err != nil {
+		t.Errorf("Auth Configuration: Key File cannot be read! Message: %#v", err)
+	}
+
+	if auth.SigningKey != "file-key" {
+		t.Errorf("Auth Configuration: Signing Key '%s' but expected 'file-key'", auth.SigningKey)
+	}
+
+	//-------------------------------------
+	// Test Default Signing Key
+
+	auth = AuthConfig{}
+	auth.SetDefaults("Gin Blog")
+
+	gin.SetMode(gin.DebugMode)
+
+	if err = validateAuth(auth); err != nil {
+		t.Errorf("Auth Configuration: Default Key is refused in Debug Mode! Message: %v", err)
+	}
+
+	gin.SetMode(gin.ReleaseMode)
+
+	if err = validateAuth(auth); err == nil || !strings.Contains(err.Error(), "- auth.signing_key: ") {
+		t.Errorf("Auth Configuration: Default Key is accepted in Release Mode")
+	}
+}
+
+// validateAuth - Validates an otherwise valid Configuration with the Authentication Settings
+func validateAuth(auth AuthConfig) error {
+	appConfig := AppConfig{DB: DBConfig{Host: "localhost", Name: "blog", User: "blog"}}
+	appConfig.SetDefaults()
+	appConfig.Auth = auth
+
+	return appConfig.Validate()
+}
+
+func TestServerConfig(t *testing.T) {
+	var appConfig AppConfig
+
+	//-------------------------------------
+	// Test Environment Overrides
+
+	os.Setenv("GINBLOG_SERVER_HOST", "127.0.0.1")
+	os.Setenv("GINBLOG_SERVER_PORT", "8443")
+	os.Setenv("GINBLOG_SERVER_CERT_FILE", "tls/server.crt")
+
+	defer os.Unsetenv("GINBLOG_SERVER_HOST")
+	defer os.Unsetenv("GINBLOG_SERVER_PORT")
+	defer os.Unsetenv("GINBLOG_SERVER_CERT_FILE")
+
+	if err := appConfig.ReadEnvironment(); err != nil {
+		t.Errorf("Server Configuration: Environment is invalid! Message: %#v", err)
+	}
+
+	server := appConfig.Server
+	server.SetDefaults("/srv/gin-blog")
+
+	if server.Address() != "127.0.0.1:8443" {
+		t.Errorf("Server Configuration: Address '%s' but expected '127.0.0.1:8443'", server.Address())
+	}
+
+	if !server.UsesTLS() || server.CertFile != filepath.Join("/srv/gin-blog", "tls/server.crt") {
+		t.Errorf("Server Configuration: Certificate File '%s' is not within the Main Directory", server.CertFile)
+	}
+
+	if server.ReadTimeout != DEFAULT_READ_TIMEOUT || server.ShutdownTimeout != DEFAULT_SHUTDOWN_TIMEOUT {
+		t.Errorf("Server Configuration: Defaults are not set: %#v", server)
+	}
+
+	os.Setenv("GINBLOG_SERVER_PORT", "http")
+
+	if err := appConfig.ReadEnvironment(); err == nil {
+		t.Errorf("Server Configuration: Invalid Port is accepted")
+	}
+
+	//-------------------------------------
+	// Test Defaults
+
+	server = ServerConfig{}
+	server.SetDefaults("")
+
+	if server.Address() != ":3000" || server.UsesTLS() {
+		t.Errorf("Server Configuration: Default Address '%s' but expected ':3000' without TLS", server.Address())
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	configDirectory, err := ioutil.TempDir("", "gin-blog")
+
+	if err != nil {
+		t.Fatalf("Config File: Directory cannot be created! Message: %#v", err)
+	}
+
+	defer os.RemoveAll(configDirectory)
+
+	configFile := filepath.Join(configDirectory, "blog.yml")
+	secretFile := filepath.Join(configDirectory, "db_password")
+
+	configData := "project: 'Layered Blog'\nserver:\n  host: 'file-host'\n  port: 4000\ndatabase:\n  host: 'file-db'\n  password: 'file-password'\n"
+
+	if err = ioutil.WriteFile(configFile, []byte(configData), 0600); err != nil {
+		t.Fatalf("Config File: File cannot be written! Message: %#v", err)
+	}
+
+	if err = ioutil.WriteFile(secretFile, []byte("secret-password\n"), 0600); err != nil {
+		t.Fatalf("Secret File: File cannot be written! Message: %#v", err)
+	}
+
+	//-------------------------------------
+	// Test Layers
+
+	os.Setenv("GINBLOG_CONFIG_FILE", configFile)
+	os.Setenv("GINBLOG_SERVER_PORT", "5000")
+	os.Setenv("GINBLOG_DATABASE_PASSWORD_FILE", secretFile)
+	os.Setenv("GINBLOG_MEDIA_S3_BUCKET", "environment-bucket")
+
+	defer os.Unsetenv("GINBLOG_CONFIG_FILE")
+	defer os.Unsetenv("GINBLOG_SERVER_PORT")
+	defer os.Unsetenv("GINBLOG_DATABASE_PASSWORD_FILE")
+	defer os.Unsetenv("GINBLOG_MEDIA_S3_BUCKET")
+
+	overrides, args, err := ParseFlags("gin-blog", []string{"-server.port", "6000", "-trash.retention_days=-1", "migrate", "status"})
+
+	if err != nil || len(args) != 2 || args[0] != "migrate" {
+		t.Fatalf("Flags: Arguments '%#v' were not parsed! Message: %#v", args, err)
+	}
+
+	appConfig, err := LoadConfig(overrides)
+
+	if err != nil {
+		t.Fatalf("Layered Configuration: Configuration cannot be loaded! Message: %#v", err)
+	}
+
+	if appConfig.Project != "Layered Blog" || appConfig.Server.Host != "file-host" || appConfig.DB.Host != "file-db" {
+		t.Errorf("Config File: Settings '%#v' were not read", appConfig)
+	}
+
+	if appConfig.Server.Port != 6000 || appConfig.Trash.RetentionDays != -1 {
+		t.Errorf("Flags: Port '%d' and Retention '%d' were not overridden", appConfig.Server.Port, appConfig.Trash.RetentionDays)
+	}
+
+	if appConfig.DB.Password != "secret-password" || appConfig.Media.S3.Bucket != "environment-bucket" {
+		t.Errorf("Environment: Password '%s' and Bucket '%s' were not overridden", appConfig.DB.Password, appConfig.Media.S3.Bucket)
+	}
+
+	if appConfig.WebRoot != DEFAULT_WEB_ROOT || appConfig.MainDirectory != configDirectory {
+		t.Errorf("Defaults: Web Root '%s' and Main Directory '%s' are not set", appConfig.WebRoot, appConfig.MainDirectory)
+	}
+
+	//-------------------------------------
+	// Test Dump
+
+	dump, err := appConfig.Dump()
+
+	if err != nil {
+		t.Fatalf("Dump: Configuration cannot be rendered! Message: %#v", err)
+	}
+
+	if strings.Contains(dump, "secret-password") || !strings.Contains(dump, "signing_key: '"+SECRET_MASK+"'") {
+		t.Errorf("Dump: Secrets are not masked:\n%s", dump)
+	}
+
+	if !strings.Contains(dump, "port: 6000") || appConfig.DB.Password != "secret-password" {
+		t.Errorf("Dump: Configuration was modified or is incomplete:\n%s", dump)
+	}
+
+	//-------------------------------------
+	// Test invalid Layers
+
+	os.Setenv("GINBLOG_DATABASE_PASSWORD", "environment-password")
+
+	if _, err = LoadConfig(nil); err == nil {
+		t.Errorf("Environment: Variable together with its File Variant is accepted")
+	}
+
+	os.Unsetenv("GINBLOG_DATABASE_PASSWORD")
+
+	if _, err = LoadConfig(Overrides{"server.port": "http"}); err == nil {
+		t.Errorf("Flags: Invalid Port is accepted")
+	}
+
+	if _, err = LoadConfig(Overrides{"config_file": filepath.Join(configDirectory, "missing.yml")}); err == nil {
+		t.Errorf("Config File: Missing Config File is accepted")
+	}
+
+	if _, _, err = ParseFlags("gin-blog", []string{"-server.unknown", "1"}); err == nil {
+		t.Errorf("Flags: Unknown Flag is accepted")
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	var appConfig AppConfig
+
+	//-------------------------------------
+	// Test Web Root Normalization
+
+	for webRoot, expected := range map[string]string{"": "/", "api": "/api/", "/api": "/api/", " /api/v1/ ": "/api/v1/"} {
+		if normalized := NormalizeWebRoot(webRoot); normalized != expected {
+			t.Errorf("Web Root '%s': Web Root '%s' but expected '%s'", webRoot, normalized, expected)
+		}
+	}
+
+	//-------------------------------------
+	// Test valid Configuration
+
+	appConfig = AppConfig{WebRoot: "api", DB: DBConfig{Host: "localhost", Name: "blog", User: "blog"}}
+	appConfig.SetDefaults()
+
+	if err := appConfig.Validate(); err != nil || appConfig.WebRoot != "/api/" {
+		t.Errorf("Valid Configuration: Web Root '%s'; Configuration is refused! Message: %v", appConfig.WebRoot, err)
+	}
+
+	//-------------------------------------
+	// Test invalid Configuration
+
+	appConfig = AppConfig{WebRoot: "/api?x/"}
+	appConfig.SetDefaults()
+	appConfig.Server.Port = 70000
+	appConfig.Search.Language = "english; DROP"
+	appConfig.Media.Storage = "s3"
+	appConfig.Media.S3.Endpoint = "s3.local"
+
+	err := appConfig.Validate()
+	validationErr, ok := err.(*ValidationError)
+
+	if !ok {
+		t.Fatalf("Invalid Configuration: ValidationError expected but got '%#v'", err)
+	}
+
+	invalid := []string{"web_root", "server.port", "database.host", "database.name", "database.user",
+		"search.language", "media.s3.endpoint", "media.s3.bucket", "media.s3.access_key", "media.s3.secret_key"}
+
+	if len(validationErr.Problems) != len(invalid) {
+		t.Errorf("Invalid Configuration: %d Problems but expected %d:\n%s", len(validationErr.Problems), len(invalid), err)
+	}
+
+	for _, name := range invalid {
+		if !strings.Contains(err.Error(), "- "+name+": ") {
+			t.Errorf("Invalid Configuration: Setting '%s' is not reported:\n%s", name, err)
+		}
+	}
+
+	if !strings.Contains(err.Error(), "GINBLOG_DATABASE_HOST") {
+		t.Errorf("Invalid Configuration: Environment Variable is not suggested:\n%s", err)
+	}
+
+	if err = appConfig.DB.Validate(); err == nil {
+		t.Errorf("Database Configuration: Missing Host is accepted")
+	}
+}
+
+func TestDBConfig(t *testing.T) {
+	db := DBConfig{Host: "db.local", Name: "blog", User: "blog", Password: `it's\secret`, Schema: "blog", SSLRootCert: "certs/root.crt"}
+	db.SetDefaults("/srv/gin-blog", "Gin Blog")
+
+	//-------------------------------------
+	// Test Defaults
+
+	if db.Port != DEFAULT_DB_PORT || db.SSLMode != DEFAULT_DB_SSL_MODE || db.ApplicationName != "Gin Blog" ||
+		db.MaxOpenConns != DEFAULT_DB_MAX_OPEN_CONNS || db.ConnectRetries != DEFAULT_DB_CONNECT_RETRIES {
+		t.Errorf("Database Configuration: Defaults are not set: %#v", db)
+	}
+
+	if db.SSLRootCert != filepath.Join("/srv/gin-blog", "certs/root.crt") {
+		t.Errorf("Database Configuration: Root Certificate '%s' is not within the Main Directory", db.SSLRootCert)
+	}
+
+	//-------------------------------------
+	// Test Connection String
+
+	expected := `host='db.local' port=5432 dbname='blog' user='blog' password='it\'s\\secret' sslmode='disable' connect_timeout=10 ` +
+		`sslrootcert='/srv/gin-blog/certs/root.crt' search_path='blog' application_name='Gin Blog'`
+
+	if dsn := db.DSN(); dsn != expected {
+		t.Errorf("Database Configuration: Connection String '%s' but expected '%s'", dsn, expected)
+	}
+
+	//-------------------------------------
+	// Test Validation
+
+	db.SSLMode = "always"
+	db.Schema = "blog; DROP"
+	db.MaxIdleConns = db.MaxOpenConns + 1
+
+	err := db.Validate()
+
+	for _, name := range []string{"database.ssl_mode", "database.ssl_root_cert", "database.schema", "database.max_idle_conns"} {
+		if err == nil || !strings.Contains(err.Error(), "- "+name+": ") {
+			t.Errorf("Database Configuration: Setting '%s' is not reported: %v", name, err)
+		}
+	}
+}
+
+func TestDBDrivers(t *testing.T) {
+	//-------------------------------------
+	// Test Driver Defaults
+
+	mysql := DBConfig{Driver: DB_DRIVER_MYSQL, Host: "db.local", Name: "blog", User: "blog"}
+	mysql.SetDefaults("/srv/gin-blog", "Gin Blog")
+
+	if mysql.Port != DEFAULT_MYSQL_PORT {
+		t.Errorf("Database Configuration: MySQL Port '%d' but expected '%d'", mysql.Port, DEFAULT_MYSQL_PORT)
+	}
+
+	sqlite := DBConfig{Driver: DB_DRIVER_SQLITE, Name: "blog.db"}
+	sqlite.SetDefaults("/srv/gin-blog", "Gin Blog")
+
+	if sqlite.Name != filepath.Join("/srv/gin-blog", "blog.db") {
+		t.Errorf("Database Configuration: SQLite File '%s' is not within the Main Directory", sqlite.Name)
+	}
+
+	memory := DBConfig{Driver: DB_DRIVER_SQLITE, Name: DB_SQLITE_MEMORY}
+	memory.SetDefaults("/srv/gin-blog", "Gin Blog")
+
+	if memory.Name != DB_SQLITE_MEMORY {
+		t.Errorf("Database Configuration: SQLite Name '%s' but expected '%s'", memory.Name, DB_SQLITE_MEMORY)
+	}
+
+	//-------------------------------------
+	// Test Driver Validation
+
+	if err := sqlite.Validate(); err != nil {
+		t.Errorf("Database Configuration: SQLite without Host is refused! Message: %v", err)
+	}
+
+	mysql.Schema = "blog"
+
+	if err := mysql.Validate(); err == nil || !strings.Contains(err.Error(), "- database.schema: ") {
+		t.Errorf("Database Configuration: MySQL Schema is not reported: %v", err)
+	}
+
+	unknown := DBConfig{Driver: "oracle", Host: "db.local", Name: "blog", User: "blog"}
+	unknown.SetDefaults("/srv/gin-blog", "Gin Blog")
+
+	if err := unknown.Validate(); err == nil || !strings.Contains(err.Error(), "- database.driver: ") {
+		t.Errorf("Database Configuration: Unknown Driver is not reported: %v", err)
+	}
+}
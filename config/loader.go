@@ -0,0 +1,373 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	//==========================================================================
+	// Structure Overrides Declaration
+
+	// Overrides - Settings given on the Command Line by their Path like "database.password"
+	Overrides map[string]string
+
+	// setting - Field of the Configuration with the Path of its YAML Keys
+	setting struct {
+		path   []string
+		field  reflect.Value
+		secret bool
+	}
+
+	// overrideFlag - Command Line Flag which records the Value of a Setting in the Overrides
+	overrideFlag struct {
+		name      string
+		overrides Overrides
+	}
+)
+
+// ENV_PREFIX - Prefix of the Environment Variables which override the Settings
+const ENV_PREFIX string = "GINBLOG"
+
+// ENV_FILE_SUFFIX - Suffix of the Environment Variables which name a File with the Value like a Docker Secret
+const ENV_FILE_SUFFIX string = "_FILE"
+
+// SECRET_MASK - Replacement of the Secrets in the Configuration Dump
+const SECRET_MASK string = "********"
+
+// DEFAULT_COMPONENT - Name of the Component when it is not configured
+const DEFAULT_COMPONENT string = "unknown"
+
+// DEFAULT_PROJECT - Name of the Project when it is not configured
+const DEFAULT_PROJECT string = "Gin Blog"
+
+// DEFAULT_DESCRIPTION - Description of the Project when it is not configured
+const DEFAULT_DESCRIPTION string = "Web Blog API with GoLang"
+
+// DEFAULT_WEB_ROOT - Path which the Routes are registered below
+const DEFAULT_WEB_ROOT string = "/"
+
+// listSettings - Collects the Fields with a YAML Key of the Structure and its nested Structures
+func listSettings(value reflect.Value, parent []string) []setting {
+	var settings []setting
+
+	for idx := 0; idx < value.NumField(); idx++ {
+		field := value.Type().Field(idx)
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+
+		if key == "" || key == "-" {
+			continue
+		}
+
+		settingPath := append(append([]string{}, parent...), key)
+
+		if field.Type.Kind() == reflect.Struct {
+			settings = append(settings, listSettings(value.Field(idx), settingPath)...)
+
+			continue
+		}
+
+		settings = append(settings, setting{settingPath, value.Field(idx), field.Tag.Get("secret") == "true"})
+	}
+
+	return settings
+}
+
+// Name - Path of the Setting as used by the Command Line Flags like "database.password"
+func (s *setting) Name() string {
+	return strings.Join(s.path, ".")
+}
+
+// EnvironmentName - Environment Variable of the Setting below the Prefix like "GINBLOG_DATABASE_PASSWORD"
+func (s *setting) EnvironmentName(prefix string) string {
+	return prefix + "_" + strings.ToUpper(strings.Join(s.path, "_"))
+}
+
+// Set - Parses the Text into the Field of the Setting
+func (s *setting) Set(text string) error {
+	switch s.field.Kind() {
+	case reflect.String:
+		s.field.SetString(text)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, err := strconv.ParseInt(strings.TrimSpace(text), 10, s.field.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		s.field.SetInt(number)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, err := strconv.ParseUint(strings.TrimSpace(text), 10, s.field.Type().Bits())
+
+		if err != nil {
+			return err
+		}
+
+		s.field.SetUint(number)
+	case reflect.Bool:
+		enabled, err := strconv.ParseBool(strings.TrimSpace(text))
+
+		if err != nil {
+			return err
+		}
+
+		s.field.SetBool(enabled)
+	default:
+		return fmt.Errorf("Type '%s' is not supported!", s.field.Type())
+	}
+
+	return nil
+}
+
+// displayValue - Value of the Setting for the Messages where the Secrets are masked
+func (s *setting) displayValue(text string) string {
+	if s.secret {
+		return SECRET_MASK
+	}
+
+	return text
+}
+
+// readEnvironment - Overrides the Settings of the Structure with the Environment Variables below the Prefix
+// A Variable with the Suffix "_FILE" names a File whose Content is the Value.
+// Giving both Variables of a Setting is refused.
+func readEnvironment(target interface{}, prefix string) error {
+	for _, s := range listSettings(reflect.ValueOf(target).Elem(), nil) {
+		name := s.EnvironmentName(prefix)
+		value, ok := os.LookupEnv(name)
+
+		if valueFile, fromFile := os.LookupEnv(name + ENV_FILE_SUFFIX); fromFile {
+			if ok {
+				return fmt.Errorf("%s: Variable cannot be used together with %s%s!", name, name, ENV_FILE_SUFFIX)
+			}
+
+			valueData, err := ioutil.ReadFile(valueFile)
+
+			if err != nil {
+				return fmt.Errorf("%s%s: File '%s' cannot be read! Message: %v", name, ENV_FILE_SUFFIX, valueFile, err)
+			}
+
+			value, ok = strings.TrimRight(string(valueData), "\r\n"), true
+		}
+
+		if !ok {
+			continue
+		}
+
+		if err := s.Set(value); err != nil {
+			return fmt.Errorf("%s: Value '%s' is invalid! Message: %v", name, s.displayValue(value), err)
+		}
+	}
+
+	return nil
+}
+
+// ReadEnvironment - Overrides the Settings with the GINBLOG_* Variables
+// The Variables are named after the YAML Keys like GINBLOG_DATABASE_PASSWORD
+// and each can be read from a File with GINBLOG_DATABASE_PASSWORD_FILE.
+func (config *AppConfig) ReadEnvironment() error {
+	return readEnvironment(config, ENV_PREFIX)
+}
+
+// ApplyOverrides - Overrides the Settings with the Values given on the Command Line
+func (config *AppConfig) ApplyOverrides(overrides Overrides) error {
+	for _, s := range listSettings(reflect.ValueOf(config).Elem(), nil) {
+		value, ok := overrides[s.Name()]
+
+		if !ok {
+			continue
+		}
+
+		if err := s.Set(value); err != nil {
+			return fmt.Errorf("Flag '-%s': Value '%s' is invalid! Message: %v", s.Name(), s.displayValue(value), err)
+		}
+	}
+
+	return nil
+}
+
+// SetDefaults - Fills the unset Settings of the Application and all Sections with their Default Values
+func (config *AppConfig) SetDefaults() {
+	if config.Component == "" {
+		config.Component = DEFAULT_COMPONENT
+	}
+
+	if config.Project == "" {
+		config.Project = DEFAULT_PROJECT
+	}
+
+	if config.Description == "" {
+		config.Description = DEFAULT_DESCRIPTION
+	}
+
+	if config.WebRoot == "" {
+		config.WebRoot = DEFAULT_WEB_ROOT
+	}
+
+	config.WebRoot = NormalizeWebRoot(config.WebRoot)
+
+	config.Server.SetDefaults(config.MainDirectory)
+	config.DB.SetDefaults(config.MainDirectory, config.Project)
+	config.Auth.SetDefaults(config.Project)
+	config.Search.SetDefaults()
+	config.Media.SetDefaults(config.MainDirectory)
+	config.Trash.SetDefaults()
+}
+
+// Dump - Renders the effective Configuration as YAML where the Secrets are masked
+func (config AppConfig) Dump() (string, error) {
+	for _, s := range listSettings(reflect.ValueOf(&config).Elem(), nil) {
+		if s.secret && s.field.String() != "" {
+			s.field.SetString(SECRET_MASK)
+		}
+	}
+
+	configData, err := yaml.Marshal(&config)
+
+	return string(configData), err
+}
+
+// String - Shows the recorded Value of the Flag
+func (f *overrideFlag) String() string {
+	if f.overrides == nil {
+		return ""
+	}
+
+	return f.overrides[f.name]
+}
+
+// Set - Records the Value of the Flag in the Overrides
+func (f *overrideFlag) Set(value string) error {
+	f.overrides[f.name] = value
+
+	return nil
+}
+
+// NewFlagSet - Creates a Command Line Flag for each Setting like "-database.password"
+// The given Values are recorded in the Overrides.
+func NewFlagSet(name string, overrides Overrides) *flag.FlagSet {
+	var config AppConfig
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+
+	// The Errors are reported by the Caller together with its Usage
+	flags.SetOutput(ioutil.Discard)
+
+	for _, s := range listSettings(reflect.ValueOf(&config).Elem(), nil) {
+		flags.Var(&overrideFlag{s.Name(), overrides}, s.Name(),
+			fmt.Sprintf("Overrides the Setting '%s' (Environment: %s)", s.Name(), s.EnvironmentName(ENV_PREFIX)))
+	}
+
+	return flags
+}
+
+// ParseFlags - Reads the Command Line Flags in front of the Command
+// It returns the Overrides and the remaining Arguments.
+func ParseFlags(name string, args []string) (Overrides, []string, error) {
+	overrides := make(Overrides)
+
+	flags := NewFlagSet(name, overrides)
+
+	if err := flags.Parse(args); err != nil {
+		return overrides, nil, err
+	}
+
+	return overrides, flags.Args(), nil
+}
+
+// LoadConfig - Loads the Configuration in Layers
+// The Default Values are overridden by the Configuration File, then by the
+// GINBLOG_* Variables and at last by the Command Line Overrides.
+// The Configuration File is searched for unless it is given with "-config_file"
+// or GINBLOG_CONFIG_FILE. Without a Configuration File only the Environment is used.
+func LoadConfig(overrides Overrides) (AppConfig, error) {
+	var config AppConfig
+	var configFile string
+	var err error
+
+	if value, ok := overrides["config_file"]; ok {
+		configFile = value
+	} else if configFile, err = lookupEnvironmentFile(ENV_PREFIX + "_CONFIG_FILE"); err != nil {
+		return config, err
+	}
+
+	if configFile != "" {
+		if configFile, err = filepath.Abs(configFile); err != nil {
+			return config, err
+		}
+
+		if !existsFile(configFile) {
+			return config, fmt.Errorf("Config File '%s': File does not exist!", configFile)
+		}
+	} else if configFile, err = searchConfigFile(); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return config, err
+		}
+
+		fmt.Printf("App - LoadConfig(): Config File is missing. Using the Environment only\n")
+	}
+
+	if configFile != "" {
+		if err = readConfigFile(&config, configFile); err != nil {
+			return config, err
+		}
+	}
+
+	if err = config.ReadEnvironment(); err != nil {
+		return config, err
+	}
+
+	if err = config.ApplyOverrides(overrides); err != nil {
+		return config, err
+	}
+
+	if configFile != "" {
+		config.ConfigFile = configFile
+	}
+
+	if err = config.Auth.ReadKeyFile(config.MainDirectory); err != nil {
+		return config, err
+	}
+
+	config.SetDefaults()
+
+	return config, nil
+}
+
+// lookupEnvironmentFile - Reads the Variable without its "_FILE" Variant
+func lookupEnvironmentFile(name string) (string, error) {
+	if _, ok := os.LookupEnv(name + ENV_FILE_SUFFIX); ok {
+		return "", fmt.Errorf("%s%s: Variable is not supported!", name, ENV_FILE_SUFFIX)
+	}
+
+	return os.Getenv(name), nil
+}
+
+// readConfigFile - Parses the YAML Configuration File into the Configuration
+func readConfigFile(config *AppConfig, configFile string) error {
+	var configData []byte
+	var err error
+
+	if configData, err = ioutil.ReadFile(configFile); err != nil {
+		return err
+	}
+
+	if err = yaml.Unmarshal(configData, config); err != nil {
+		return fmt.Errorf("Config File '%s': File is invalid! Message: %v", configFile, err)
+	}
+
+	config.MainDirectory = path.Dir(configFile)
+	config.ConfigFile = configFile
+
+	return nil
+}
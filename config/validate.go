@@ -0,0 +1,209 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	//==========================================================================
+	// Structure ValidationError Declaration
+
+	// ValidationError - Lists every invalid Setting of the Configuration
+	ValidationError struct {
+		Problems []string
+	}
+
+	// validation - Collects the Problems of the Settings
+	validation struct {
+		problems []string
+	}
+)
+
+// SEARCH_LANGUAGE_PATTERN - Name of a PostgreSQL Text Search Configuration
+var SEARCH_LANGUAGE_PATTERN = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SCHEMA_PATTERN - Name of a PostgreSQL Schema
+var SCHEMA_PATTERN = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// WEB_ROOT_PATTERN - Path Segments which the Routes can be registered below
+var WEB_ROOT_PATTERN = regexp.MustCompile(`^/([A-Za-z0-9._~-]+/)*$`)
+
+func (err *ValidationError) Error() string {
+	return fmt.Sprintf("Configuration is invalid! %d Settings need to be fixed:\n  - %s",
+		len(err.Problems), strings.Join(err.Problems, "\n  - "))
+}
+
+// add - Records the Problem of the Setting with the Hint how to override it
+func (v *validation) add(name string, format string, args ...interface{}) {
+	hint := fmt.Sprintf("(Flag: -%s; Environment: %s_%s)", name, ENV_PREFIX, strings.ToUpper(strings.ReplaceAll(name, ".", "_")))
+
+	v.problems = append(v.problems, fmt.Sprintf("%s: %s %s", name, fmt.Sprintf(format, args...), hint))
+}
+
+// require - Records the Setting as missing if it is empty
+func (v *validation) require(name string, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.add(name, "Setting is missing!")
+	}
+}
+
+// result - Reports the collected Problems as ValidationError
+func (v *validation) result() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+
+	return &ValidationError{v.problems}
+}
+
+// NormalizeWebRoot - Gives the Web Root a leading and a trailing Slash
+// so that the Routes can be appended like WebRoot + "users"
+func NormalizeWebRoot(webRoot string) string {
+	webRoot = strings.TrimSpace(webRoot)
+
+	if !strings.HasPrefix(webRoot, "/") {
+		webRoot = "/" + webRoot
+	}
+
+	if !strings.HasSuffix(webRoot, "/") {
+		webRoot += "/"
+	}
+
+	return webRoot
+}
+
+// Validate - Checks every Setting of the loaded Configuration
+// It returns a ValidationError which lists all invalid Settings at once.
+func (config *AppConfig) Validate() error {
+	var v validation
+
+	if !WEB_ROOT_PATTERN.MatchString(config.WebRoot) {
+		v.add("web_root", "Web Root '%s' is not a Path like '/' or '/api/'!", config.WebRoot)
+	}
+
+	config.Server.validate(&v)
+	config.DB.validate(&v)
+	config.Auth.validate(&v)
+	config.Search.validate(&v)
+	config.Media.validate(&v)
+
+	return v.result()
+}
+
+// Validate - Checks the Settings which are needed to connect to the Database
+func (db *DBConfig) Validate() error {
+	var v validation
+
+	db.validate(&v)
+
+	return v.result()
+}
+
+func (server *ServerConfig) validate(v *validation) {
+	if server.Port == 0 || server.Port > 65535 {
+		v.add("server.port", "Port '%d' is not between 1 and 65535!", server.Port)
+	}
+
+	if server.CertFile != "" && !existsFile(server.CertFile) {
+		v.add("server.cert_file", "Certificate File '%s' does not exist!", server.CertFile)
+	}
+
+	if server.KeyFile != "" && !existsFile(server.KeyFile) {
+		v.add("server.key_file", "Key File '%s' does not exist!", server.KeyFile)
+	}
+
+	if server.CertFile == "" && server.KeyFile != "" {
+		v.add("server.cert_file", "Certificate File is missing for the Key File!")
+	}
+
+	if server.KeyFile == "" && server.CertFile != "" {
+		v.add("server.key_file", "Key File is missing for the Certificate File!")
+	}
+}
+
+func (db *DBConfig) validate(v *validation) {
+	switch db.Driver {
+	case DB_DRIVER_SQLITE:
+		v.require("database.name", db.Name)
+
+		return
+	case DB_DRIVER_POSTGRES, DB_DRIVER_MYSQL:
+	default:
+		v.add("database.driver", "Driver '%s' is unknown! Use '%s', '%s' or '%s'", db.Driver, DB_DRIVER_POSTGRES, DB_DRIVER_MYSQL, DB_DRIVER_SQLITE)
+
+		return
+	}
+
+	v.require("database.host", db.Host)
+	v.require("database.name", db.Name)
+	v.require("database.user", db.User)
+
+	if db.Port == 0 || db.Port > 65535 {
+		v.add("database.port", "Port '%d' is not between 1 and 65535!", db.Port)
+	}
+
+	sslMode := false
+
+	for _, mode := range DB_SSL_MODES {
+		sslMode = sslMode || db.SSLMode == mode
+	}
+
+	if !sslMode {
+		v.add("database.ssl_mode", "SSL Mode '%s' is unknown! Use one of '%s'", db.SSLMode, strings.Join(DB_SSL_MODES, "', '"))
+	}
+
+	if db.SSLRootCert != "" && !existsFile(db.SSLRootCert) {
+		v.add("database.ssl_root_cert", "Root Certificate '%s' does not exist!", db.SSLRootCert)
+	}
+
+	if db.Schema != "" && db.Driver != DB_DRIVER_POSTGRES {
+		v.add("database.schema", "Schema is only supported by PostgreSQL! Use 'database.name' for MySQL")
+	} else if db.Schema != "" && !SCHEMA_PATTERN.MatchString(db.Schema) {
+		v.add("database.schema", "Schema '%s' is not a Schema Name like 'public'!", db.Schema)
+	}
+
+	if db.MaxIdleConns > db.MaxOpenConns {
+		v.add("database.max_idle_conns", "Idle Connections '%d' exceed the open Connections '%d'!", db.MaxIdleConns, db.MaxOpenConns)
+	}
+}
+
+func (auth *AuthConfig) validate(v *validation) {
+	v.require("auth.signing_key", auth.SigningKey)
+
+	if auth.SigningKey == DEFAULT_SIGNING_KEY && gin.Mode() != gin.DebugMode {
+		v.add("auth.signing_key", "Default Signing Key is only allowed in '%s' Mode! Configure a Key or 'auth.key_file'", gin.DebugMode)
+	}
+
+	if auth.RefreshExpiry < auth.SessionExpiry {
+		v.add("auth.refresh_expiry", "Refresh Expiry '%d' is shorter than the Session Expiry '%d'!", auth.RefreshExpiry, auth.SessionExpiry)
+	}
+}
+
+func (search *SearchConfig) validate(v *validation) {
+	if !SEARCH_LANGUAGE_PATTERN.MatchString(search.Language) {
+		v.add("search.language", "Language '%s' is not a Text Search Configuration like 'english'!", search.Language)
+	}
+}
+
+func (media *MediaConfig) validate(v *validation) {
+	switch media.Storage {
+	case "local":
+		v.require("media.directory", media.Directory)
+	case "s3":
+		v.require("media.s3.bucket", media.S3.Bucket)
+		v.require("media.s3.access_key", media.S3.AccessKey)
+		v.require("media.s3.secret_key", media.S3.SecretKey)
+
+		if endpoint, err := url.Parse(media.S3.Endpoint); err != nil || endpoint.Host == "" ||
+			(endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+			v.add("media.s3.endpoint", "Endpoint '%s' is not a URL like 'https://s3.amazonaws.com'!", media.S3.Endpoint)
+		}
+	default:
+		v.add("media.storage", "Storage '%s' is unknown! Use 'local' or 's3'", media.Storage)
+	}
+}
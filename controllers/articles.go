@@ -5,50 +5,56 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 
 	"gin-blog/config"
 	"gin-blog/model"
+	"gin-blog/repository"
 )
 
-func MigrateArticles(db *gorm.DB) error {
-
-	//Copy reference to global database
-	if DATABASE == nil {
-		DATABASE = db
-	}
-
-	// Automigrate the Article model
-	err := db.AutoMigrate(&model.Article{})
-
-	if err != nil {
-		fmt.Println("Model 'Article': Auto Migration failed")
-	}
-
-	return err
-}
-
-func RegisterArticleRoutes(engine *gin.Engine, config *config.AppConfig) {
+func RegisterArticleRoutes(engine *gin.Engine, config *config.AppConfig, handler *Handler) {
 
 	if PROJECT == "" {
 		// Copy the Project Name
 		PROJECT = config.Project
 	}
 
+	// Copy the Handler Settings
+	handler.configure(config)
+
 	// Article Routes
-	engine.GET(config.WebRoot+"articles", DisplayArticles)
-	engine.GET(config.WebRoot+"articles/:id", DisplayArticle)
-	engine.PUT(config.WebRoot+"articles/:id", AuthorizeRequest(), UpdateArticle)
-	engine.POST(config.WebRoot+"articles", AuthorizeRequest(), CreateArticle)
-	engine.DELETE(config.WebRoot+"articles/:id", AuthorizeRequest(), DeleteArticle)
+	engine.GET(config.WebRoot+"articles", handler.IdentifyRequest(), handler.DisplayArticles)
+	engine.GET(config.WebRoot+"articles/:id", handler.IdentifyRequest(), handler.DisplayArticle)
+	engine.GET(config.WebRoot+"articles/by-slug/:slug", handler.IdentifyRequest(), handler.DisplayArticleBySlug)
+	engine.PUT(config.WebRoot+"articles/:id", handler.AuthorizeRequest(), handler.UpdateArticle)
+	engine.POST(config.WebRoot+"articles", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionWriteArticles), handler.CreateArticle)
+	engine.DELETE(config.WebRoot+"articles/:id", handler.AuthorizeRequest(), handler.DeleteArticle)
+
+	// Article Search Route
+	engine.GET(config.WebRoot+"search", handler.IdentifyRequest(), handler.SearchArticles)
+
+	// Article Comment Routes
+	engine.GET(config.WebRoot+"articles/:id/comments", handler.IdentifyRequest(), handler.DisplayComments)
+	engine.GET(config.WebRoot+"articles/:id/comments/moderation", handler.AuthorizeRequest(), handler.DisplayModerationQueue)
+	engine.POST(config.WebRoot+"articles/:id/comments", handler.IdentifyRequest(), handler.CreateComment)
+	engine.PUT(config.WebRoot+"articles/:id/comments/:comment", handler.AuthorizeRequest(), handler.ModerateComment)
+
+	// Article Revision Routes
+	engine.GET(config.WebRoot+"articles/:id/revisions", handler.AuthorizeRequest(), handler.DisplayRevisions)
+	engine.GET(config.WebRoot+"articles/:id/revisions/:number", handler.AuthorizeRequest(), handler.DisplayRevision)
+	engine.GET(config.WebRoot+"articles/:id/revisions/:number/diff", handler.AuthorizeRequest(), handler.DisplayRevisionDiff)
+	engine.POST(config.WebRoot+"articles/:id/revisions/:number/restore", handler.AuthorizeRequest(), handler.RestoreRevision)
+
+	// Author Article Routes
+	engine.GET(config.WebRoot+"users/:id/articles", handler.IdentifyRequest(), handler.DisplayUserArticles)
+	engine.GET(config.WebRoot+"authors/:slug/articles", handler.IdentifyRequest(), handler.DisplayAuthorArticles)
 }
 
-func DisplayArticle(c *gin.Context) {
+func (handler *Handler) DisplayArticle(c *gin.Context) {
 	var article *model.Article
 	var displayed model.DisplayedArticle
-	var user *model.User
 	var articleId uint64
 	var err error
 
@@ -71,7 +77,7 @@ func DisplayArticle(c *gin.Context) {
 
 	fmt.Printf("Controller 'Articles': Article ID 1: %#v\n", articleId)
 
-	if article, err = GetArticleByID(uint(articleId)); article == nil || err != nil {
+	if article, err = handler.Articles.GetByID(uint(articleId)); article == nil || err != nil || !GetAuthUser(c).CanViewArticle(article) {
 
 		fmt.Printf("Controller 'Articles': Article (ID '%d'): %#v; Error: %#v\n", articleId, article, err)
 
@@ -93,95 +99,448 @@ func DisplayArticle(c *gin.Context) {
 		return
 	}
 
-	displayed = model.NewDisplayedArticle(article)
+	displayed = handler.newDisplayedArticle(article)
 
-	if user, err = GetUserByID(article.UserID); user == nil || err != nil {
+	dispatchView(c, "articles", displayed)
+}
 
-		fmt.Printf("Controller 'Articles': User (ID '%d'): %#v; Error: %#v\n", article.UserID, user, err)
+func (handler *Handler) DisplayArticleBySlug(c *gin.Context) {
+	articleSlug := c.Params.ByName("slug")
 
-		displayed.Author = "Unknown"
+	article, redirected, err := handler.findArticleBySlug(articleSlug)
+
+	if article == nil || err != nil || !GetAuthUser(c).CanViewArticle(article) {
+		fmt.Printf("Controller 'Articles': Article (Slug '%s'): %#v; Error: %#v\n", articleSlug, article, err)
+
+		if err != nil && !repository.IsNotFound(err) {
+			AbortWithStorageError(c, "articles", err)
+
+			return
+		}
+
+		c.JSON(http.StatusNotFound,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusNotFound,
+				"articles",
+				"Not Found",
+				fmt.Sprintf("Article (Slug: '%s'): Article does not exist", articleSlug),
+			})
+
+		return
 	}
 
-	if user != nil {
-		displayed.Author = user.Name
-		displayed.AuthorSlug = user.Slug
+	if redirected {
+		dispatchSlugRedirect(c, article.Slug)
+
+		return
 	}
 
-	c.JSON(http.StatusOK, displayed)
+	dispatchView(c, "articles", handler.newDisplayedArticle(article))
 }
 
-func DisplayArticles(c *gin.Context) {
-	var articles []model.Article
-	var userId int
+func (handler *Handler) DisplayArticles(c *gin.Context) {
+	var query repository.ArticleQuery
 	var err error
 
-	userIdString := c.Params.ByName("userId")
+	if query, err = ParseArticleQuery(c); err != nil {
+		c.JSON(http.StatusUnprocessableEntity,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusUnprocessableEntity,
+				"articles",
+				"Unprocessable Content",
+				err.Error(),
+			})
 
-	if userIdString == "" {
-		userIdString = c.Params.ByName("user_id")
+		return
 	}
 
-	if userIdString != "" {
-		if userId, err = strconv.Atoi(userIdString); err != nil {
-			c.JSON(http.StatusUnprocessableEntity,
-				APIErrorResponse{
-					PROJECT + " - Error",
-					http.StatusUnprocessableEntity,
-					"articles",
-					"Unprocessable Content",
-					"User ID: ID is invalid! Message: " + err.Error(),
-				})
+	if matched, err := handler.applyAuthorFilter(c, &query); err != nil {
+		AbortWithStorageError(c, "articles", err)
 
-			return
-		}
+		return
+	} else if !matched {
+		// An unknown Author has no Articles
+		dispatchListView(c, "articles", newArticleListSuccess(c, &query, nil, 0, nil), "Articles")
 
-		articles = GetArticlesByUserID(uint(userId))
-	} else {
-		DATABASE.Find(&articles)
+		return
 	}
 
-	var displayedArticles []model.DisplayedArticle
-	var articleMap map[uint]*model.Article = make(map[uint]*model.Article)
+	handler.dispatchArticleList(c, &query, nil)
+}
+
+func (handler *Handler) DisplayUserArticles(c *gin.Context) {
+	var query repository.ArticleQuery
+	var user *model.User
+	var userId uint64
+	var err error
+
+	userIdString := c.Params.ByName("id")
+
+	if userId, err = strconv.ParseUint(userIdString, 10, 64); err != nil {
+		c.JSON(http.StatusUnprocessableEntity,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusUnprocessableEntity,
+				"articles",
+				"Unprocessable Content",
+				"User ID: ID is invalid! Message: " + err.Error(),
+			})
+
+		return
+	}
+
+	if query, err = ParseArticleQuery(c); err != nil {
+		c.JSON(http.StatusUnprocessableEntity,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusUnprocessableEntity,
+				"articles",
+				"Unprocessable Content",
+				err.Error(),
+			})
+
+		return
+	}
+
+	if user, err = handler.Users.GetByID(uint(userId)); user == nil || err != nil {
+		handler.dispatchAuthorNotFound(c, fmt.Sprintf("User (ID: '%d'): User does not exist", userId), err)
+
+		return
+	}
+
+	query.UserID = user.ID
+
+	handler.dispatchArticleList(c, &query, user)
+}
+
+func (handler *Handler) DisplayAuthorArticles(c *gin.Context) {
+	var query repository.ArticleQuery
+	var user *model.User
+	var redirected bool
+	var err error
+
+	authorSlug := c.Params.ByName("slug")
+
+	if query, err = ParseArticleQuery(c); err != nil {
+		c.JSON(http.StatusUnprocessableEntity,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusUnprocessableEntity,
+				"articles",
+				"Unprocessable Content",
+				err.Error(),
+			})
+
+		return
+	}
+
+	if user, redirected, err = handler.findUserBySlug(authorSlug); user == nil || err != nil {
+		handler.dispatchAuthorNotFound(c, fmt.Sprintf("Author (Slug: '%s'): Author does not exist", authorSlug), err)
+
+		return
+	}
+
+	if redirected {
+		dispatchSlugRedirect(c, user.Slug)
+
+		return
+	}
+
+	query.UserID = user.ID
+
+	handler.dispatchArticleList(c, &query, user)
+}
+
+// ResolveAuthors - Looks up the Authors of all Articles with one Query
+func (handler *Handler) ResolveAuthors(articles []model.Article) (map[uint]*model.User, error) {
 	var userMap map[uint]*model.User = make(map[uint]*model.User)
 	var userIDs []uint
 
-	for idx, article := range articles {
-		displayedArticles = append(displayedArticles, model.NewDisplayedArticle(&article))
-		articleMap[article.ID] = &articles[idx]
+	for _, article := range articles {
+		if _, ok := userMap[article.UserID]; !ok && article.UserID != 0 {
+			userMap[article.UserID] = nil
+			userIDs = append(userIDs, article.UserID)
+		}
+	}
+
+	users, err := handler.Users.GetByIDs(userIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, user := range users {
+		userMap[user.ID] = &users[idx]
+	}
+
+	fmt.Printf("Controller 'Articles': User Map: %#v\n", userMap)
+
+	return userMap, nil
+}
+
+func (handler *Handler) dispatchArticleList(c *gin.Context, query *repository.ArticleQuery, author *model.User) {
+	var displayedArticles []model.DisplayedArticle
+	var err error
+
+	restrictArticleQuery(c, query)
+
+	articles, total, ok := handler.findArticles(c, query)
+
+	if !ok {
+		return
+	}
+
+	if displayedArticles, err = handler.newDisplayedArticles(articles); err != nil {
+		AbortWithStorageError(c, "articles", err)
+
+		return
+	}
+
+	dispatchListView(c, "articles", newArticleListSuccess(c, query, displayedArticles, total, author), "Articles")
+}
+
+// findArticles - Looks up the Articles matching the Query and the Tag and Category Parameters
+// An unknown Tag or Category has no Articles.
+// It dispatches the Error Response itself and reports whether the Articles could be looked up.
+func (handler *Handler) findArticles(c *gin.Context, query *repository.ArticleQuery) ([]model.Article, int64, bool) {
+	matched, err := handler.applyTaxonomyFilters(c, query)
+
+	if err != nil {
+		AbortWithStorageError(c, "articles", err)
+
+		return nil, 0, false
 	}
 
-	for userID, _ := range userMap {
-		userIDs = append(userIDs, userID)
+	if !matched {
+		return nil, 0, true
 	}
 
-	userRes := GetUsersByIDs(userIDs)
+	articles, total, err := handler.Articles.Find(query)
 
-	fmt.Printf("Controller 'Articles': Users: %#v\n", userRes)
+	if err != nil {
+		AbortWithStorageError(c, "articles", err)
 
-	if userRes != nil {
-		for idx, user := range *userRes {
-			userMap[user.ID] = &(*userRes)[idx]
+		return nil, 0, false
+	}
+
+	return articles, total, true
+}
+
+// applyAuthorFilter - Restricts the Query to the Author given by the Slug Parameter 'author'
+// It reports whether any Article can match the Filters.
+func (handler *Handler) applyAuthorFilter(c *gin.Context, query *repository.ArticleQuery) (bool, error) {
+	authorSlug := c.Query("author")
+
+	if authorSlug == "" {
+		return true, nil
+	}
+
+	author, err := handler.Users.GetBySlug(authorSlug)
+
+	if author == nil || err != nil {
+		if err != nil && !repository.IsNotFound(err) {
+			return false, err
 		}
+
+		return false, nil
 	}
 
-	fmt.Printf("Controller 'Articles': User Map: %#v\n", userMap)
+	if query.UserID != 0 && query.UserID != author.ID {
+		// Contradicting Author Filters match no Articles
+		return false, nil
+	}
 
-	for idx, displayed := range displayedArticles {
-		article := articleMap[displayed.ID]
+	query.UserID = author.ID
 
-		if user, ok := userMap[article.UserID]; ok {
-			fmt.Printf("Controller 'Articles': User (ID: '%d'): %#v\n", article.UserID, user)
+	return true, nil
+}
 
-			displayedArticles[idx].Author = user.Name
-			displayedArticles[idx].AuthorSlug = user.Slug
+// restrictArticleQuery - Restricts the Query to the Articles visible to the Authorized User
+func restrictArticleQuery(c *gin.Context, query *repository.ArticleQuery) {
+	if authUser := GetAuthUser(c); authUser == nil || !authUser.Can(model.PermissionPublishArticles) {
+		// Unpublished Articles are only listed for their Author
+		query.PublicOnly = true
+
+		if authUser != nil {
+			query.OwnerID = authUser.ID
 		}
 	}
+}
+
+// checkArticleStatus - Completes the Workflow Stage of the Article and checks whether the Editor may change it
+// Only Editors can schedule, publish or archive Articles and withdraw them from these Stages.
+// It dispatches the Error Response itself and reports whether the Article can be stored.
+func checkArticleStatus(c *gin.Context, editor *model.User, article *model.Article, former *model.Article) bool {
+	statusChanged := article.Status != former.Status
+	publishChanged := !equalTimes(article.PublishedAt, former.PublishedAt)
+
+	if !statusChanged && !publishChanged {
+		// The Workflow Stage is kept
+		return true
+	}
+
+	if err := article.NormalizeStatus(time.Now()); err != nil {
+		c.JSON(http.StatusUnprocessableEntity,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusUnprocessableEntity,
+				"articles",
+				"Unprocessable Content",
+				err.Error(),
+			})
+
+		return false
+	}
+
+	editorial := article.Status.IsEditorial() || former.Status.IsEditorial()
+
+	if editorial && !editor.Can(model.PermissionPublishArticles) {
+		AbortForbidden(c, fmt.Sprintf("User (ID: '%d'): Articles can only be scheduled, published or archived by an Editor!", editor.ID))
 
-	c.JSON(http.StatusOK, displayedArticles)
+		return false
+	}
+
+	return true
 }
 
-func CreateArticle(c *gin.Context) {
+func equalTimes(left *time.Time, right *time.Time) bool {
+	if left == nil || right == nil {
+		return left == right
+	}
+
+	return left.Equal(*right)
+}
+
+// newDisplayedArticle - Looks up the Author, Tags and Category of a single Article for its View
+func (handler *Handler) newDisplayedArticle(article *model.Article) model.DisplayedArticle {
+	displayed, err := handler.newDisplayedArticles([]model.Article{*article})
+
+	if err != nil {
+		fmt.Printf("Controller 'Articles': Article (ID '%d'): View failed! Error: %#v\n", article.ID, err)
+
+		return newAuthorArticle(article, nil)
+	}
+
+	return displayed[0]
+}
+
+// newDisplayedArticles - Looks up the Authors, Tags, Categories and Comment Counts of all Articles for their Views
+func (handler *Handler) newDisplayedArticles(articles []model.Article) ([]model.DisplayedArticle, error) {
+	var displayedArticles []model.DisplayedArticle
+	var articleIDs []uint
+	var tree *model.CategoryTree
+
+	userMap, err := handler.ResolveAuthors(articles)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, article := range articles {
+		articleIDs = append(articleIDs, article.ID)
+
+		if article.CategoryID != nil && tree == nil {
+			categories, err := handler.Categories.List()
+
+			if err != nil {
+				return nil, err
+			}
+
+			tree = model.NewCategoryTree(categories)
+		}
+	}
+
+	tagMap, err := handler.Tags.GetByArticleIDs(articleIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	commentCounts, err := handler.Comments.CountByArticleIDs(articleIDs, model.CommentApproved)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range articles {
+		displayed := newAuthorArticle(&articles[idx], userMap[articles[idx].UserID])
+
+		displayed.Tags = model.NewDisplayedTags(tagMap[articles[idx].ID])
+		displayed.CommentCount = commentCounts[articles[idx].ID]
+
+		if articles[idx].CategoryID != nil {
+			if category, ok := tree.Categories[*articles[idx].CategoryID]; ok {
+				displayed.Category = category.Name
+				displayed.CategorySlug = category.Slug
+			}
+		}
+
+		displayedArticles = append(displayedArticles, displayed)
+	}
+
+	return displayedArticles, nil
+}
+
+func newAuthorArticle(article *model.Article, user *model.User) model.DisplayedArticle {
+	displayed := model.NewDisplayedArticle(article)
+
+	if user != nil {
+		displayed.Author = user.Name
+		displayed.AuthorSlug = user.Slug
+	} else {
+		displayed.Author = "Unknown"
+	}
+
+	return displayed
+}
+
+func (handler *Handler) dispatchAuthorNotFound(c *gin.Context, desc string, err error) {
+	if err != nil && !repository.IsNotFound(err) {
+		AbortWithStorageError(c, "articles", err)
+
+		return
+	}
+
+	c.JSON(http.StatusNotFound,
+		APIErrorResponse{
+			PROJECT + " - Error",
+			http.StatusNotFound,
+			"articles",
+			"Not Found",
+			desc,
+		})
+}
+
+func newArticleListSuccess(c *gin.Context, query *repository.ArticleQuery, articles []model.DisplayedArticle, total int64, author *model.User) ArticleListSuccess {
+	var list ArticleListSuccess = ArticleListSuccess{
+		PROJECT + " - Articles",
+		http.StatusOK,
+		"articles",
+		"OK",
+		NewPagination(c, query.Page, query.PerPage, total),
+		nil,
+		articles,
+	}
+
+	if articles == nil {
+		// Always dispatch a JSON Array
+		list.Articles = []model.DisplayedArticle{}
+	}
+
+	if author != nil {
+		displayedAuthor := model.NewDisplayedAuthor(author)
+
+		list.Author = &displayedAuthor
+	}
+
+	return list
+}
+
+func (handler *Handler) CreateArticle(c *gin.Context) {
 	var article model.Article
+	var tags []model.Tag
 	var user *model.User
 	var err error
 
@@ -196,16 +555,38 @@ func CreateArticle(c *gin.Context) {
 
 	c.BindJSON(&article)
 
-	if article.Slug == "" {
-		article.Slug = article.Title
-	}
-
 	fmt.Printf("Model 'Article': %#v\n", article)
 
 	if article.UserID == 0 {
 		article.UserID = editor.(*model.User).ID
 	}
 
+	if !editor.(*model.User).CanModifyArticle(&article) {
+		AbortForbidden(c, fmt.Sprintf("User (ID: '%d'): Articles can only be created for the own Account!", editor.(*model.User).ID))
+
+		return
+	}
+
+	if article.Status == "" {
+		article.Status = model.StatusDraft
+	}
+
+	if !checkArticleStatus(c, editor.(*model.User), &article, &model.Article{}) {
+		return
+	}
+
+	if err = article.NormalizeFormat(); err != nil {
+		dispatchUnprocessable(c, "articles", err.Error())
+
+		return
+	}
+
+	if err = article.CheckContentSize(); err != nil {
+		dispatchUnprocessable(c, "articles", err.Error())
+
+		return
+	}
+
 	if article.UserID == 0 {
 		c.JSON(http.StatusUnprocessableEntity,
 			APIErrorResponse{
@@ -218,7 +599,7 @@ func CreateArticle(c *gin.Context) {
 
 		return
 	} else {
-		if user, err = GetUserByID(article.UserID); err != nil {
+		if user, err = handler.Users.GetByID(article.UserID); err != nil {
 			c.JSON(http.StatusNotFound,
 				APIErrorResponse{
 					PROJECT + " - Error",
@@ -232,16 +613,48 @@ func CreateArticle(c *gin.Context) {
 		}
 	}
 
+	if article.Slug, ok = resolveSlug(c, "articles", article.Slug, article.Title, "article", 0, handler.slugCheck(model.RedirectArticle, handler.Articles.IsSlugTaken)); !ok {
+		return
+	}
+
+	if !handler.assignCategory(c, &article, article.CategorySlug) {
+		return
+	}
+
+	if tags, ok = handler.resolveTags(c, article.TagNames); !ok {
+		return
+	}
+
 	if user != nil {
-		DATABASE.Create(&article)
+		// The Article is stored together with its new Tags and its first Revision
+		err = handler.Transaction(func(storage repository.Storage) error {
+			scoped := handler.withStorage(storage)
+
+			if err := scoped.Articles.Create(&article); err != nil {
+				return err
+			}
+
+			if err := scoped.assignTags(&article, tags); err != nil {
+				return err
+			}
+
+			return scoped.recordRevision(&article, editor.(*model.User), 0)
+		})
 
-		c.JSON(http.StatusOK, article)
+		if err != nil {
+			AbortWithStorageError(c, "articles", err)
+
+			return
+		}
+
+		dispatchView(c, "articles", handler.newDisplayedArticle(&article))
 	}
 }
 
-func UpdateArticle(c *gin.Context) {
+func (handler *Handler) UpdateArticle(c *gin.Context) {
 	var article *model.Article
 	var updated model.Article
+	var tags []model.Tag
 	var user *model.User
 	var articleId uint64
 	var err error
@@ -278,7 +691,7 @@ func UpdateArticle(c *gin.Context) {
 
 	fmt.Printf("Controller 'Articles': Article ID 1: %#v\n", articleId)
 
-	if article, err = GetArticleByID(uint(articleId)); article == nil || err != nil {
+	if article, err = handler.Articles.GetByID(uint(articleId)); article == nil || err != nil {
 
 		fmt.Printf("Controller 'Articles': Article (ID '%d'): %#v; Error: %#v\n", articleId, article, err)
 
@@ -300,8 +713,20 @@ func UpdateArticle(c *gin.Context) {
 		return
 	}
 
+	if !editor.(*model.User).CanModifyArticle(article) {
+		AbortForbidden(c, fmt.Sprintf("Article (ID: '%d'): Article can only be modified by its Author or an Editor!", article.ID))
+
+		return
+	}
+
+	if updated.UserID != 0 && updated.UserID != article.UserID && !editor.(*model.User).Can(model.PermissionEditArticles) {
+		AbortForbidden(c, fmt.Sprintf("Article (ID: '%d'): Article can only be reassigned by an Editor!", article.ID))
+
+		return
+	}
+
 	if updated.UserID != 0 {
-		if user, err = GetUserByID(updated.UserID); user == nil || err != nil {
+		if user, err = handler.Users.GetByID(updated.UserID); user == nil || err != nil {
 			if err == nil {
 				err = errors.New("User ID: User does not exist!")
 			}
@@ -319,14 +744,70 @@ func UpdateArticle(c *gin.Context) {
 		}
 	}
 
+	if updated.Slug != "" && updated.Slug != article.Slug {
+		if updated.Slug, ok = resolveSlug(c, "articles", updated.Slug, "", "article", article.ID, handler.slugCheck(model.RedirectArticle, handler.Articles.IsSlugTaken)); !ok {
+			return
+		}
+	}
+
+	formerSlug := article.Slug
+	former := *article
+
 	article.Update(&updated)
 
-	DATABASE.Save(&article)
+	if !checkArticleStatus(c, editor.(*model.User), article, &former) {
+		return
+	}
+
+	if err = article.NormalizeFormat(); err != nil {
+		dispatchUnprocessable(c, "articles", err.Error())
+
+		return
+	}
+
+	if err = article.CheckContentSize(); err != nil {
+		dispatchUnprocessable(c, "articles", err.Error())
+
+		return
+	}
+
+	if !handler.assignCategory(c, article, updated.CategorySlug) {
+		return
+	}
+
+	if tags, ok = handler.resolveTags(c, updated.TagNames); !ok {
+		return
+	}
+
+	// The Article is stored together with its new Tags, its former Slug and its next Revision
+	err = handler.Transaction(func(storage repository.Storage) error {
+		scoped := handler.withStorage(storage)
+
+		if err := scoped.Articles.Save(article); err != nil {
+			return err
+		}
+
+		if err := scoped.assignTags(article, tags); err != nil {
+			return err
+		}
 
-	c.JSON(http.StatusOK, article)
+		if err := scoped.recordSlugChange(model.RedirectArticle, article.ID, formerSlug, article.Slug); err != nil {
+			return err
+		}
+
+		return scoped.recordRevision(article, editor.(*model.User), 0)
+	})
+
+	if err != nil {
+		AbortWithStorageError(c, "articles", err)
+
+		return
+	}
+
+	dispatchView(c, "articles", handler.newDisplayedArticle(article))
 }
 
-func DeleteArticle(c *gin.Context) {
+func (handler *Handler) DeleteArticle(c *gin.Context) {
 	var article *model.Article
 	var articleId uint64
 	var message string
@@ -360,14 +841,24 @@ func DeleteArticle(c *gin.Context) {
 
 	fmt.Printf("Controller 'Articles': Article ID 1: %#v\n", articleId)
 
-	if article, err = GetArticleByID(uint(articleId)); article == nil || err != nil {
+	if article, err = handler.Articles.GetByID(uint(articleId)); article == nil || err != nil {
 		fmt.Printf("Controller 'Articles': Article (ID '%d'): %#v; Error: %#v\n", articleId, article, err)
 
 		message = fmt.Sprintf("Article (ID: '%d'): User does not exist", articleId)
 	}
 
+	if article != nil && !editor.(*model.User).CanModifyArticle(article) {
+		AbortForbidden(c, fmt.Sprintf("Article (ID: '%d'): Article can only be deleted by its Author or an Editor!", article.ID))
+
+		return
+	}
+
 	if article != nil {
-		DATABASE.Delete(&article, article.ID)
+		if err = handler.Articles.Delete(article); err != nil {
+			AbortWithStorageError(c, "articles", err)
+
+			return
+		}
 
 		message = fmt.Sprintf("Article (ID: '%d'): Article was deleted", article.ID)
 	}
@@ -382,60 +873,3 @@ func DeleteArticle(c *gin.Context) {
 		},
 	)
 }
-
-func GetArticleByID(articleID uint) (*model.Article, error) {
-	var match *model.Article
-	var err error
-
-	articleRes := GetArticlesByIDs([]uint{articleID})
-
-	if articleRes == nil {
-		return nil, fmt.Errorf("Article (ID: '%d'): Article does not exist!", articleID)
-	}
-
-	fmt.Printf("Controller 'Articles': GetArticleByID(%d) (Count: '%d'): %#v\n", articleID, len(*articleRes), *articleRes)
-
-	if len(*articleRes) != 0 {
-		match = &(*articleRes)[0]
-	} else {
-		err = fmt.Errorf("Article (ID: '%d'): Article does not exist!", articleID)
-	}
-
-	return match, err
-}
-
-func GetArticleBySlug(articleSlug string) (*model.Article, error) {
-	var articles []model.Article
-	var match *model.Article
-	var err error
-
-	DATABASE.Find(&articles, "slug = ?", articleSlug)
-
-	fmt.Printf("Controller 'Articles': GetArticleBySlug('%s') (Count: '%d'):: %#v\n", articleSlug, len(articles), articles)
-
-	if len(articles) != 0 {
-		match = &articles[0]
-	} else {
-		err = fmt.Errorf("Article (Slug: '%s'): Article does not exist!", articleSlug)
-	}
-
-	return match, err
-}
-
-func GetArticlesByIDs(articleIDs []uint) *[]model.Article {
-	var articles []model.Article
-
-	DATABASE.Find(&articles, articleIDs)
-
-	return &articles
-}
-
-func GetArticlesByUserID(userID uint) []model.Article {
-	var articles []model.Article
-
-	DATABASE.Find(&articles, "user_id = ?", userID)
-
-	fmt.Printf("Controller 'Articles': GetArticlesByUserID(%d): %#v\n", userID, articles)
-
-	return articles
-}
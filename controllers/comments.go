@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/model"
+	"gin-blog/repository"
+)
+
+// DisplayComments - Lists the approved Comments of the Article as Tree of their Replies
+// The Tree contains every approved Comment, so the Count matches the Comment Count of the Article.
+func (handler *Handler) DisplayComments(c *gin.Context) {
+	article, ok := handler.findVisibleArticle(c, "comments")
+
+	if !ok {
+		return
+	}
+
+	comments, err := handler.Comments.GetByArticleID(article.ID, model.CommentApproved)
+
+	if err != nil {
+		AbortWithStorageError(c, "comments", err)
+
+		return
+	}
+
+	handler.dispatchCommentList(c, article, len(comments), model.NewCommentTree(comments))
+}
+
+// DisplayModerationQueue - Lists the Comments of the Article in one Moderation Stage
+// The Stage is given by the Parameter 'status' and defaults to the pending Comments
+func (handler *Handler) DisplayModerationQueue(c *gin.Context) {
+	var displayed []model.DisplayedComment
+
+	article, ok := handler.findModeratedArticle(c)
+
+	if !ok {
+		return
+	}
+
+	status := model.CommentStatus(c.DefaultQuery("status", string(model.CommentPending)))
+
+	if !model.IsValidCommentStatus(status) {
+		dispatchUnprocessable(c, "comments", fmt.Sprintf("Comment Status '%s': Status is invalid! Allowed Status: %v", status, model.COMMENTSTATUSES))
+
+		return
+	}
+
+	comments, err := handler.Comments.GetByArticleID(article.ID, status)
+
+	if err != nil {
+		AbortWithStorageError(c, "comments", err)
+
+		return
+	}
+
+	displayed = make([]model.DisplayedComment, 0, len(comments))
+
+	for idx := range comments {
+		displayed = append(displayed, model.NewDisplayedComment(&comments[idx]))
+	}
+
+	handler.dispatchCommentList(c, article, len(comments), displayed)
+}
+
+// CreateComment - Adds a Comment or a Reply to the Article
+// Comments of the Users who may modify the Article are approved at once while all others are pending.
+func (handler *Handler) CreateComment(c *gin.Context) {
+	var data model.CommentData
+
+	article, ok := handler.findVisibleArticle(c, "comments")
+
+	if !ok {
+		return
+	}
+
+	c.BindJSON(&data)
+
+	comment := data.NewComment(article.ID)
+	comment.Status = model.CommentPending
+
+	if authUser := GetAuthUser(c); authUser != nil {
+		userID := authUser.ID
+
+		comment.UserID = &userID
+		comment.AuthorName = authUser.Name
+		comment.AuthorEmail = authUser.Email
+
+		if authUser.CanModifyArticle(article) {
+			comment.Status = model.CommentApproved
+		}
+	}
+
+	if err := comment.Validate(); err != nil {
+		dispatchUnprocessable(c, "comments", err.Error())
+
+		return
+	}
+
+	if comment.ParentID != nil {
+		parent, err := handler.Comments.GetByID(*comment.ParentID)
+
+		if err != nil && !repository.IsNotFound(err) {
+			AbortWithStorageError(c, "comments", err)
+
+			return
+		}
+
+		// Replies are only accepted to the approved Comments of the same Article
+		if parent == nil || parent.ArticleID != article.ID || parent.Status != model.CommentApproved {
+			dispatchUnprocessable(c, "comments", fmt.Sprintf("Comment (ID: '%d'): Parent Comment does not exist!", *comment.ParentID))
+
+			return
+		}
+	}
+
+	if err := handler.Comments.Create(&comment); err != nil {
+		AbortWithStorageError(c, "comments", err)
+
+		return
+	}
+
+	dispatchView(c, "comments", model.NewDisplayedComment(&comment))
+}
+
+// ModerateComment - Approves a Comment or marks it as Spam
+func (handler *Handler) ModerateComment(c *gin.Context) {
+	var moderation model.CommentModeration
+	var comment *model.Comment
+	var commentId uint64
+	var err error
+
+	article, ok := handler.findModeratedArticle(c)
+
+	if !ok {
+		return
+	}
+
+	commentIdString := c.Params.ByName("comment")
+
+	if commentId, err = strconv.ParseUint(commentIdString, 10, 64); err != nil {
+		dispatchUnprocessable(c, "comments", "Comment ID: ID is invalid! Message: "+err.Error())
+
+		return
+	}
+
+	if comment, err = handler.Comments.GetByID(uint(commentId)); err == nil && comment.ArticleID != article.ID {
+		err = &repository.NotFoundError{Message: fmt.Sprintf("Comment (ID: '%d'): Comment does not exist!", commentId)}
+	}
+
+	if err != nil {
+		dispatchNotFound(c, "comments", err)
+
+		return
+	}
+
+	c.BindJSON(&moderation)
+
+	if !model.IsValidCommentStatus(moderation.Status) {
+		dispatchUnprocessable(c, "comments", fmt.Sprintf("Comment Status '%s': Status is invalid! Allowed Status: %v", moderation.Status, model.COMMENTSTATUSES))
+
+		return
+	}
+
+	comment.Status = moderation.Status
+
+	if err = handler.Comments.Save(comment); err != nil {
+		AbortWithStorageError(c, "comments", err)
+
+		return
+	}
+
+	dispatchView(c, "comments", model.NewDisplayedComment(comment))
+}
+
+// findModeratedArticle - Looks up the Article whose Comments the Authorized User moderates
+// The Comments are moderated by the Users who may modify the Article.
+// It dispatches the Error Response itself and reports whether the Article was found.
+func (handler *Handler) findModeratedArticle(c *gin.Context) (*model.Article, bool) {
+	moderator := GetAuthUser(c)
+
+	if moderator == nil {
+		// Exit on missing Authorized User
+		return nil, false
+	}
+
+	article, ok := handler.findVisibleArticle(c, "comments")
+
+	if !ok {
+		return nil, false
+	}
+
+	if !moderator.CanModifyArticle(article) {
+		AbortForbidden(c, fmt.Sprintf("Article (ID: '%d'): Comments can only be moderated by its Author or an Editor!", article.ID))
+
+		return nil, false
+	}
+
+	return article, true
+}
+
+func (handler *Handler) dispatchCommentList(c *gin.Context, article *model.Article, count int, comments []model.DisplayedComment) {
+	dispatchListView(c, "comments",
+		CommentListSuccess{
+			PROJECT + " - Comments",
+			http.StatusOK,
+			"comments",
+			"OK",
+			article.ID,
+			count,
+			comments,
+		}, "Comments")
+}
@@ -0,0 +1,275 @@
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/config"
+	"gin-blog/model"
+	"gin-blog/repository"
+)
+
+// FEEDCONTENTTYPES - Content Types of the Feed Formats by their File Extension
+var FEEDCONTENTTYPES map[string]string = map[string]string{
+	"rss":  "application/rss+xml; charset=utf-8",
+	"atom": "application/atom+xml; charset=utf-8",
+	"json": "application/feed+json; charset=utf-8",
+}
+
+// DEFAULTFEEDSORT - Sort Order of the Feeds when not requested otherwise
+var DEFAULTFEEDSORT string = "-published_at"
+
+func RegisterFeedRoutes(engine *gin.Engine, config *config.AppConfig, handler *Handler) {
+
+	if PROJECT == "" {
+		// Copy the Project Name
+		PROJECT = config.Project
+	}
+
+	// Copy the Handler Settings
+	handler.configure(config)
+
+	// Feed Routes
+	for extension := range FEEDCONTENTTYPES {
+		engine.GET(config.WebRoot+"feed."+extension, handler.DisplayFeed)
+		engine.GET(config.WebRoot+"authors/:slug/feed."+extension, handler.DisplayAuthorFeed)
+		engine.GET(config.WebRoot+"tags/:slug/feed."+extension, handler.DisplayTagFeed)
+	}
+}
+
+// DisplayFeed - Syndicates the published Articles with the Filters of the Article List
+func (handler *Handler) DisplayFeed(c *gin.Context) {
+	query, ok := parseFeedQuery(c)
+
+	if !ok {
+		return
+	}
+
+	feed := model.Feed{
+		Title:       PROJECT,
+		Description: PROJECTDESCRIPTION,
+		Link:        handler.absoluteURL(c, "articles"),
+	}
+
+	if matched, err := handler.applyAuthorFilter(c, &query); err != nil {
+		AbortWithStorageError(c, "feeds", err)
+
+		return
+	} else if !matched {
+		// An unknown Author has no Articles
+		handler.dispatchFeed(c, &feed, nil)
+
+		return
+	}
+
+	handler.dispatchFeed(c, &feed, &query)
+}
+
+// DisplayAuthorFeed - Syndicates the published Articles of the Author
+func (handler *Handler) DisplayAuthorFeed(c *gin.Context) {
+	authorSlug := c.Params.ByName("slug")
+
+	query, ok := parseFeedQuery(c)
+
+	if !ok {
+		return
+	}
+
+	user, redirected, err := handler.findUserBySlug(authorSlug)
+
+	if user == nil || err != nil {
+		handler.dispatchAuthorNotFound(c, fmt.Sprintf("Author (Slug: '%s'): Author does not exist", authorSlug), err)
+
+		return
+	}
+
+	if redirected {
+		dispatchSlugRedirect(c, user.Slug)
+
+		return
+	}
+
+	query.UserID = user.ID
+
+	feed := model.Feed{
+		Title:       PROJECT + " - " + user.Name,
+		Description: fmt.Sprintf("Articles by %s", user.Name),
+		Link:        handler.absoluteURL(c, "authors/"+url.PathEscape(user.Slug)+"/articles"),
+	}
+
+	handler.dispatchFeed(c, &feed, &query)
+}
+
+// DisplayTagFeed - Syndicates the published Articles with the Tag
+func (handler *Handler) DisplayTagFeed(c *gin.Context) {
+	query, ok := parseFeedQuery(c)
+
+	if !ok {
+		return
+	}
+
+	tag, ok := handler.findTag(c)
+
+	if !ok {
+		return
+	}
+
+	query.TagID = tag.ID
+
+	feed := model.Feed{
+		Title:       PROJECT + " - " + tag.Name,
+		Description: fmt.Sprintf("Articles tagged %s", tag.Name),
+		Link:        handler.absoluteURL(c, "articles?tag="+url.QueryEscape(tag.Slug)),
+	}
+
+	handler.dispatchFeed(c, &feed, &query)
+}
+
+// parseFeedQuery - Reads the Article List Parameters and restricts them to the published Articles
+// Feeds are sorted by their Publish Time when not requested otherwise.
+// It dispatches the Error Response itself and reports whether the Parameters are valid.
+func parseFeedQuery(c *gin.Context) (repository.ArticleQuery, bool) {
+	query, err := ParseArticleQuery(c)
+
+	if err != nil {
+		dispatchUnprocessable(c, "feeds", err.Error())
+
+		return query, false
+	}
+
+	if c.Query("sort") == "" {
+		query.Sort = DEFAULTFEEDSORT
+	}
+
+	// Feeds are public and the same for all Readers
+	query.PublicOnly = true
+	query.OwnerID = 0
+
+	return query, true
+}
+
+// dispatchFeed - Renders the Articles of the Query in the Feed Format of the Route
+// A missing Query renders an empty Feed.
+// Clients which send the current ETag or Modification Time receive 'Not Modified'.
+func (handler *Handler) dispatchFeed(c *gin.Context, feed *model.Feed, query *repository.ArticleQuery) {
+	var articles []model.Article
+	var displayedArticles []model.DisplayedArticle
+	var body []byte
+	var ok bool
+	var err error
+
+	extension := strings.TrimPrefix(path.Ext(c.FullPath()), ".")
+
+	if query != nil {
+		if articles, _, ok = handler.findArticles(c, query); !ok {
+			return
+		}
+	}
+
+	if displayedArticles, err = handler.newDisplayedArticles(articles); err != nil {
+		AbortWithStorageError(c, "feeds", err)
+
+		return
+	}
+
+	feed.FeedLink = handler.absoluteURL(c, strings.TrimPrefix(c.Request.URL.RequestURI(), handler.WebRoot))
+	feed.Items = []model.FeedItem{}
+
+	for idx := range articles {
+		link := handler.absoluteURL(c, "articles/by-slug/"+url.PathEscape(articles[idx].Slug))
+
+		feed.Items = append(feed.Items, model.NewFeedItem(&articles[idx], &displayedArticles[idx], link))
+
+		if articles[idx].UpdatedAt.After(feed.Updated) {
+			feed.Updated = articles[idx].UpdatedAt
+		}
+	}
+
+	switch extension {
+	case "rss":
+		body, err = feed.RSS()
+	case "atom":
+		body, err = feed.Atom()
+	default:
+		body, err = feed.JSONFeed()
+	}
+
+	if err != nil {
+		fmt.Printf("Controller 'Feeds': Feed '%s': Rendering failed! Error: %#v\n", feed.FeedLink, err)
+
+		c.JSON(http.StatusInternalServerError,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusInternalServerError,
+				"feeds",
+				"Internal Server Error",
+				"Feed: Feed could not be rendered!",
+			})
+
+		return
+	}
+
+	checksum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(checksum[:16]) + `"`
+
+	c.Header("ETag", etag)
+
+	if !feed.Updated.IsZero() {
+		c.Header("Last-Modified", feed.Updated.UTC().Format(http.TimeFormat))
+	}
+
+	if isFeedUnchanged(c, etag, feed.Updated) {
+		c.Status(http.StatusNotModified)
+
+		return
+	}
+
+	c.Data(http.StatusOK, FEEDCONTENTTYPES[extension], body)
+}
+
+// isFeedUnchanged - Checks the Conditional Request Headers against the current Feed
+// The Entity Tag takes Precedence over the Modification Time.
+func isFeedUnchanged(c *gin.Context, etag string, updated time.Time) bool {
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if since := c.GetHeader("If-Modified-Since"); since != "" && !updated.IsZero() {
+		if sinceTime, err := http.ParseTime(since); err == nil {
+			return !updated.Truncate(time.Second).After(sinceTime)
+		}
+	}
+
+	return false
+}
+
+// absoluteURL - Resolves the Path below the Web Root to the Host and Scheme of the Request
+func (handler *Handler) absoluteURL(c *gin.Context, relative string) string {
+	scheme := "http"
+
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+
+	if forwarded := c.GetHeader("X-Forwarded-Proto"); forwarded != "" {
+		scheme = strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+
+	return scheme + "://" + c.Request.Host + handler.WebRoot + strings.TrimPrefix(relative, "/")
+}
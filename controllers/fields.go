@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/model"
+)
+
+// ParseFields - Reads the comma separated Field Selection of the 'fields' Query Parameter
+func ParseFields(c *gin.Context) []string {
+	var fields []string
+
+	for _, field := range strings.Split(c.Query("fields"), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// SelectFields - Reduces a View or a List of Views to the selected Fields
+// Without a Field Selection the View is returned unchanged
+func SelectFields(view interface{}, fields []string) (interface{}, error) {
+	var generic interface{}
+
+	if len(fields) == 0 {
+		return view, nil
+	}
+
+	viewJSON, err := json.Marshal(view)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(viewJSON, &generic); err != nil {
+		return nil, err
+	}
+
+	return selectGenericFields(generic, fields)
+}
+
+// SelectListFields - Reduces the Views in the List Property of an Envelope to the selected Fields
+func SelectListFields(envelope interface{}, property string, fields []string) (interface{}, error) {
+	var generic map[string]interface{}
+
+	if len(fields) == 0 {
+		return envelope, nil
+	}
+
+	envelopeJSON, err := json.Marshal(envelope)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(envelopeJSON, &generic); err != nil {
+		return nil, err
+	}
+
+	if generic[property], err = selectGenericFields(generic[property], fields); err != nil {
+		return nil, err
+	}
+
+	return generic, nil
+}
+
+func selectGenericFields(generic interface{}, fields []string) (interface{}, error) {
+	var err error
+
+	switch value := generic.(type) {
+	case []interface{}:
+		for idx := range value {
+			if value[idx], err = selectGenericFields(value[idx], fields); err != nil {
+				return nil, err
+			}
+		}
+
+		return value, nil
+	case map[string]interface{}:
+		selected := make(map[string]interface{}, len(fields))
+
+		for _, field := range fields {
+			fieldValue, ok := value[field]
+
+			if !ok {
+				return nil, fmt.Errorf("Fields: Field '%s' is unknown!", field)
+			}
+
+			selected[field] = fieldValue
+		}
+
+		return selected, nil
+	}
+
+	return generic, nil
+}
+
+// NewUserView - Selects the User Profile which the Role of the Authorized User may see
+func NewUserView(authUser *model.User, user *model.User) interface{} {
+	if authUser != nil && (authUser.ID == user.ID || authUser.Can(model.PermissionManageUsers)) {
+		return model.NewDisplayedAccount(user)
+	}
+
+	return model.NewDisplayedUser(user)
+}
+
+// dispatchView - Dispatches the View reduced to the Fields requested by the 'fields' Query Parameter
+func dispatchView(c *gin.Context, page string, view interface{}) {
+	selected, err := SelectFields(view, ParseFields(c))
+
+	dispatchSelected(c, page, selected, err)
+}
+
+// dispatchListView - Dispatches the Envelope with the Views in the List Property reduced to the requested Fields
+func dispatchListView(c *gin.Context, page string, envelope interface{}, property string) {
+	selected, err := SelectListFields(envelope, property, ParseFields(c))
+
+	dispatchSelected(c, page, selected, err)
+}
+
+func dispatchSelected(c *gin.Context, page string, selected interface{}, err error) {
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusUnprocessableEntity,
+				page,
+				"Unprocessable Content",
+				err.Error(),
+			})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, selected)
+}
@@ -14,17 +14,22 @@ import (
 	"gin-blog/model"
 )
 
-func RegisterLoginRoutes(engine *gin.Engine, config *config.AppConfig) {
+func RegisterLoginRoutes(engine *gin.Engine, config *config.AppConfig, handler *Handler) {
 
 	if PROJECT == "" {
 		// Copy the Project Name
 		PROJECT = config.Project
 	}
 
-	engine.POST(config.WebRoot+"login", DispatchLogin)
+	// Copy the Handler Settings
+	handler.configure(config)
+
+	engine.POST(config.WebRoot+"login", handler.DispatchLogin)
+	engine.POST(config.WebRoot+"login/refresh", handler.DispatchRefresh)
+	engine.POST(config.WebRoot+"logout", handler.AuthorizeRequest(), handler.DispatchLogout)
 }
 
-func DispatchLogin(c *gin.Context) {
+func (handler *Handler) DispatchLogin(c *gin.Context) {
 	var userLogin model.Login
 	var user *model.User
 	var err error
@@ -59,7 +64,7 @@ func DispatchLogin(c *gin.Context) {
 		return
 	}
 
-	if user, err = GetUserByLogin(userLogin.Login); user == nil || err != nil {
+	if user, err = handler.Users.GetByLogin(userLogin.Login); user == nil || err != nil {
 		c.JSON(http.StatusUnauthorized,
 			APIErrorResponse{
 				PROJECT + " - Error",
@@ -74,35 +79,20 @@ func DispatchLogin(c *gin.Context) {
 
 	//fmt.Printf("Controller 'Login': User: %#v; Login: %#v; \n", user, userLogin)
 
-	if user.AuthLogin(&userLogin, model.ENCRYPTIONSALT) {
-		// Session Validity
-		sessionStart := time.Now()
-		sessionMinutes, _ := time.ParseDuration(fmt.Sprintf("%dm", SESSIONEXPIRY))
-		sessionExpiry := time.Now().Add(sessionMinutes)
-
-		fmt.Printf("Controller 'Login': Session: iat: '%#v'; exp: '%#v'\n", time.Now().Unix(), time.Now().Add(sessionMinutes).Unix())
-
-		// Create a new JWT
-		token := jwt.NewWithClaims(jwt.SigningMethodHS512,
-			jwt.MapClaims{
-				"iss": PROJECT,
-				"sub": AuthorizationSubject{user.ID, user.Login},
-				"iat": sessionStart.Unix(),
-				"exp": sessionExpiry.Unix(),
-			})
-		tokenString, err := token.SignedString(model.ENCRYPTIONKEY)
+	storedHash := user.Password
 
-		if err == nil {
-			c.JSON(http.StatusOK,
-				LoginSuccess{
-					PROJECT + " - Success",
-					http.StatusOK,
-					"login",
-					"OK",
-					tokenString,
-					sessionExpiry.Format(time.RFC3339),
-				})
+	if user.AuthLogin(&userLogin, handler.Auth.PasswordPepper) {
+		if user.Password != storedHash {
+			// Persist the renewed Password Hash
+			if err = handler.Users.Save(user); err != nil {
+				fmt.Printf("Controller 'Login': User (ID '%d'): Password Rehash failed! Error: %#v\n", user.ID, err)
+			}
 		}
+
+		// Open a new Login Session
+		session := model.Session{UserID: user.ID}
+
+		handler.dispatchSessionTokens(c, user, &session)
 	} else {
 		c.JSON(http.StatusUnauthorized,
 			APIErrorResponse{
@@ -115,17 +105,160 @@ func DispatchLogin(c *gin.Context) {
 	}
 }
 
-func AuthorizeRequest() gin.HandlerFunc {
+func (handler *Handler) DispatchRefresh(c *gin.Context) {
+	var refresh RefreshRequest
+	var session *model.Session
+	var user *model.User
+	var err error
+
+	if strings.Contains(c.Request.Header.Get("content-type"), "application/json") {
+		// Parse into the Refresh Structure
+		c.BindJSON(&refresh)
+	} else {
+		// Populate Refresh from Parameters
+		refresh.RefreshToken = c.PostForm("refresh_token")
+	}
+
+	if refresh.RefreshToken == "" {
+		c.JSON(http.StatusUnprocessableEntity,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusUnprocessableEntity,
+				"login",
+				"Unprocessable Content",
+				"Refresh Token: Token is missing!",
+			})
+
+		return
+	}
+
+	if session, err = handler.Sessions.GetByRefreshHash(model.HashRefreshToken(refresh.RefreshToken)); err == nil && !session.IsActive(time.Now()) {
+		err = errors.New("Refresh Token: Session is expired or revoked!")
+	}
+
+	if err == nil {
+		if user, err = handler.Users.GetByID(session.UserID); err != nil {
+			err = errors.New("Refresh Token: User unauthorized!")
+		}
+	}
+
+	if err != nil {
+		fmt.Printf("Controller 'Login': Refresh failed! Error: %#v\n", err)
+
+		c.JSON(http.StatusUnauthorized,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusUnauthorized,
+				"login",
+				"Unauthorized",
+				"Refresh Token: Token is invalid!",
+			})
+
+		return
+	}
+
+	// Rotate the Refresh Token of the Session
+	handler.dispatchSessionTokens(c, user, session)
+}
+
+func (handler *Handler) DispatchLogout(c *gin.Context) {
+	authSession, ok := c.Get("AuthSession")
+
+	if authSession == nil || !ok {
+		// Exit on missing Authorized Session
+		return
+	}
+
+	session := authSession.(*model.Session)
+
+	session.Revoke(time.Now())
+
+	if err := handler.Sessions.Save(session); err != nil {
+		AbortWithStorageError(c, "login", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		LogoutSuccess{
+			PROJECT + " - Logout Success",
+			http.StatusOK,
+			"logout",
+			"OK",
+			fmt.Sprintf("Session (ID: '%d'): Session was revoked", session.ID),
+		})
+}
+
+// dispatchSessionTokens - Issues a new Access Token and a new Refresh Token for the Session
+// A new Session is stored while an existing Session is extended and its former Refresh Token becomes invalid
+func (handler *Handler) dispatchSessionTokens(c *gin.Context, user *model.User, session *model.Session) {
+	var refreshToken string
+	var err error
+
+	// Session Validity
+	sessionStart := time.Now()
+	sessionExpiry := sessionStart.Add(time.Duration(handler.Auth.SessionExpiry) * time.Minute)
+
+	session.ExpiresAt = sessionStart.Add(time.Duration(handler.Auth.RefreshExpiry) * time.Minute)
+
+	if refreshToken, session.RefreshHash, err = model.NewRefreshToken(); err == nil {
+		if session.ID == 0 {
+			err = handler.Sessions.Create(session)
+		} else {
+			err = handler.Sessions.Save(session)
+		}
+	}
+
+	if err != nil {
+		AbortWithStorageError(c, "login", err)
+
+		return
+	}
+
+	fmt.Printf("Controller 'Login': Session (ID '%d'): iat: '%#v'; exp: '%#v'\n", session.ID, sessionStart.Unix(), sessionExpiry.Unix())
+
+	// Create a new JWT
+	token := jwt.NewWithClaims(jwt.SigningMethodHS512,
+		jwt.MapClaims{
+			"iss": handler.Auth.Issuer,
+			"sub": AuthorizationSubject{user.ID, user.Login},
+			"sid": session.ID,
+			"iat": sessionStart.Unix(),
+			"exp": sessionExpiry.Unix(),
+		})
+	tokenString, err := token.SignedString([]byte(handler.Auth.SigningKey))
+
+	if err != nil {
+		AbortWithStorageError(c, "login", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		LoginSuccess{
+			PROJECT + " - Success",
+			http.StatusOK,
+			"login",
+			"OK",
+			tokenString,
+			sessionExpiry.Format(time.RFC3339),
+			refreshToken,
+			session.ExpiresAt.Format(time.RFC3339),
+		})
+}
+
+func (handler *Handler) AuthorizeRequest() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var authUser *model.User
+		var authSession *model.Session
 		var err error
 
-		if authUser, err = ValidateAuthorizationHeader(c); authUser == nil || err != nil {
+		if authUser, authSession, err = handler.ValidateAuthorizationHeader(c); authUser == nil || err != nil {
 			if err == nil {
 				err = errors.New("Authorization Token: User unauthorized!")
 			}
 
-			c.JSON(http.StatusUnauthorized,
+			c.AbortWithStatusJSON(http.StatusUnauthorized,
 				APIErrorResponse{
 					PROJECT + " - Error",
 					http.StatusUnauthorized,
@@ -138,18 +271,35 @@ func AuthorizeRequest() gin.HandlerFunc {
 		}
 
 		c.Set("AuthUser", authUser)
+		c.Set("AuthSession", authSession)
 
 		c.Next()
 	}
 }
 
-func ValidateAuthorizationHeader(c *gin.Context) (*model.User, error) {
+// IdentifyRequest - Authorizes Requests which carry an Authorization Header
+// Requests without Authorization Header continue anonymously
+func (handler *Handler) IdentifyRequest() gin.HandlerFunc {
+	authorize := handler.AuthorizeRequest()
+
+	return func(c *gin.Context) {
+		if len(c.Request.Header["Authorization"]) == 0 {
+			c.Next()
+
+			return
+		}
+
+		authorize(c)
+	}
+}
+
+func (handler *Handler) ValidateAuthorizationHeader(c *gin.Context) (*model.User, *model.Session, error) {
 	var tokenString string = ""
 
 	authorizationHeader := c.Request.Header["Authorization"]
 
 	if len(authorizationHeader) == 0 {
-		return nil, errors.New("Authorization Token: Token is invalid! Message: No Token!")
+		return nil, nil, errors.New("Authorization Token: Token is invalid! Message: No Token!")
 	}
 
 	bearerString := authorizationHeader[len(authorizationHeader)-1]
@@ -163,19 +313,28 @@ func ValidateAuthorizationHeader(c *gin.Context) (*model.User, error) {
 	}
 
 	if tokenString == "" {
-		return nil, errors.New("Authorization Token: Token is invalid! Message: No Token!")
+		return nil, nil, errors.New("Authorization Token: Token is invalid! Message: No Token!")
 	}
 
-	return ValidateToken(tokenString)
+	return handler.ValidateSessionToken(tokenString)
+}
+
+func (handler *Handler) ValidateToken(tokenString string) (*model.User, error) {
+	user, _, err := handler.ValidateSessionToken(tokenString)
+
+	return user, err
 }
 
-func ValidateToken(tokenString string) (*model.User, error) {
+// ValidateSessionToken - Checks the Access Token and whether its Login Session is still active
+func (handler *Handler) ValidateSessionToken(tokenString string) (*model.User, *model.Session, error) {
 	var user *model.User
+	var session *model.Session
 	var err error
 
-	token, err := jwt.Parse(tokenString, GetEncryptionKey)
+	token, err := jwt.Parse(tokenString, handler.GetEncryptionKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS512.Alg()}), jwt.WithIssuer(handler.Auth.Issuer))
 	if err != nil {
-		return nil, fmt.Errorf("Authorization Token: Token is invalid! Message: %v", err)
+		return nil, nil, fmt.Errorf("Authorization Token: Token is invalid! Message: %v", err)
 	}
 
 	fmt.Printf("Controller 'Login': Parsed Token: %#v\n", token)
@@ -190,34 +349,50 @@ func ValidateToken(tokenString string) (*model.User, error) {
 
 			fmt.Printf("Controller 'Login': auth sub: %#v\n", authSubject)
 
-			if user, err = GetUserByID(authSubject.ID); user == nil || err != nil {
+			if user, err = handler.Users.GetByID(authSubject.ID); user == nil || err != nil {
 				if err == nil {
 					err = errors.New("Authorization Token: User unauthorized!")
 				}
 
-				return nil, err
+				return nil, nil, err
 			}
 
 			// User Data Integrity Check
 			if user.Login != authSubject.Login {
-				return nil, errors.New("Authorization Token: User unauthorized!")
+				return nil, nil, errors.New("Authorization Token: User unauthorized!")
+			}
+		} else {
+			return nil, nil, errors.New("Authorization Token: Payload invalid!")
+		}
+
+		if sessionID, ok := tokenData["sid"].(float64); ok && sessionID > 0 {
+			if session, err = handler.Sessions.GetByID(uint(sessionID)); session == nil || err != nil {
+				return nil, nil, errors.New("Authorization Token: Session is invalid!")
+			}
+
+			// Session Integrity and Revocation Check
+			if session.UserID != user.ID || !session.IsActive(time.Now()) {
+				return nil, nil, errors.New("Authorization Token: Session is expired or revoked!")
 			}
 		} else {
-			return nil, errors.New("Authorization Token: Payload invalid!")
+			return nil, nil, errors.New("Authorization Token: Payload invalid!")
 		}
 	} else {
-		return nil, errors.New("Authorization Token: Payload invalid!")
+		return nil, nil, errors.New("Authorization Token: Payload invalid!")
 	}
 
-	return user, nil
+	return user, session, nil
 }
 
-func GetEncryptionKey(token *jwt.Token) (interface{}, error) {
+func (handler *Handler) GetEncryptionKey(token *jwt.Token) (interface{}, error) {
 	// Don't forget to validate the alg is what you expect:
 	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 		return nil, fmt.Errorf("Authorization Token: Token is invalid! Message: Unexpected signing method: %v", token.Header["alg"])
 	}
 
-	// hmacSampleSecret is a []byte containing your secret, e.g. []byte("my_secret_key")
-	return model.ENCRYPTIONKEY, nil
+	if handler.Auth.SigningKey == "" {
+		return nil, errors.New("Authorization Token: Token cannot be validated! Message: Signing Key is missing")
+	}
+
+	return []byte(handler.Auth.SigningKey), nil
 }
@@ -0,0 +1,480 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/config"
+	"gin-blog/model"
+	"gin-blog/repository"
+)
+
+// MULTIPARTOVERHEAD - Bytes which the Multipart Form may take besides the uploaded File
+var MULTIPARTOVERHEAD int64 = 64 * 1024
+
+// MEDIACACHEAGE - Seconds which Clients may cache the Media Files
+var MEDIACACHEAGE int = 24 * 60 * 60
+
+func RegisterMediaRoutes(engine *gin.Engine, config *config.AppConfig, handler *Handler) {
+
+	if PROJECT == "" {
+		// Copy the Project Name
+		PROJECT = config.Project
+	}
+
+	// Copy the Handler Settings
+	handler.configure(config)
+
+	// Media Routes
+	engine.GET(config.WebRoot+"media", handler.AuthorizeRequest(), handler.DisplayUserMedia)
+	engine.POST(config.WebRoot+"media", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionWriteArticles), handler.UploadMedia)
+	engine.GET(config.WebRoot+"media/:id", handler.IdentifyRequest(), handler.DisplayMedia)
+	engine.GET(config.WebRoot+"media/:id/file", handler.IdentifyRequest(), handler.DisplayMediaFile)
+	engine.GET(config.WebRoot+"media/:id/thumbnail", handler.IdentifyRequest(), handler.DisplayMediaThumbnail)
+	engine.DELETE(config.WebRoot+"media/:id", handler.AuthorizeRequest(), handler.DeleteMedia)
+
+	// Article Media Route
+	engine.GET(config.WebRoot+"articles/:id/media", handler.IdentifyRequest(), handler.DisplayArticleMedia)
+}
+
+// UploadMedia - Stores the File of the Multipart Field 'file' with its Thumbnail
+// The optional Field 'article_id' attaches the Media to an Article which the User may modify.
+func (handler *Handler) UploadMedia(c *gin.Context) {
+	var article *model.Article
+
+	authUser := GetAuthUser(c)
+
+	if authUser == nil {
+		// Exit on missing Authorized User
+		return
+	}
+
+	if c.Request.ContentLength > handler.MaxMediaSize+MULTIPARTOVERHEAD {
+		handler.dispatchTooLarge(c)
+
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, handler.MaxMediaSize+MULTIPARTOVERHEAD)
+
+	fileHeader, err := c.FormFile("file")
+
+	if err != nil {
+		if strings.Contains(err.Error(), "request body too large") {
+			handler.dispatchTooLarge(c)
+		} else {
+			dispatchUnprocessable(c, "media", "Media File: File is missing! Message: "+err.Error())
+		}
+
+		return
+	}
+
+	if fileHeader.Size > handler.MaxMediaSize {
+		handler.dispatchTooLarge(c)
+
+		return
+	}
+
+	if articleIdString := c.PostForm("article_id"); articleIdString != "" {
+		articleId, err := strconv.ParseUint(articleIdString, 10, 64)
+
+		if err != nil {
+			dispatchUnprocessable(c, "media", "Article ID: ID is invalid! Message: "+err.Error())
+
+			return
+		}
+
+		if article, err = handler.Articles.GetByID(uint(articleId)); err != nil || article == nil || !authUser.CanViewArticle(article) {
+			if err != nil && !repository.IsNotFound(err) {
+				AbortWithStorageError(c, "media", err)
+
+				return
+			}
+
+			dispatchUnprocessable(c, "media", fmt.Sprintf("Article (ID: '%d'): Article does not exist!", articleId))
+
+			return
+		}
+
+		if !authUser.CanModifyArticle(article) {
+			AbortForbidden(c, fmt.Sprintf("Article (ID: '%d'): Media can only be attached by its Author or an Editor!", article.ID))
+
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+
+	if err != nil {
+		dispatchUnprocessable(c, "media", "Media File: File cannot be read! Message: "+err.Error())
+
+		return
+	}
+
+	data, err := ioutil.ReadAll(file)
+
+	file.Close()
+
+	if err != nil {
+		dispatchUnprocessable(c, "media", "Media File: File cannot be read! Message: "+err.Error())
+
+		return
+	}
+
+	media := model.Media{
+		UserID:   authUser.ID,
+		FileName: model.CleanFileName(fileHeader.Filename),
+		Size:     int64(len(data)),
+	}
+
+	if article != nil {
+		media.ArticleID = &article.ID
+	}
+
+	if media.ContentType, err = model.DetectMediaType(data); err != nil {
+		dispatchUnprocessable(c, "media", err.Error())
+
+		return
+	}
+
+	var thumbnail *model.Thumbnail
+
+	if media.IsImage() {
+		if media.Width, media.Height, thumbnail, err = model.NewThumbnail(data, media.ContentType); err != nil {
+			dispatchUnprocessable(c, "media", err.Error())
+
+			return
+		}
+	}
+
+	if media.StorageKey, err = newStorageKey(model.MEDIATYPES[media.ContentType]); err != nil {
+		AbortWithStorageError(c, "media", err)
+
+		return
+	}
+
+	if thumbnail != nil {
+		media.ThumbnailKey = strings.TrimSuffix(media.StorageKey, model.MEDIATYPES[media.ContentType]) +
+			"-thumbnail" + model.MEDIATYPES[thumbnail.ContentType]
+	}
+
+	if err = handler.Files.Put(media.StorageKey, data, media.ContentType); err != nil {
+		fmt.Printf("Controller 'Media': File '%s': Upload failed! Error: %#v\n", media.StorageKey, err)
+
+		AbortWithStorageError(c, "media", err)
+
+		return
+	}
+
+	if thumbnail != nil {
+		err = handler.Files.Put(media.ThumbnailKey, thumbnail.Data, thumbnail.ContentType)
+	}
+
+	if err == nil {
+		err = handler.Media.Create(&media)
+	}
+
+	if err != nil {
+		fmt.Printf("Controller 'Media': Media '%s': Upload failed! Error: %#v\n", media.StorageKey, err)
+
+		// Do not keep Files without Media Record
+		handler.deleteMediaFiles(&media)
+
+		AbortWithStorageError(c, "media", err)
+
+		return
+	}
+
+	dispatchView(c, "media", model.NewDisplayedMedia(&media, handler.WebRoot))
+}
+
+// DisplayMedia - Shows the Details of the Media
+func (handler *Handler) DisplayMedia(c *gin.Context) {
+	media, ok := handler.findVisibleMedia(c)
+
+	if !ok {
+		return
+	}
+
+	dispatchView(c, "media", model.NewDisplayedMedia(media, handler.WebRoot))
+}
+
+// DisplayUserMedia - Lists the Media uploaded by the Authorized User
+func (handler *Handler) DisplayUserMedia(c *gin.Context) {
+	authUser := GetAuthUser(c)
+
+	if authUser == nil {
+		// Exit on missing Authorized User
+		return
+	}
+
+	media, err := handler.Media.GetByUserID(authUser.ID)
+
+	if err != nil {
+		AbortWithStorageError(c, "media", err)
+
+		return
+	}
+
+	handler.dispatchMediaList(c, 0, media)
+}
+
+// DisplayArticleMedia - Lists the Media attached to the Article
+func (handler *Handler) DisplayArticleMedia(c *gin.Context) {
+	article, ok := handler.findVisibleArticle(c, "media")
+
+	if !ok {
+		return
+	}
+
+	media, err := handler.Media.GetByArticleID(article.ID)
+
+	if err != nil {
+		AbortWithStorageError(c, "media", err)
+
+		return
+	}
+
+	handler.dispatchMediaList(c, article.ID, media)
+}
+
+// DisplayMediaFile - Sends the uploaded File
+// Files which are not Images are sent as Download.
+func (handler *Handler) DisplayMediaFile(c *gin.Context) {
+	media, ok := handler.findVisibleMedia(c)
+
+	if !ok {
+		return
+	}
+
+	disposition := "inline"
+
+	if !media.IsImage() {
+		disposition = "attachment"
+	}
+
+	handler.dispatchMediaFile(c, media.StorageKey, media.ContentType, media.Size,
+		mime.FormatMediaType(disposition, map[string]string{"filename": media.FileName}))
+}
+
+// DisplayMediaThumbnail - Sends the Thumbnail of an uploaded Image
+func (handler *Handler) DisplayMediaThumbnail(c *gin.Context) {
+	media, ok := handler.findVisibleMedia(c)
+
+	if !ok {
+		return
+	}
+
+	if media.ThumbnailKey == "" {
+		c.JSON(http.StatusNotFound,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusNotFound,
+				"media",
+				"Not Found",
+				fmt.Sprintf("Media (ID: '%d'): Media has no Thumbnail", media.ID),
+			})
+
+		return
+	}
+
+	contentType := mime.TypeByExtension(media.ThumbnailKey[strings.LastIndex(media.ThumbnailKey, "."):])
+
+	handler.dispatchMediaFile(c, media.ThumbnailKey, contentType, -1, "inline")
+}
+
+// DeleteMedia - Removes the Media with its Files
+// Users can delete their own Uploads while Editors can delete any Upload.
+func (handler *Handler) DeleteMedia(c *gin.Context) {
+	authUser := GetAuthUser(c)
+
+	if authUser == nil {
+		// Exit on missing Authorized User
+		return
+	}
+
+	media, ok := handler.findMedia(c)
+
+	if !ok {
+		return
+	}
+
+	if !authUser.CanDeleteMedia(media) {
+		AbortForbidden(c, fmt.Sprintf("Media (ID: '%d'): Media can only be deleted by its Uploader or an Editor!", media.ID))
+
+		return
+	}
+
+	if err := handler.Media.Delete(media); err != nil {
+		AbortWithStorageError(c, "media", err)
+
+		return
+	}
+
+	handler.deleteMediaFiles(media)
+
+	c.JSON(http.StatusOK,
+		APIDeleteSuccess{
+			PROJECT + " - Delete Success",
+			http.StatusOK,
+			"media",
+			"OK",
+			fmt.Sprintf("Media (ID: '%d'): Media was deleted", media.ID),
+		},
+	)
+}
+
+// findMedia - Looks up the Media of the ID Parameter
+// It dispatches the Error Response itself and reports whether the Media was found.
+func (handler *Handler) findMedia(c *gin.Context) (*model.Media, bool) {
+	mediaId, err := strconv.ParseUint(c.Params.ByName("id"), 10, 64)
+
+	if err != nil {
+		dispatchUnprocessable(c, "media", "Media ID: ID is invalid! Message: "+err.Error())
+
+		return nil, false
+	}
+
+	media, err := handler.Media.GetByID(uint(mediaId))
+
+	if err != nil {
+		dispatchNotFound(c, "media", err)
+
+		return nil, false
+	}
+
+	return media, true
+}
+
+// findVisibleMedia - Looks up the Media of the ID Parameter if it is visible to the Requesting User
+// Media attached to an Article are only visible together with the Article.
+// Media of an Article in the Trash are only visible to the Users who may restore it.
+// It dispatches the Error Response itself and reports whether the Media was found.
+func (handler *Handler) findVisibleMedia(c *gin.Context) (*model.Media, bool) {
+	media, ok := handler.findMedia(c)
+
+	if !ok || media.ArticleID == nil {
+		return media, ok
+	}
+
+	authUser := GetAuthUser(c)
+	visible := false
+
+	article, err := handler.Articles.GetByID(*media.ArticleID)
+
+	if err == nil {
+		visible = authUser.CanViewArticle(article)
+	} else if repository.IsNotFound(err) {
+		if article, err = handler.Articles.GetDeletedByID(*media.ArticleID); err == nil {
+			visible = authUser != nil && authUser.CanModifyArticle(article)
+		}
+	}
+
+	if err != nil && !repository.IsNotFound(err) {
+		AbortWithStorageError(c, "media", err)
+
+		return nil, false
+	}
+
+	if !visible {
+		c.JSON(http.StatusNotFound,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusNotFound,
+				"media",
+				"Not Found",
+				fmt.Sprintf("Media (ID: '%d'): Media does not exist!", media.ID),
+			})
+
+		return nil, false
+	}
+
+	return media, true
+}
+
+// dispatchMediaFile - Streams the File from the File Storage
+// The File is never sniffed by the Client as another Content Type.
+func (handler *Handler) dispatchMediaFile(c *gin.Context, key string, contentType string, size int64, disposition string) {
+	file, err := handler.Files.Open(key)
+
+	if err != nil {
+		fmt.Printf("Controller 'Media': File '%s': Download failed! Error: %#v\n", key, err)
+
+		dispatchNotFound(c, "media", err)
+
+		return
+	}
+
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, size, contentType, file, map[string]string{
+		"Content-Disposition":    disposition,
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          fmt.Sprintf("max-age=%d", MEDIACACHEAGE),
+	})
+}
+
+func (handler *Handler) dispatchMediaList(c *gin.Context, articleID uint, media []model.Media) {
+	displayed := make([]model.DisplayedMedia, 0, len(media))
+
+	for idx := range media {
+		displayed = append(displayed, model.NewDisplayedMedia(&media[idx], handler.WebRoot))
+	}
+
+	dispatchListView(c, "media",
+		MediaListSuccess{
+			PROJECT + " - Media",
+			http.StatusOK,
+			"media",
+			"OK",
+			articleID,
+			displayed,
+		}, "Media")
+}
+
+func (handler *Handler) dispatchTooLarge(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge,
+		APIErrorResponse{
+			PROJECT + " - Error",
+			http.StatusRequestEntityTooLarge,
+			"media",
+			"Request Entity Too Large",
+			fmt.Sprintf("Media File: File exceeds the Limit of %d KB!", handler.MaxMediaSize/1024),
+		})
+}
+
+// deleteMediaFiles - Removes the File and the Thumbnail of the Media from the File Storage
+// Failures only leave unreferenced Files behind and are logged.
+func (handler *Handler) deleteMediaFiles(media *model.Media) {
+	for _, key := range []string{media.StorageKey, media.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+
+		if err := handler.Files.Delete(key); err != nil {
+			fmt.Printf("Controller 'Media': File '%s': Deletion failed! Error: %#v\n", key, err)
+		}
+	}
+}
+
+// newStorageKey - Generates a random Key with the File Extension
+// The Keys are spread over Subdirectories by their first Characters.
+func newStorageKey(extension string) (string, error) {
+	random := make([]byte, 16)
+
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	name := hex.EncodeToString(random)
+
+	return name[:2] + "/" + name + extension, nil
+}
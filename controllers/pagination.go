@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/model"
+	"gin-blog/repository"
+)
+
+// DEFAULTPERPAGE - Number of Entries on a List Page when not requested otherwise
+var DEFAULTPERPAGE int = 20
+
+// MAXPERPAGE - Largest Number of Entries that can be requested for a List Page
+var MAXPERPAGE int = 100
+
+// MAXPAGE - Highest Page Number that can be requested so that the Offset of the Page cannot overflow
+var MAXPAGE int = 100000
+
+// DEFAULTARTICLESORT - Sort Order of Article Lists when not requested otherwise
+var DEFAULTARTICLESORT string = "-created_at"
+
+// ParseArticleQuery - Reads the Page, Sort Order and Filter Parameters of an Article List
+func ParseArticleQuery(c *gin.Context) (repository.ArticleQuery, error) {
+	var query repository.ArticleQuery = repository.ArticleQuery{
+		Page:    1,
+		PerPage: DEFAULTPERPAGE,
+		Sort:    DEFAULTARTICLESORT,
+	}
+	var err error
+
+	if query.Page, query.PerPage, err = parsePage(c); err != nil {
+		return query, err
+	}
+
+	if sort := c.Query("sort"); sort != "" {
+		if _, _, err = repository.ParseSort(sort); err != nil {
+			return query, err
+		}
+
+		query.Sort = sort
+	}
+
+	if userIdString := c.Query("user_id"); userIdString != "" {
+		var userId uint64
+
+		if userId, err = strconv.ParseUint(userIdString, 10, 64); err != nil {
+			return query, fmt.Errorf("User ID: ID is invalid! Message: %v", err)
+		}
+
+		query.UserID = uint(userId)
+	}
+
+	if status := c.Query("status"); status != "" {
+		if !model.IsValidArticleStatus(model.ArticleStatus(status)) {
+			return query, fmt.Errorf("Status '%s': Status is invalid! Allowed Status: %v", status, model.ARTICLESTATUSES)
+		}
+
+		query.Status = model.ArticleStatus(status)
+	}
+
+	if query.CreatedAfter, err = parseDateParameter(c, "from"); err != nil {
+		return query, err
+	}
+
+	if query.CreatedBefore, err = parseDateParameter(c, "to"); err != nil {
+		return query, err
+	}
+
+	if !query.CreatedBefore.IsZero() && len(c.Query("to")) == len("2006-01-02") {
+		// A Date includes the whole Day
+		query.CreatedBefore = query.CreatedBefore.AddDate(0, 0, 1)
+	}
+
+	return query, nil
+}
+
+// NewPagination - Builds the Page Information and Links for a List Response
+func NewPagination(c *gin.Context, page int, perPage int, total int64) Pagination {
+	var pagination Pagination = Pagination{
+		Current: page,
+		PerPage: perPage,
+		Total:   total,
+	}
+
+	if perPage > 0 {
+		pagination.PageCount = int((total + int64(perPage) - 1) / int64(perPage))
+	}
+
+	if page < pagination.PageCount {
+		pagination.Next = pageLink(c, page+1)
+	}
+
+	if page > 1 && pagination.PageCount > 0 {
+		prev := page - 1
+
+		if prev > pagination.PageCount {
+			prev = pagination.PageCount
+		}
+
+		pagination.Prev = pageLink(c, prev)
+	}
+
+	return pagination
+}
+
+func parsePage(c *gin.Context) (int, int, error) {
+	var page int = 1
+	var perPage int = DEFAULTPERPAGE
+	var err error
+
+	if pageString := c.Query("page"); pageString != "" {
+		if page, err = strconv.Atoi(pageString); err != nil || page < 1 || page > MAXPAGE {
+			return 1, perPage, fmt.Errorf("Page '%s': Page is invalid! Allowed Pages: 1 - %d", pageString, MAXPAGE)
+		}
+	}
+
+	if perPageString := c.Query("per_page"); perPageString != "" {
+		if perPage, err = strconv.Atoi(perPageString); err != nil || perPage < 1 || perPage > MAXPERPAGE {
+			return page, DEFAULTPERPAGE, fmt.Errorf("Per Page '%s': Page Size is invalid! Allowed Sizes: 1 - %d", perPageString, MAXPERPAGE)
+		}
+	}
+
+	return page, perPage, nil
+}
+
+func parseDateParameter(c *gin.Context, name string) (time.Time, error) {
+	var date time.Time
+	var err error
+
+	value := c.Query(name)
+
+	if value == "" {
+		return date, nil
+	}
+
+	if date, err = time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+
+	if date, err = time.Parse("2006-01-02", value); err != nil {
+		return date, fmt.Errorf("Parameter '%s': Date '%s' is invalid! Expected Format: 'YYYY-MM-DD' or RFC 3339", name, value)
+	}
+
+	return date, nil
+}
+
+func pageLink(c *gin.Context, page int) string {
+	var link url.URL = *c.Request.URL
+
+	query := link.Query()
+	query.Set("page", strconv.Itoa(page))
+
+	link.RawQuery = query.Encode()
+
+	return link.RequestURI()
+}
@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/model"
+)
+
+// RequirePermission - Refuses the Request unless the Role of the Authorized User grants the Permission
+// It must be registered after AuthorizeRequest
+func (handler *Handler) RequirePermission(permission model.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authUser := GetAuthUser(c)
+
+		if authUser == nil {
+			AbortUnauthorized(c)
+
+			return
+		}
+
+		if !authUser.Can(permission) {
+			AbortForbidden(c, fmt.Sprintf("User (ID: '%d'): Role '%s' lacks the Permission '%s'!", authUser.ID, authUser.Role, permission))
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// GetAuthUser - Returns the User authorized by AuthorizeRequest
+func GetAuthUser(c *gin.Context) *model.User {
+	if authUser, ok := c.Get("AuthUser"); ok && authUser != nil {
+		if user, ok := authUser.(*model.User); ok {
+			return user
+		}
+	}
+
+	return nil
+}
+
+func AbortUnauthorized(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusUnauthorized,
+		APIErrorResponse{
+			PROJECT + " - Error",
+			http.StatusUnauthorized,
+			"login",
+			"Unauthorized",
+			"Authorization failed: User unauthorized!",
+		})
+}
+
+func AbortForbidden(c *gin.Context, desc string) {
+	c.AbortWithStatusJSON(http.StatusForbidden,
+		APIErrorResponse{
+			PROJECT + " - Error",
+			http.StatusForbidden,
+			"login",
+			"Forbidden",
+			desc,
+		})
+}
@@ -0,0 +1,321 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/model"
+	"gin-blog/repository"
+)
+
+func (handler *Handler) DisplayRevisions(c *gin.Context) {
+	article, ok := handler.findRevisedArticle(c)
+
+	if !ok {
+		return
+	}
+
+	revisions, err := handler.Revisions.GetByArticleID(article.ID)
+
+	if err != nil {
+		AbortWithStorageError(c, "revisions", err)
+
+		return
+	}
+
+	displayed, err := handler.newDisplayedRevisions(revisions)
+
+	if err != nil {
+		AbortWithStorageError(c, "revisions", err)
+
+		return
+	}
+
+	dispatchListView(c, "revisions",
+		RevisionListSuccess{
+			PROJECT + " - Revisions",
+			http.StatusOK,
+			"revisions",
+			"OK",
+			article.ID,
+			displayed,
+		}, "Revisions")
+}
+
+func (handler *Handler) DisplayRevision(c *gin.Context) {
+	article, ok := handler.findRevisedArticle(c)
+
+	if !ok {
+		return
+	}
+
+	revision, ok := handler.findRevision(c, article, c.Params.ByName("number"))
+
+	if !ok {
+		return
+	}
+
+	displayed, err := handler.newDisplayedRevisions([]model.ArticleRevision{*revision})
+
+	if err != nil {
+		AbortWithStorageError(c, "revisions", err)
+
+		return
+	}
+
+	dispatchView(c, "revisions", displayed[0])
+}
+
+// DisplayRevisionDiff - Compares the Revision with the Revision given by the Parameter 'from'
+// Without Parameter the Revision is compared with its preceding Revision
+func (handler *Handler) DisplayRevisionDiff(c *gin.Context) {
+	var from *model.ArticleRevision
+
+	article, ok := handler.findRevisedArticle(c)
+
+	if !ok {
+		return
+	}
+
+	to, ok := handler.findRevision(c, article, c.Params.ByName("number"))
+
+	if !ok {
+		return
+	}
+
+	if fromNumber := c.Query("from"); fromNumber != "" {
+		if from, ok = handler.findRevision(c, article, fromNumber); !ok {
+			return
+		}
+	} else if to.Number > 1 {
+		if from, ok = handler.findRevision(c, article, strconv.FormatUint(uint64(to.Number-1), 10)); !ok {
+			return
+		}
+	} else {
+		// The first Revision is compared with an empty Article
+		from = &model.ArticleRevision{ArticleID: article.ID}
+	}
+
+	diff, err := model.UnifiedDiff(fmt.Sprintf("revision/%d", from.Number), fmt.Sprintf("revision/%d", to.Number), from.Document(), to.Document())
+
+	if err != nil {
+		dispatchUnprocessable(c, "revisions", err.Error())
+
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		RevisionDiffSuccess{
+			PROJECT + " - Revision Diff",
+			http.StatusOK,
+			"revisions",
+			"OK",
+			article.ID,
+			from.Number,
+			to.Number,
+			diff,
+		})
+}
+
+// RestoreRevision - Saves the Title, Slug, Content and Format of the Revision as a new Revision of the Article
+func (handler *Handler) RestoreRevision(c *gin.Context) {
+	var err error
+
+	article, ok := handler.findRevisedArticle(c)
+
+	if !ok {
+		return
+	}
+
+	revision, ok := handler.findRevision(c, article, c.Params.ByName("number"))
+
+	if !ok {
+		return
+	}
+
+	formerSlug := article.Slug
+
+	revision.Restore(article)
+
+	if article.Slug != formerSlug {
+		// The restored Slug might have been taken by another Article meanwhile
+		if article.Slug, ok = resolveSlug(c, "revisions", article.Slug, "", "article", article.ID, handler.slugCheck(model.RedirectArticle, handler.Articles.IsSlugTaken)); !ok {
+			return
+		}
+	}
+
+	// The restored Article is stored together with its former Slug and its next Revision
+	err = handler.Transaction(func(storage repository.Storage) error {
+		scoped := handler.withStorage(storage)
+
+		if err := scoped.Articles.Save(article); err != nil {
+			return err
+		}
+
+		if err := scoped.recordSlugChange(model.RedirectArticle, article.ID, formerSlug, article.Slug); err != nil {
+			return err
+		}
+
+		return scoped.recordRevision(article, GetAuthUser(c), revision.Number)
+	})
+
+	if err != nil {
+		AbortWithStorageError(c, "revisions", err)
+
+		return
+	}
+
+	dispatchView(c, "articles", handler.newDisplayedArticle(article))
+}
+
+// recordRevision - Stores the saved Fields of the Article as its next Revision
+func (handler *Handler) recordRevision(article *model.Article, editor *model.User, restoredFrom uint) error {
+	revision := model.NewArticleRevision(article, editor.ID)
+
+	revision.RestoredFrom = restoredFrom
+
+	return handler.Revisions.Create(&revision)
+}
+
+// findRevisedArticle - Looks up the Article of the Revision Routes
+// The Revisions are only shown to the Users who may modify the Article.
+// It dispatches the Error Response itself and reports whether the Article was found.
+func (handler *Handler) findRevisedArticle(c *gin.Context) (*model.Article, bool) {
+	editor := GetAuthUser(c)
+
+	if editor == nil {
+		// Exit on missing Authorized User
+		return nil, false
+	}
+
+	article, ok := handler.findVisibleArticle(c, "revisions")
+
+	if !ok {
+		return nil, false
+	}
+
+	if !editor.CanModifyArticle(article) {
+		AbortForbidden(c, fmt.Sprintf("Article (ID: '%d'): Revisions are only available to its Author or an Editor!", article.ID))
+
+		return nil, false
+	}
+
+	return article, true
+}
+
+// findVisibleArticle - Looks up the Article of the ID Parameter if it is visible to the Authorized User
+// It dispatches the Error Response itself and reports whether the Article was found.
+func (handler *Handler) findVisibleArticle(c *gin.Context, page string) (*model.Article, bool) {
+	var article *model.Article
+	var articleId uint64
+	var err error
+
+	if articleId, err = strconv.ParseUint(c.Params.ByName("id"), 10, 64); err != nil {
+		c.JSON(http.StatusUnprocessableEntity,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusUnprocessableEntity,
+				page,
+				"Unprocessable Content",
+				"Article ID: ID is invalid! Message: " + err.Error(),
+			})
+
+		return nil, false
+	}
+
+	if article, err = handler.Articles.GetByID(uint(articleId)); article == nil || err != nil || !GetAuthUser(c).CanViewArticle(article) {
+		fmt.Printf("Controller 'Articles': Article (ID '%d'): %#v; Error: %#v\n", articleId, article, err)
+
+		if err != nil && !repository.IsNotFound(err) {
+			AbortWithStorageError(c, page, err)
+
+			return nil, false
+		}
+
+		c.JSON(http.StatusNotFound,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusNotFound,
+				page,
+				"Not Found",
+				fmt.Sprintf("Article (ID: '%d'): Article does not exist", articleId),
+			})
+
+		return nil, false
+	}
+
+	return article, true
+}
+
+// findRevision - Looks up the Revision of the Article by its Number
+// It dispatches the Error Response itself and reports whether the Revision was found.
+func (handler *Handler) findRevision(c *gin.Context, article *model.Article, numberString string) (*model.ArticleRevision, bool) {
+	number, err := strconv.ParseUint(numberString, 10, 32)
+
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusUnprocessableEntity,
+				"revisions",
+				"Unprocessable Content",
+				fmt.Sprintf("Revision Number '%s': Number is invalid!", numberString),
+			})
+
+		return nil, false
+	}
+
+	revision, err := handler.Revisions.GetByNumber(article.ID, uint(number))
+
+	if err != nil {
+		c.JSON(http.StatusNotFound,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusNotFound,
+				"revisions",
+				"Not Found",
+				err.Error(),
+			})
+
+		return nil, false
+	}
+
+	return revision, true
+}
+
+// newDisplayedRevisions - Creates the Revision Views with the Names of their Editors
+func (handler *Handler) newDisplayedRevisions(revisions []model.ArticleRevision) ([]model.DisplayedRevision, error) {
+	var editorIDs []uint
+
+	seen := make(map[uint]bool)
+
+	for _, revision := range revisions {
+		if !seen[revision.UserID] {
+			seen[revision.UserID] = true
+			editorIDs = append(editorIDs, revision.UserID)
+		}
+	}
+
+	editors, err := handler.Users.GetByIDs(editorIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint]string)
+
+	for _, editor := range editors {
+		names[editor.ID] = editor.Name
+	}
+
+	displayed := make([]model.DisplayedRevision, 0, len(revisions))
+
+	for index := range revisions {
+		displayed = append(displayed, model.NewDisplayedRevision(&revisions[index], names[revisions[index].UserID]))
+	}
+
+	return displayed, nil
+}
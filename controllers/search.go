@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/model"
+	"gin-blog/repository"
+)
+
+// SearchArticles - Finds the visible Articles matching the Search Parameter 'q' ordered by their Rank
+// The Filters and the Pagination of the Article List apply to the Search as well.
+func (handler *Handler) SearchArticles(c *gin.Context) {
+	var results []repository.SearchResult
+	var displayedArticles []model.DisplayedArticle
+	var total int64
+
+	terms := strings.TrimSpace(c.Query("q"))
+
+	if terms == "" {
+		dispatchUnprocessable(c, "search", "Search: Parameter 'q' is missing!")
+
+		return
+	}
+
+	articleQuery, err := ParseArticleQuery(c)
+
+	if err != nil {
+		dispatchUnprocessable(c, "search", err.Error())
+
+		return
+	}
+
+	query := repository.SearchQuery{ArticleQuery: articleQuery, Terms: terms}
+
+	restrictArticleQuery(c, &query.ArticleQuery)
+
+	matched, err := handler.applyAuthorFilter(c, &query.ArticleQuery)
+
+	if err == nil && matched {
+		matched, err = handler.applyTaxonomyFilters(c, &query.ArticleQuery)
+	}
+
+	if err == nil && matched {
+		results, total, err = handler.Articles.Search(&query)
+	}
+
+	if err != nil {
+		AbortWithStorageError(c, "search", err)
+
+		return
+	}
+
+	articles := make([]model.Article, 0, len(results))
+
+	for _, result := range results {
+		articles = append(articles, result.Article)
+	}
+
+	if displayedArticles, err = handler.newDisplayedArticles(articles); err != nil {
+		AbortWithStorageError(c, "search", err)
+
+		return
+	}
+
+	displayedResults := make([]model.DisplayedSearchResult, 0, len(results))
+
+	for idx, result := range results {
+		displayedResults = append(displayedResults, model.DisplayedSearchResult{
+			Article: displayedArticles[idx],
+			Rank:    result.Rank,
+			Snippet: model.HighlightSnippet(result.Snippet),
+		})
+	}
+
+	dispatchListView(c, "search",
+		SearchSuccess{
+			PROJECT + " - Search",
+			http.StatusOK,
+			"search",
+			"OK",
+			terms,
+			NewPagination(c, query.Page, query.PerPage, total),
+			displayedResults,
+		}, "Results")
+}
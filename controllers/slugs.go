@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/model"
+	"gin-blog/repository"
+)
+
+// resolveSlug - Checks the Slug supplied by the Client or generates a unique Slug from the Source Text
+// Text without transliterable Characters falls back to the Fallback Slug.
+// It dispatches the Error Response itself and reports whether the Slug can be used.
+func resolveSlug(c *gin.Context, page string, requested string, source string, fallback string, exceptID uint,
+	isTaken func(slug string, exceptID uint) (bool, error)) (string, bool) {
+
+	if requested != "" {
+		if !model.IsValidSlug(requested) {
+			dispatchSlugError(c, page, fmt.Sprintf("Slug '%s': Slug is invalid! Only lowercase Letters, Digits and single Hyphens are allowed", requested))
+
+			return "", false
+		}
+
+		taken, err := isTaken(requested, exceptID)
+
+		if err != nil {
+			AbortWithStorageError(c, page, err)
+
+			return "", false
+		}
+
+		if taken {
+			dispatchSlugError(c, page, fmt.Sprintf("Slug '%s': Slug is already taken!", requested))
+
+			return "", false
+		}
+
+		return requested, true
+	}
+
+	base := model.Slugify(source)
+
+	if base == "" {
+		base = fallback
+	}
+
+	slug, err := model.UniqueSlug(base, func(slug string) (bool, error) {
+		return isTaken(slug, exceptID)
+	})
+
+	if err != nil {
+		AbortWithStorageError(c, page, err)
+
+		return "", false
+	}
+
+	return slug, true
+}
+
+func dispatchSlugError(c *gin.Context, page string, desc string) {
+	c.JSON(http.StatusUnprocessableEntity,
+		APIErrorResponse{
+			PROJECT + " - Error",
+			http.StatusUnprocessableEntity,
+			page,
+			"Unprocessable Content",
+			desc,
+		})
+}
+
+// slugCheck - Extends the Slug Check of a Repository with the former Slugs
+// A former Slug stays reserved for the Entity which used it
+func (handler *Handler) slugCheck(entity string, isTaken func(slug string, exceptID uint) (bool, error)) func(slug string, exceptID uint) (bool, error) {
+	return func(slug string, exceptID uint) (bool, error) {
+		taken, err := isTaken(slug, exceptID)
+
+		if taken || err != nil {
+			return taken, err
+		}
+
+		redirect, err := handler.Redirects.GetBySlug(entity, slug)
+
+		if err != nil {
+			if repository.IsNotFound(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		return redirect.TargetID != exceptID, nil
+	}
+}
+
+// recordSlugChange - Redirects the former Slug to the Entity
+// A former Slug which the Entity takes again is not redirected anymore
+func (handler *Handler) recordSlugChange(entity string, targetID uint, oldSlug string, newSlug string) error {
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+
+	if err := handler.Redirects.Save(&model.SlugRedirect{Entity: entity, Slug: oldSlug, TargetID: targetID}); err != nil {
+		return err
+	}
+
+	return handler.Redirects.DeleteBySlug(entity, newSlug)
+}
+
+// findArticleBySlug - Looks up the Article by its current or a former Slug
+// It reports whether the Slug is a former Slug of the Article
+func (handler *Handler) findArticleBySlug(slug string) (*model.Article, bool, error) {
+	article, err := handler.Articles.GetBySlug(slug)
+
+	if err == nil || !repository.IsNotFound(err) {
+		return article, false, err
+	}
+
+	redirect, redirectErr := handler.Redirects.GetBySlug(model.RedirectArticle, slug)
+
+	if redirectErr != nil {
+		if repository.IsNotFound(redirectErr) {
+			return nil, false, err
+		}
+
+		return nil, false, redirectErr
+	}
+
+	article, err = handler.Articles.GetByID(redirect.TargetID)
+
+	return article, err == nil, err
+}
+
+// findUserBySlug - Looks up the User by its current or a former Slug
+// It reports whether the Slug is a former Slug of the User
+func (handler *Handler) findUserBySlug(slug string) (*model.User, bool, error) {
+	user, err := handler.Users.GetBySlug(slug)
+
+	if err == nil || !repository.IsNotFound(err) {
+		return user, false, err
+	}
+
+	redirect, redirectErr := handler.Redirects.GetBySlug(model.RedirectUser, slug)
+
+	if redirectErr != nil {
+		if repository.IsNotFound(redirectErr) {
+			return nil, false, err
+		}
+
+		return nil, false, redirectErr
+	}
+
+	user, err = handler.Users.GetByID(redirect.TargetID)
+
+	return user, err == nil, err
+}
+
+// dispatchSlugRedirect - Redirects permanently to the Route with the current Slug
+// The Route must name its Slug Parameter ':slug'
+func dispatchSlugRedirect(c *gin.Context, slug string) {
+	location := url.URL{
+		Path:     strings.Replace(c.FullPath(), ":slug", url.PathEscape(slug), 1),
+		RawQuery: c.Request.URL.RawQuery,
+	}
+
+	c.Redirect(http.StatusMovedPermanently, location.String())
+}
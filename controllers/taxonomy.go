@@ -0,0 +1,573 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/config"
+	"gin-blog/model"
+	"gin-blog/repository"
+)
+
+func RegisterTaxonomyRoutes(engine *gin.Engine, config *config.AppConfig, handler *Handler) {
+
+	if PROJECT == "" {
+		// Copy the Project Name
+		PROJECT = config.Project
+	}
+
+	// Copy the Handler Settings
+	handler.configure(config)
+
+	// Tag Routes
+	engine.GET(config.WebRoot+"tags", handler.IdentifyRequest(), handler.DisplayTags)
+	engine.GET(config.WebRoot+"tags/:slug", handler.IdentifyRequest(), handler.DisplayTag)
+	engine.POST(config.WebRoot+"tags", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionManageTaxonomy), handler.CreateTag)
+	engine.PUT(config.WebRoot+"tags/:slug", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionManageTaxonomy), handler.UpdateTag)
+	engine.DELETE(config.WebRoot+"tags/:slug", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionManageTaxonomy), handler.DeleteTag)
+
+	// Category Routes
+	engine.GET(config.WebRoot+"categories", handler.DisplayCategories)
+	engine.GET(config.WebRoot+"categories/:slug", handler.DisplayCategory)
+	engine.POST(config.WebRoot+"categories", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionManageTaxonomy), handler.CreateCategory)
+	engine.PUT(config.WebRoot+"categories/:slug", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionManageTaxonomy), handler.UpdateCategory)
+	engine.DELETE(config.WebRoot+"categories/:slug", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionManageTaxonomy), handler.DeleteCategory)
+}
+
+// DisplayTags - Lists all Tags with the Number of their visible Articles for a Tag Cloud
+func (handler *Handler) DisplayTags(c *gin.Context) {
+	var query repository.ArticleQuery
+
+	tags, err := handler.Tags.List()
+
+	if err != nil {
+		AbortWithStorageError(c, "tags", err)
+
+		return
+	}
+
+	restrictArticleQuery(c, &query)
+
+	counts, err := handler.Articles.CountTags(&query)
+
+	if err != nil {
+		AbortWithStorageError(c, "tags", err)
+
+		return
+	}
+
+	tagCounts := make([]model.TagCount, 0, len(tags))
+
+	for idx := range tags {
+		tagCounts = append(tagCounts, model.TagCount{DisplayedTag: model.NewDisplayedTag(&tags[idx]), ArticleCount: counts[tags[idx].ID]})
+	}
+
+	dispatchListView(c, "tags",
+		TagListSuccess{
+			PROJECT + " - Tags",
+			http.StatusOK,
+			"tags",
+			"OK",
+			tagCounts,
+		}, "Tags")
+}
+
+func (handler *Handler) DisplayTag(c *gin.Context) {
+	var query repository.ArticleQuery
+
+	tag, ok := handler.findTag(c)
+
+	if !ok {
+		return
+	}
+
+	restrictArticleQuery(c, &query)
+
+	query.TagID = tag.ID
+
+	counts, err := handler.Articles.CountTags(&query)
+
+	if err != nil {
+		AbortWithStorageError(c, "tags", err)
+
+		return
+	}
+
+	dispatchView(c, "tags", model.TagCount{DisplayedTag: model.NewDisplayedTag(tag), ArticleCount: counts[tag.ID]})
+}
+
+func (handler *Handler) CreateTag(c *gin.Context) {
+	var tag model.Tag
+	var ok bool
+
+	c.BindJSON(&tag)
+
+	tag.Name = strings.TrimSpace(tag.Name)
+
+	if tag.Name == "" {
+		dispatchUnprocessable(c, "tags", "Model 'Tag': Name is missing!")
+
+		return
+	}
+
+	if tag.Slug, ok = resolveSlug(c, "tags", tag.Slug, tag.Name, "tag", 0, handler.Tags.IsSlugTaken); !ok {
+		return
+	}
+
+	if err := handler.Tags.Create(&tag); err != nil {
+		AbortWithStorageError(c, "tags", err)
+
+		return
+	}
+
+	dispatchView(c, "tags", model.NewDisplayedTag(&tag))
+}
+
+func (handler *Handler) UpdateTag(c *gin.Context) {
+	var updated model.Tag
+
+	tag, ok := handler.findTag(c)
+
+	if !ok {
+		return
+	}
+
+	c.BindJSON(&updated)
+
+	if name := strings.TrimSpace(updated.Name); name != "" {
+		tag.Name = name
+	}
+
+	if updated.Slug != "" && updated.Slug != tag.Slug {
+		if tag.Slug, ok = resolveSlug(c, "tags", updated.Slug, "", "tag", tag.ID, handler.Tags.IsSlugTaken); !ok {
+			return
+		}
+	}
+
+	if err := handler.Tags.Save(tag); err != nil {
+		AbortWithStorageError(c, "tags", err)
+
+		return
+	}
+
+	dispatchView(c, "tags", model.NewDisplayedTag(tag))
+}
+
+// DeleteTag - Removes the Tag from all Articles and deletes it
+func (handler *Handler) DeleteTag(c *gin.Context) {
+	tag, ok := handler.findTag(c)
+
+	if !ok {
+		return
+	}
+
+	if err := handler.Tags.Delete(tag); err != nil {
+		AbortWithStorageError(c, "tags", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		APIDeleteSuccess{
+			PROJECT + " - Delete Success",
+			http.StatusOK,
+			"tags",
+			"OK",
+			fmt.Sprintf("Tag (Slug: '%s'): Tag was deleted", tag.Slug),
+		},
+	)
+}
+
+// DisplayCategories - Lists all Categories as Tree of the Top Level Categories
+func (handler *Handler) DisplayCategories(c *gin.Context) {
+	tree, ok := handler.loadCategoryTree(c)
+
+	if !ok {
+		return
+	}
+
+	dispatchListView(c, "categories",
+		CategoryListSuccess{
+			PROJECT + " - Categories",
+			http.StatusOK,
+			"categories",
+			"OK",
+			tree.DisplayAll(),
+		}, "Categories")
+}
+
+func (handler *Handler) DisplayCategory(c *gin.Context) {
+	tree, ok := handler.loadCategoryTree(c)
+
+	if !ok {
+		return
+	}
+
+	category, ok := findTreeCategory(c, tree)
+
+	if !ok {
+		return
+	}
+
+	dispatchView(c, "categories", tree.Display(category))
+}
+
+func (handler *Handler) CreateCategory(c *gin.Context) {
+	var data model.CategoryData
+	var ok bool
+
+	c.BindJSON(&data)
+
+	category := data.NewCategory()
+
+	category.Name = strings.TrimSpace(category.Name)
+
+	if category.Name == "" {
+		dispatchUnprocessable(c, "categories", "Model 'Category': Name is missing!")
+
+		return
+	}
+
+	tree, ok := handler.loadCategoryTree(c)
+
+	if !ok {
+		return
+	}
+
+	if !setCategoryParent(c, tree, &category, data.Parent) {
+		return
+	}
+
+	if category.Slug, ok = resolveSlug(c, "categories", category.Slug, category.Name, "category", 0, handler.Categories.IsSlugTaken); !ok {
+		return
+	}
+
+	if err := handler.Categories.Create(&category); err != nil {
+		AbortWithStorageError(c, "categories", err)
+
+		return
+	}
+
+	tree.Categories[category.ID] = &category
+
+	dispatchView(c, "categories", tree.Display(&category))
+}
+
+func (handler *Handler) UpdateCategory(c *gin.Context) {
+	var data model.CategoryData
+
+	tree, ok := handler.loadCategoryTree(c)
+
+	if !ok {
+		return
+	}
+
+	category, ok := findTreeCategory(c, tree)
+
+	if !ok {
+		return
+	}
+
+	c.BindJSON(&data)
+
+	data.Name = strings.TrimSpace(data.Name)
+
+	if data.Slug != "" && data.Slug != category.Slug {
+		if data.Slug, ok = resolveSlug(c, "categories", data.Slug, "", "category", category.ID, handler.Categories.IsSlugTaken); !ok {
+			return
+		}
+	}
+
+	if !setCategoryParent(c, tree, category, data.Parent) {
+		return
+	}
+
+	category.Update(&data)
+
+	if err := handler.Categories.Save(category); err != nil {
+		AbortWithStorageError(c, "categories", err)
+
+		return
+	}
+
+	if tree, ok = handler.loadCategoryTree(c); !ok {
+		return
+	}
+
+	dispatchView(c, "categories", tree.Display(tree.Categories[category.ID]))
+}
+
+// DeleteCategory - Deletes a Category which has neither Subcategories nor Articles
+func (handler *Handler) DeleteCategory(c *gin.Context) {
+	tree, ok := handler.loadCategoryTree(c)
+
+	if !ok {
+		return
+	}
+
+	category, ok := findTreeCategory(c, tree)
+
+	if !ok {
+		return
+	}
+
+	if len(tree.Children[category.ID]) != 0 {
+		dispatchUnprocessable(c, "categories", fmt.Sprintf("Category (Slug: '%s'): Category still has Subcategories!", category.Slug))
+
+		return
+	}
+
+	_, total, err := handler.Articles.Find(&repository.ArticleQuery{PerPage: 1, CategoryIDs: []uint{category.ID}})
+
+	if err != nil {
+		AbortWithStorageError(c, "categories", err)
+
+		return
+	}
+
+	if total != 0 {
+		dispatchUnprocessable(c, "categories", fmt.Sprintf("Category (Slug: '%s'): Category still has %d Articles!", category.Slug, total))
+
+		return
+	}
+
+	if err = handler.Categories.Delete(category); err != nil {
+		AbortWithStorageError(c, "categories", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		APIDeleteSuccess{
+			PROJECT + " - Delete Success",
+			http.StatusOK,
+			"categories",
+			"OK",
+			fmt.Sprintf("Category (Slug: '%s'): Category was deleted", category.Slug),
+		},
+	)
+}
+
+// findTag - Looks up the Tag of the Slug Parameter
+// It dispatches the Error Response itself and reports whether the Tag was found.
+func (handler *Handler) findTag(c *gin.Context) (*model.Tag, bool) {
+	tag, err := handler.Tags.GetBySlug(c.Params.ByName("slug"))
+
+	if err != nil {
+		dispatchNotFound(c, "tags", err)
+
+		return nil, false
+	}
+
+	return tag, true
+}
+
+// loadCategoryTree - Looks up all Categories
+// It dispatches the Error Response itself and reports whether the Categories were found.
+func (handler *Handler) loadCategoryTree(c *gin.Context) (*model.CategoryTree, bool) {
+	categories, err := handler.Categories.List()
+
+	if err != nil {
+		AbortWithStorageError(c, "categories", err)
+
+		return nil, false
+	}
+
+	return model.NewCategoryTree(categories), true
+}
+
+// findTreeCategory - Looks up the Category of the Slug Parameter
+// It dispatches the Error Response itself and reports whether the Category was found.
+func findTreeCategory(c *gin.Context, tree *model.CategoryTree) (*model.Category, bool) {
+	categorySlug := c.Params.ByName("slug")
+
+	for _, category := range tree.Categories {
+		if category.Slug == categorySlug {
+			return category, true
+		}
+	}
+
+	dispatchNotFound(c, "categories", &repository.NotFoundError{Message: fmt.Sprintf("Category (Slug: '%s'): Category does not exist!", categorySlug)})
+
+	return nil, false
+}
+
+// setCategoryParent - Nests the Category into the Parent given by its Slug
+// An empty Slug moves the Category to the Top Level and a missing Slug keeps the Parent.
+// It dispatches the Error Response itself and reports whether the Parent can be used.
+func setCategoryParent(c *gin.Context, tree *model.CategoryTree, category *model.Category, parentSlug *string) bool {
+	if parentSlug == nil {
+		return true
+	}
+
+	if *parentSlug == "" {
+		category.ParentID = nil
+
+		return true
+	}
+
+	for _, parent := range tree.Categories {
+		if parent.Slug == *parentSlug {
+			if err := tree.CheckParent(category, parent.ID); err != nil {
+				dispatchUnprocessable(c, "categories", err.Error())
+
+				return false
+			}
+
+			parentID := parent.ID
+
+			category.ParentID = &parentID
+
+			return true
+		}
+	}
+
+	dispatchUnprocessable(c, "categories", fmt.Sprintf("Category (Slug: '%s'): Parent Category does not exist!", *parentSlug))
+
+	return false
+}
+
+// applyTaxonomyFilters - Restricts the Query to the Tag and Category Parameters
+// The Category includes its Subcategories. It reports whether the Tag and the Category exist.
+func (handler *Handler) applyTaxonomyFilters(c *gin.Context, query *repository.ArticleQuery) (bool, error) {
+	if tagSlug := c.Query("tag"); tagSlug != "" {
+		tag, err := handler.Tags.GetBySlug(tagSlug)
+
+		if err != nil {
+			if repository.IsNotFound(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		query.TagID = tag.ID
+	}
+
+	if categorySlug := c.Query("category"); categorySlug != "" {
+		category, err := handler.Categories.GetBySlug(categorySlug)
+
+		if err != nil {
+			if repository.IsNotFound(err) {
+				return false, nil
+			}
+
+			return false, err
+		}
+
+		categories, err := handler.Categories.List()
+
+		if err != nil {
+			return false, err
+		}
+
+		query.CategoryIDs = model.NewCategoryTree(categories).Descendants(category.ID)
+	}
+
+	return true, nil
+}
+
+// assignCategory - Assigns the Category given by its Slug to the Article
+// An empty Slug removes the Category and a missing Slug keeps it.
+// It dispatches the Error Response itself and reports whether the Category can be assigned.
+func (handler *Handler) assignCategory(c *gin.Context, article *model.Article, categorySlug *string) bool {
+	if categorySlug == nil {
+		return true
+	}
+
+	if *categorySlug == "" {
+		article.CategoryID = nil
+
+		return true
+	}
+
+	category, err := handler.Categories.GetBySlug(*categorySlug)
+
+	if err != nil {
+		if repository.IsNotFound(err) {
+			dispatchUnprocessable(c, "articles", err.Error())
+		} else {
+			AbortWithStorageError(c, "articles", err)
+		}
+
+		return false
+	}
+
+	article.CategoryID = &category.ID
+
+	return true
+}
+
+// resolveTags - Looks up the Tags of the Names where the missing Tags are given without ID
+// Missing Names keep the Tags of the Article which is reported with a nil List.
+// It dispatches the Error Response itself and reports whether the Tags can be assigned.
+func (handler *Handler) resolveTags(c *gin.Context, tagNames []string) ([]model.Tag, bool) {
+	var tagSlugs []string
+
+	if tagNames == nil {
+		return nil, true
+	}
+
+	requested := make(map[string]model.Tag)
+
+	for _, tagName := range tagNames {
+		tag := model.NewTag(strings.TrimSpace(tagName))
+
+		if tag.Slug == "" {
+			dispatchUnprocessable(c, "articles", fmt.Sprintf("Tag '%s': Name is invalid!", tagName))
+
+			return nil, false
+		}
+
+		if _, ok := requested[tag.Slug]; !ok {
+			requested[tag.Slug] = tag
+			tagSlugs = append(tagSlugs, tag.Slug)
+		}
+	}
+
+	existing, err := handler.Tags.GetBySlugs(tagSlugs)
+
+	if err != nil {
+		AbortWithStorageError(c, "articles", err)
+
+		return nil, false
+	}
+
+	for _, tag := range existing {
+		requested[tag.Slug] = tag
+	}
+
+	tags := make([]model.Tag, 0, len(tagSlugs))
+
+	for _, tagSlug := range tagSlugs {
+		tags = append(tags, requested[tagSlug])
+	}
+
+	return tags, true
+}
+
+// assignTags - Replaces the Tags of the saved Article unless the Tags are kept
+// The Tags without ID are created first. Every User who may modify the Article
+// can create Tags this way, while renaming and deleting Tags needs PermissionManageTaxonomy.
+func (handler *Handler) assignTags(article *model.Article, tags []model.Tag) error {
+	if tags == nil {
+		return nil
+	}
+
+	tagIDs := make([]uint, 0, len(tags))
+
+	for index := range tags {
+		if tags[index].ID == 0 {
+			if err := handler.Tags.Create(&tags[index]); err != nil {
+				return err
+			}
+		}
+
+		tagIDs = append(tagIDs, tags[index].ID)
+	}
+
+	return handler.Tags.SetArticleTags(article.ID, tagIDs)
+}
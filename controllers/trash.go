@@ -0,0 +1,275 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/config"
+	"gin-blog/model"
+	"gin-blog/repository"
+)
+
+func RegisterTrashRoutes(engine *gin.Engine, config *config.AppConfig, handler *Handler) {
+
+	if PROJECT == "" {
+		// Copy the Project Name
+		PROJECT = config.Project
+	}
+
+	// Copy the Handler Settings
+	handler.configure(config)
+
+	// Trash Routes
+	engine.GET(config.WebRoot+"trash", handler.AuthorizeRequest(), handler.DisplayTrash)
+	engine.POST(config.WebRoot+"articles/:id/restore", handler.AuthorizeRequest(), handler.RestoreArticle)
+	engine.POST(config.WebRoot+"users/:id/restore", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionManageUsers), handler.RestoreUser)
+	engine.DELETE(config.WebRoot+"trash/articles/:id", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionManageUsers), handler.PurgeTrashedArticle)
+	engine.DELETE(config.WebRoot+"trash/users/:id", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionManageUsers), handler.PurgeTrashedUser)
+}
+
+// DisplayTrash - Lists the deleted Articles and Users which the Authorized User may restore
+// Authors see their own Articles, Editors all Articles and Administrators also the Users.
+func (handler *Handler) DisplayTrash(c *gin.Context) {
+	authUser := GetAuthUser(c)
+
+	if authUser == nil {
+		// Exit on missing Authorized User
+		return
+	}
+
+	trash := TrashSuccess{
+		PROJECT + " - Trash",
+		http.StatusOK,
+		"trash",
+		"OK",
+		[]model.TrashedArticle{},
+		[]model.TrashedUser{},
+	}
+
+	articles, err := handler.Articles.ListDeleted(time.Now())
+
+	if err != nil {
+		AbortWithStorageError(c, "trash", err)
+
+		return
+	}
+
+	for idx := range articles {
+		if authUser.CanModifyArticle(&articles[idx]) {
+			trash.Articles = append(trash.Articles, model.NewTrashedArticle(&articles[idx], handler.TrashRetention))
+		}
+	}
+
+	if authUser.Can(model.PermissionManageUsers) {
+		users, err := handler.Users.ListDeleted(time.Now())
+
+		if err != nil {
+			AbortWithStorageError(c, "trash", err)
+
+			return
+		}
+
+		for idx := range users {
+			trash.Users = append(trash.Users, model.NewTrashedUser(&users[idx], handler.TrashRetention))
+		}
+	}
+
+	c.JSON(http.StatusOK, trash)
+}
+
+// RestoreArticle - Takes the deleted Article out of the Trash
+func (handler *Handler) RestoreArticle(c *gin.Context) {
+	authUser := GetAuthUser(c)
+
+	if authUser == nil {
+		// Exit on missing Authorized User
+		return
+	}
+
+	article, ok := handler.findTrashedArticle(c)
+
+	if !ok {
+		return
+	}
+
+	if !authUser.CanModifyArticle(article) {
+		AbortForbidden(c, fmt.Sprintf("Article (ID: '%d'): Article can only be restored by its Author or an Editor!", article.ID))
+
+		return
+	}
+
+	if err := handler.Articles.Restore(article); err != nil {
+		AbortWithStorageError(c, "trash", err)
+
+		return
+	}
+
+	dispatchView(c, "articles", handler.newDisplayedArticle(article))
+}
+
+// RestoreUser - Takes the deleted User out of the Trash
+// A User whose Login was given to another User meanwhile cannot be restored.
+func (handler *Handler) RestoreUser(c *gin.Context) {
+	user, ok := handler.findTrashedUser(c)
+
+	if !ok {
+		return
+	}
+
+	if other, err := handler.Users.GetByLogin(user.Login); err != nil && !repository.IsNotFound(err) {
+		AbortWithStorageError(c, "trash", err)
+
+		return
+	} else if other != nil {
+		dispatchUnprocessable(c, "trash", fmt.Sprintf("User (ID: '%d'): Login '%s' is used by another User!", user.ID, user.Login))
+
+		return
+	}
+
+	if err := handler.Users.Restore(user); err != nil {
+		AbortWithStorageError(c, "trash", err)
+
+		return
+	}
+
+	dispatchView(c, "users", NewUserView(GetAuthUser(c), user))
+}
+
+// PurgeTrashedArticle - Removes the deleted Article permanently
+func (handler *Handler) PurgeTrashedArticle(c *gin.Context) {
+	article, ok := handler.findTrashedArticle(c)
+
+	if !ok {
+		return
+	}
+
+	if err := handler.PurgeArticle(article); err != nil {
+		AbortWithStorageError(c, "trash", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		APIDeleteSuccess{
+			PROJECT + " - Delete Success",
+			http.StatusOK,
+			"trash",
+			"OK",
+			fmt.Sprintf("Article (ID: '%d'): Article was purged", article.ID),
+		},
+	)
+}
+
+// PurgeTrashedUser - Removes the deleted User permanently
+func (handler *Handler) PurgeTrashedUser(c *gin.Context) {
+	user, ok := handler.findTrashedUser(c)
+
+	if !ok {
+		return
+	}
+
+	if err := handler.PurgeUser(user); err != nil {
+		AbortWithStorageError(c, "trash", err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		APIDeleteSuccess{
+			PROJECT + " - Delete Success",
+			http.StatusOK,
+			"trash",
+			"OK",
+			fmt.Sprintf("User (ID: '%d'): User was purged", user.ID),
+		},
+	)
+}
+
+// PurgeArticle - Removes the deleted Article permanently together with its Uploads
+// The Uploads are not kept unattached since unattached Media are visible to everyone.
+func (handler *Handler) PurgeArticle(article *model.Article) error {
+	media, err := handler.Media.GetByArticleID(article.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if err = handler.Articles.Purge(article); err != nil {
+		return err
+	}
+
+	for idx := range media {
+		handler.deleteMediaFiles(&media[idx])
+	}
+
+	return nil
+}
+
+// PurgeUser - Removes the deleted User permanently together with the Uploads
+// Users who still have Articles in the Blog or in the Trash are refused by the Repository with a ConflictError.
+// The uploaded Files are only removed once the User and the Media Records are purged.
+func (handler *Handler) PurgeUser(user *model.User) error {
+	media, err := handler.Media.GetByUserID(user.ID)
+
+	if err != nil {
+		return err
+	}
+
+	if err = handler.Users.Purge(user); err != nil {
+		return err
+	}
+
+	for idx := range media {
+		handler.deleteMediaFiles(&media[idx])
+	}
+
+	return nil
+}
+
+// findTrashedArticle - Looks up the deleted Article of the ID Parameter
+// It dispatches the Error Response itself and reports whether the Article was found.
+func (handler *Handler) findTrashedArticle(c *gin.Context) (*model.Article, bool) {
+	articleId, err := strconv.ParseUint(c.Params.ByName("id"), 10, 64)
+
+	if err != nil {
+		dispatchUnprocessable(c, "trash", "Article ID: ID is invalid! Message: "+err.Error())
+
+		return nil, false
+	}
+
+	article, err := handler.Articles.GetDeletedByID(uint(articleId))
+
+	if err != nil {
+		dispatchNotFound(c, "trash", err)
+
+		return nil, false
+	}
+
+	return article, true
+}
+
+// findTrashedUser - Looks up the deleted User of the ID Parameter
+// It dispatches the Error Response itself and reports whether the User was found.
+func (handler *Handler) findTrashedUser(c *gin.Context) (*model.User, bool) {
+	userId, err := strconv.ParseUint(c.Params.ByName("id"), 10, 64)
+
+	if err != nil {
+		dispatchUnprocessable(c, "trash", "User ID: ID is invalid! Message: "+err.Error())
+
+		return nil, false
+	}
+
+	user, err := handler.Users.GetDeletedByID(uint(userId))
+
+	if err != nil {
+		dispatchNotFound(c, "trash", err)
+
+		return nil, false
+	}
+
+	return user, true
+}
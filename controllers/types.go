@@ -2,8 +2,14 @@ package controllers
 
 import (
 	"fmt"
+	"net/http"
+	"time"
 
-	"gorm.io/gorm"
+	"github.com/gin-gonic/gin"
+
+	"gin-blog/config"
+	"gin-blog/model"
+	"gin-blog/repository"
 )
 
 type (
@@ -32,18 +38,137 @@ type (
 	}
 
 	LoginSuccess struct {
+		Title         string
+		StatusCode    uint
+		Page          string
+		Message       string
+		Token         string
+		Expiry        string
+		RefreshToken  string
+		RefreshExpiry string
+	}
+
+	RefreshRequest struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+
+	LogoutSuccess struct {
+		Title       string
+		StatusCode  uint
+		Page        string
+		Message     string
+		Description string
+	}
+
+	Pagination struct {
+		Current   int
+		PerPage   int
+		PageCount int
+		Total     int64
+		Next      string
+		Prev      string
+	}
+
+	ArticleListSuccess struct {
+		Title      string
+		StatusCode uint
+		Page       string
+		Message    string
+		Pagination Pagination
+		Author     *model.DisplayedAuthor `json:",omitempty"`
+		Articles   []model.DisplayedArticle
+	}
+
+	TagListSuccess struct {
+		Title      string
+		StatusCode uint
+		Page       string
+		Message    string
+		Tags       []model.TagCount
+	}
+
+	CategoryListSuccess struct {
+		Title      string
+		StatusCode uint
+		Page       string
+		Message    string
+		Categories []model.DisplayedCategory
+	}
+
+	SearchSuccess struct {
+		Title      string
+		StatusCode uint
+		Page       string
+		Message    string
+		Query      string
+		Pagination Pagination
+		Results    []model.DisplayedSearchResult
+	}
+
+	CommentListSuccess struct {
+		Title        string
+		StatusCode   uint
+		Page         string
+		Message      string
+		ArticleID    uint
+		CommentCount int
+		Comments     []model.DisplayedComment
+	}
+
+	RevisionListSuccess struct {
 		Title      string
 		StatusCode uint
 		Page       string
 		Message    string
-		Token      string
-		Expiry     string
+		ArticleID  uint
+		Revisions  []model.DisplayedRevision
+	}
+
+	RevisionDiffSuccess struct {
+		Title      string
+		StatusCode uint
+		Page       string
+		Message    string
+		ArticleID  uint
+		From       uint
+		To         uint
+		Diff       string
 	}
 
 	AuthorizationSubject struct {
 		ID    uint
 		Login string
 	}
+
+	MediaListSuccess struct {
+		Title      string
+		StatusCode uint
+		Page       string
+		Message    string
+		ArticleID  uint `json:",omitempty"`
+		Media      []model.DisplayedMedia
+	}
+
+	TrashSuccess struct {
+		Title      string
+		StatusCode uint
+		Page       string
+		Message    string
+		Articles   []model.TrashedArticle
+		Users      []model.TrashedUser
+	}
+
+	// Handler - Request Handlers with the Storage they operate on
+	// The Files of the uploaded Media are kept in a separate File Storage
+	// A Trash Retention of zero keeps the deleted Entities forever
+	Handler struct {
+		repository.Storage
+		Files          repository.FileStore
+		Auth           config.AuthConfig
+		WebRoot        string
+		MaxMediaSize   int64
+		TrashRetention time.Duration
+	}
 )
 
 // PROJECT - Project Name
@@ -52,11 +177,30 @@ var PROJECT string = ""
 // PROJECTDESCRIPTION - The Project Description
 var PROJECTDESCRIPTION string = ""
 
-// DATABASE - Global Database Connection
-var DATABASE *gorm.DB
+func NewHandler(storage repository.Storage) *Handler {
+	return &Handler{Storage: storage}
+}
+
+// withStorage - Copies the Request Handlers onto the Repositories of a Transaction
+func (handler *Handler) withStorage(storage repository.Storage) *Handler {
+	scoped := *handler
+	scoped.Storage = storage
+
+	return &scoped
+}
+
+// configure - Copies the Settings which the Request Handlers depend on
+func (handler *Handler) configure(config *config.AppConfig) {
+	handler.Auth = config.Auth
+	handler.Auth.SetDefaults(config.Project)
+	handler.WebRoot = config.WebRoot
 
-// SESSIONEXPIRY - Validity of a Login Session
-var SESSIONEXPIRY uint = 20
+	media := config.Media
+	media.SetDefaults(config.MainDirectory)
+
+	handler.MaxMediaSize = int64(media.MaxSize) * 1024
+	handler.TrashRetention, _ = config.Trash.Retention()
+}
 
 func NewAuthorizationSubject(subject map[string]interface{}) AuthorizationSubject {
 	var authSubject AuthorizationSubject = AuthorizationSubject{0, ""}
@@ -84,3 +228,58 @@ func NewAuthorizationSubject(subject map[string]interface{}) AuthorizationSubjec
 
 	return authSubject
 }
+
+// AbortWithStorageError - Dispatches an Error Response for a failed Storage Operation
+// A violated Uniqueness Constraint is reported as Unprocessable Content
+func AbortWithStorageError(c *gin.Context, page string, err error) {
+	if repository.IsConflict(err) {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusUnprocessableEntity,
+				page,
+				"Unprocessable Content",
+				err.Error(),
+			})
+
+		return
+	}
+
+	c.AbortWithStatusJSON(http.StatusInternalServerError,
+		APIErrorResponse{
+			PROJECT + " - Error",
+			http.StatusInternalServerError,
+			page,
+			"Internal Server Error",
+			err.Error(),
+		})
+}
+
+// dispatchNotFound - Reports a missing Entity with 404 and any other Storage Error with 500
+func dispatchNotFound(c *gin.Context, page string, err error) {
+	if !repository.IsNotFound(err) {
+		AbortWithStorageError(c, page, err)
+
+		return
+	}
+
+	c.JSON(http.StatusNotFound,
+		APIErrorResponse{
+			PROJECT + " - Error",
+			http.StatusNotFound,
+			page,
+			"Not Found",
+			err.Error(),
+		})
+}
+
+func dispatchUnprocessable(c *gin.Context, page string, desc string) {
+	c.JSON(http.StatusUnprocessableEntity,
+		APIErrorResponse{
+			PROJECT + " - Error",
+			http.StatusUnprocessableEntity,
+			page,
+			"Unprocessable Content",
+			desc,
+		})
+}
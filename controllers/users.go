@@ -4,47 +4,34 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 
 	"gin-blog/config"
 	"gin-blog/model"
+	"gin-blog/repository"
 )
 
-func MigrateUsers(db *gorm.DB) error {
-
-	if DATABASE == nil {
-		DATABASE = db
-	}
-
-	// Automigrate the User model
-	err := db.AutoMigrate(&model.User{})
-
-	if err != nil {
-		fmt.Println("Model 'User': Auto Migration failed")
-	}
-
-	return err
-}
-
-func RegisterUserRoutes(engine *gin.Engine, config *config.AppConfig) {
+func RegisterUserRoutes(engine *gin.Engine, config *config.AppConfig, handler *Handler) {
 
 	if PROJECT == "" {
 		// Copy the Project Name
 		PROJECT = config.Project
 	}
 
+	// Copy the Handler Settings
+	handler.configure(config)
+
 	// User Routes
-	engine.GET(config.WebRoot+"users", AuthorizeRequest(), DisplayUsers)
-	engine.GET(config.WebRoot+"users/:id", AuthorizeRequest(), DisplayUser)
-	engine.POST(config.WebRoot+"users", AuthorizeRequest(), CreateUser)
-	engine.PUT(config.WebRoot+"users/:id", AuthorizeRequest(), UpdateUser)
-	engine.DELETE(config.WebRoot+"users/:id", AuthorizeRequest(), DeleteUser)
+	engine.GET(config.WebRoot+"users", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionReadUsers), handler.DisplayUsers)
+	engine.GET(config.WebRoot+"users/:id", handler.AuthorizeRequest(), handler.DisplayUser)
+	engine.GET(config.WebRoot+"users/by-slug/:slug", handler.DisplayUserBySlug)
+	engine.POST(config.WebRoot+"users", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionManageUsers), handler.CreateUser)
+	engine.PUT(config.WebRoot+"users/:id", handler.AuthorizeRequest(), handler.UpdateUser)
+	engine.DELETE(config.WebRoot+"users/:id", handler.AuthorizeRequest(), handler.RequirePermission(model.PermissionManageUsers), handler.DeleteUser)
 }
 
-func DisplayUser(c *gin.Context) {
+func (handler *Handler) DisplayUser(c *gin.Context) {
 	var user *model.User
 	var userId uint64
 	var err error
@@ -77,7 +64,13 @@ func DisplayUser(c *gin.Context) {
 
 	fmt.Printf("Controller 'Users': User ID 1: %#v\n", userId)
 
-	if user, err = GetUserByID(uint(userId)); user == nil || err != nil {
+	if authUser := admin.(*model.User); authUser.ID != uint(userId) && !authUser.Can(model.PermissionReadUsers) {
+		AbortForbidden(c, fmt.Sprintf("User (ID: '%d'): Only the own Account can be viewed!", authUser.ID))
+
+		return
+	}
+
+	if user, err = handler.Users.GetByID(uint(userId)); user == nil || err != nil {
 
 		fmt.Printf("Controller 'Users': User (ID '%d'): %#v; Error: %#v\n", userId, user, err)
 
@@ -99,11 +92,48 @@ func DisplayUser(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, *user)
+	dispatchView(c, "users", NewUserView(admin.(*model.User), user))
+}
+
+// DisplayUserBySlug - Displays the public Author Profile of the User
+func (handler *Handler) DisplayUserBySlug(c *gin.Context) {
+	userSlug := c.Params.ByName("slug")
+
+	user, redirected, err := handler.findUserBySlug(userSlug)
+
+	if user == nil || err != nil {
+		fmt.Printf("Controller 'Users': User (Slug '%s'): %#v; Error: %#v\n", userSlug, user, err)
+
+		if err != nil && !repository.IsNotFound(err) {
+			AbortWithStorageError(c, "users", err)
+
+			return
+		}
+
+		c.JSON(http.StatusNotFound,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusNotFound,
+				"users",
+				"Not Found",
+				fmt.Sprintf("User (Slug: '%s'): User does not exist", userSlug),
+			})
+
+		return
+	}
+
+	if redirected {
+		dispatchSlugRedirect(c, user.Slug)
+
+		return
+	}
+
+	dispatchView(c, "users", model.NewDisplayedAuthor(user))
 }
 
-func DisplayUsers(c *gin.Context) {
+func (handler *Handler) DisplayUsers(c *gin.Context) {
 	var users []model.User
+	var err error
 
 	admin, ok := c.Get("AuthUser")
 
@@ -114,13 +144,25 @@ func DisplayUsers(c *gin.Context) {
 		return
 	}
 
-	DATABASE.Model(&model.User{}).Select("id, name, login, email").Find(&users)
+	if users, err = handler.Users.List(); err != nil {
+		AbortWithStorageError(c, "users", err)
 
-	c.JSON(http.StatusOK, users)
+		return
+	}
+
+	views := make([]interface{}, 0, len(users))
+
+	for idx := range users {
+		views = append(views, NewUserView(admin.(*model.User), &users[idx]))
+	}
+
+	dispatchView(c, "users", views)
 }
 
-func CreateUser(c *gin.Context) {
+func (handler *Handler) CreateUser(c *gin.Context) {
 	var user model.User
+	var data model.UserData
+	var err error
 
 	admin, ok := c.Get("AuthUser")
 
@@ -131,24 +173,52 @@ func CreateUser(c *gin.Context) {
 		return
 	}
 
-	c.BindJSON(&user)
+	c.BindJSON(&data)
+
+	user = data.NewUser()
+
+	if user.Role == "" {
+		user.Role = model.DEFAULTROLE
+	}
+
+	if !model.IsValidRole(user.Role) {
+		c.JSON(http.StatusUnprocessableEntity,
+			APIErrorResponse{
+				PROJECT + " - Error",
+				http.StatusUnprocessableEntity,
+				"users",
+				"Unprocessable Content",
+				fmt.Sprintf("User Role: Role '%s' is invalid!", user.Role),
+			})
 
-	if user.Slug == "" {
-		user.Slug = user.Name
+		return
 	}
 
-	if !strings.HasPrefix(user.Password, "*") {
-		user.Password = model.EncryptPassword(user.Password, model.ENCRYPTIONSALT)
+	if user.Slug, ok = resolveSlug(c, "users", user.Slug, user.Name, "user", 0, handler.slugCheck(model.RedirectUser, handler.Users.IsSlugTaken)); !ok {
+		return
+	}
+
+	// The Password is hashed even when it looks like a Hash
+	if user.Password != "" {
+		if user.Password, err = model.HashPassword(user.Password); err != nil {
+			AbortWithStorageError(c, "users", err)
+
+			return
+		}
 	}
 
-	DATABASE.Create(&user)
+	if err = handler.Users.Create(&user); err != nil {
+		AbortWithStorageError(c, "users", err)
+
+		return
+	}
 
-	c.JSON(http.StatusOK, user)
+	dispatchView(c, "users", NewUserView(admin.(*model.User), &user))
 }
 
-func UpdateUser(c *gin.Context) {
+func (handler *Handler) UpdateUser(c *gin.Context) {
 	var user *model.User
-	var updated model.User
+	var data model.UserData
 	var userId uint64
 	var err error
 
@@ -180,9 +250,40 @@ func UpdateUser(c *gin.Context) {
 
 	fmt.Printf("Controller 'Users': User ID 1: %#v\n", userId)
 
-	c.BindJSON(&updated)
+	c.BindJSON(&data)
+
+	updated := data.NewUser()
+
+	authUser := admin.(*model.User)
+
+	if authUser.ID != uint(userId) && !authUser.Can(model.PermissionManageUsers) {
+		AbortForbidden(c, fmt.Sprintf("User (ID: '%d'): Only the own Account can be modified!", authUser.ID))
+
+		return
+	}
+
+	if updated.Role != "" {
+		if !authUser.Can(model.PermissionManageUsers) {
+			AbortForbidden(c, fmt.Sprintf("User (ID: '%d'): Roles can only be assigned by Administrators!", authUser.ID))
+
+			return
+		}
+
+		if !model.IsValidRole(updated.Role) {
+			c.JSON(http.StatusUnprocessableEntity,
+				APIErrorResponse{
+					PROJECT + " - Error",
+					http.StatusUnprocessableEntity,
+					"users",
+					"Unprocessable Content",
+					fmt.Sprintf("User Role: Role '%s' is invalid!", updated.Role),
+				})
+
+			return
+		}
+	}
 
-	if user, err = GetUserByID(uint(userId)); user == nil || err != nil {
+	if user, err = handler.Users.GetByID(uint(userId)); user == nil || err != nil {
 		fmt.Printf("Controller 'Users': User (ID '%d'): %#v; Error: %#v\n", userId, user, err)
 
 		desc := "User (ID: '" + userIdString + "'): User does not exist"
@@ -203,14 +304,36 @@ func UpdateUser(c *gin.Context) {
 		return
 	}
 
-	user.Update(&updated)
+	if updated.Slug != "" && updated.Slug != user.Slug {
+		if updated.Slug, ok = resolveSlug(c, "users", updated.Slug, "", "user", user.ID, handler.slugCheck(model.RedirectUser, handler.Users.IsSlugTaken)); !ok {
+			return
+		}
+	}
+
+	formerSlug := user.Slug
+
+	if err = user.Update(&updated); err != nil {
+		AbortWithStorageError(c, "users", err)
+
+		return
+	}
 
-	DATABASE.Save(&user)
+	if err = handler.Users.Save(user); err != nil {
+		AbortWithStorageError(c, "users", err)
+
+		return
+	}
+
+	if err = handler.recordSlugChange(model.RedirectUser, user.ID, formerSlug, user.Slug); err != nil {
+		AbortWithStorageError(c, "users", err)
+
+		return
+	}
 
-	c.JSON(http.StatusOK, user)
+	dispatchView(c, "users", NewUserView(authUser, user))
 }
 
-func DeleteUser(c *gin.Context) {
+func (handler *Handler) DeleteUser(c *gin.Context) {
 	var user *model.User
 	var userId uint64
 	var message string
@@ -244,14 +367,24 @@ func DeleteUser(c *gin.Context) {
 
 	fmt.Printf("Controller 'Users': User ID 1: %#v\n", userId)
 
-	if user, err = GetUserByID(uint(userId)); user == nil || err != nil {
+	if authUser := admin.(*model.User); authUser.ID == uint(userId) {
+		AbortForbidden(c, fmt.Sprintf("User (ID: '%d'): The own Account cannot be deleted!", authUser.ID))
+
+		return
+	}
+
+	if user, err = handler.Users.GetByID(uint(userId)); user == nil || err != nil {
 		fmt.Printf("Controller 'Users': User (ID '%d'): %#v; Error: %#v\n", userId, user, err)
 
 		message = fmt.Sprintf("User (ID: '%d'): User does not exist", userId)
 	}
 
 	if user != nil {
-		DATABASE.Delete(&user, user.ID)
+		if err = handler.Users.Delete(user); err != nil {
+			AbortWithStorageError(c, "users", err)
+
+			return
+		}
 
 		message = fmt.Sprintf("User (ID: '%d'): User was deleted", user.ID)
 	}
@@ -266,50 +399,3 @@ func DeleteUser(c *gin.Context) {
 		},
 	)
 }
-
-func GetUserByID(userID uint) (*model.User, error) {
-	var match *model.User
-	var err error
-
-	userRes := GetUsersByIDs([]uint{userID})
-
-	if userRes == nil {
-		return nil, fmt.Errorf("User (ID: '%d'): User does not exist!", userID)
-	}
-
-	fmt.Printf("Controller 'Users': GetUserByID(%d) (Count: '%d'): %#v\n", userID, len(*userRes), *userRes)
-
-	if len(*userRes) != 0 {
-		match = &(*userRes)[0]
-	} else {
-		err = fmt.Errorf("User (ID: '%d'): User does not exist!", userID)
-	}
-
-	return match, err
-}
-
-func GetUsersByIDs(userIDs []uint) *[]model.User {
-	var users []model.User
-
-	DATABASE.Find(&users, userIDs)
-
-	return &users
-}
-
-func GetUserByLogin(userLogin string) (*model.User, error) {
-	var users []model.User
-	var match *model.User
-	var err error
-
-	DATABASE.Find(&users, "login = ?", userLogin)
-
-	fmt.Printf("Controller 'Users': GetUserByLogin(%s) (Count: '%d'): %#v\n", userLogin, len(users), users)
-
-	if len(users) != 0 {
-		match = &users[0]
-	} else {
-		err = fmt.Errorf("User (Login: '%s'): User does not exist", userLogin)
-	}
-
-	return match, err
-}
@@ -10,17 +10,29 @@ replace gin-blog/config => ./config
 
 replace gin-blog/controllers => ./controllers
 
+replace gin-blog/migrations => ./migrations
+
 replace gin-blog/model => ./model
 
+replace gin-blog/repository => ./repository
+
 require (
 	github.com/client9/misspell v0.3.4 // indirect
 	github.com/gin-gonic/gin v1.10.0
+	github.com/go-sql-driver/mysql v1.7.0
 	github.com/golang-jwt/jwt/v5 v5.2.1
+	github.com/jackc/pgx/v5 v5.4.3
 	github.com/sergi/go-diff v1.1.0 // indirect
+	github.com/yuin/goldmark v1.6.0
+	golang.org/x/crypto v0.23.0
 	golang.org/x/exp v0.0.0-20220722155223-a9213eeb770e // indirect
+	golang.org/x/net v0.25.0
+	golang.org/x/text v0.15.0
 	golang.org/x/tools/gopls v0.15.3 // indirect
 	gopkg.in/errgo.v2 v2.1.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1
+	gorm.io/driver/mysql v1.5.7
 	gorm.io/driver/postgres v1.5.7
+	gorm.io/driver/sqlite v1.5.5
 	gorm.io/gorm v1.25.10
 )
@@ -10,7 +10,7 @@ import (
 func main() {
 	var code int = 0
 
-	err := app.Start()
+	err := app.Run(os.Args[1:])
 
 	if err != nil {
 		fmt.Printf("Application failed! %v", err)
@@ -8,7 +8,6 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 
 	"gin-blog/app"
 	"gin-blog/config"
@@ -17,24 +16,21 @@ import (
 
 func TestHomePage(t *testing.T) {
 	var appConfig config.AppConfig
-	var db *gorm.DB
 	var err error
 
 	gin.SetMode(gin.TestMode)
 
 	if appConfig, err = config.ReadConfigFile(); err != nil {
-		t.Fatalf("Application Configuration: Configuration is missing! Message: %#v", err)
-	}
-
-	if db, err = app.ConnectDatabase(&appConfig); err != nil {
-		t.Fatalf("Database Connection: Connection failed! Message: %#v", err)
-	}
+		t.Logf("Application Configuration: Configuration is missing! Using Test Configuration. Message: %#v", err)
 
-	if err = app.InitializeDatabase(db); err != nil {
-		t.Fatalf("Database Setup: Setup failed! Message: %#v", err)
+		appConfig = config.AppConfig{
+			Project:     "Gin Blog",
+			Description: "Web Blog API with GoLang",
+			WebRoot:     "/",
+		}
 	}
 
-	router := app.RegisterRoutes(&appConfig)
+	router := app.RegisterRoutes(&appConfig, app.NewMemoryHandler())
 
 	//-------------------------------------
 	// Test Home Page
@@ -0,0 +1,819 @@
+package migrations
+
+// MIGRATIONS - Versioned Schema Changes in the Order of their Application
+// Applied Migrations must never be changed. Schema Changes are added as new Migration
+// with the next Version Number and a Down Migration which reverts them.
+// The first Migrations create the Tables only if they do not exist, so Databases
+// which were set up with the former Auto Migration can adopt the versioned Schema.
+var MIGRATIONS []Migration = []Migration{
+	{
+		Version: 1,
+		Name:    "create_users",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS users (
+				id bigserial PRIMARY KEY,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				name text,
+				slug text,
+				login text,
+				email text,
+				password text
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS users`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS users (
+						id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+						created_at datetime(3) NULL,
+						updated_at datetime(3) NULL,
+						deleted_at datetime(3) NULL,
+						name varchar(255),
+						slug varchar(255),
+						login varchar(255),
+						email varchar(255),
+						password varchar(255),
+						INDEX idx_users_deleted_at (deleted_at)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS users`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS users (
+						id integer PRIMARY KEY AUTOINCREMENT,
+						created_at datetime,
+						updated_at datetime,
+						deleted_at datetime,
+						name text,
+						slug text,
+						login text,
+						email text,
+						password text
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS users`,
+				},
+			},
+		},
+	},
+	{
+		Version: 2,
+		Name:    "create_articles",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS articles (
+				id bigserial PRIMARY KEY,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				user_id bigint,
+				title text,
+				slug text,
+				content text,
+				CONSTRAINT fk_users_articles FOREIGN KEY (user_id) REFERENCES users (id)
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_articles_deleted_at ON articles (deleted_at)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS articles`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS articles (
+						id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+						created_at datetime(3) NULL,
+						updated_at datetime(3) NULL,
+						deleted_at datetime(3) NULL,
+						user_id bigint unsigned,
+						title text,
+						slug varchar(255),
+						content longtext,
+						INDEX idx_articles_deleted_at (deleted_at),
+						CONSTRAINT fk_users_articles FOREIGN KEY (user_id) REFERENCES users (id)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS articles`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS articles (
+						id integer PRIMARY KEY AUTOINCREMENT,
+						created_at datetime,
+						updated_at datetime,
+						deleted_at datetime,
+						user_id integer,
+						title text,
+						slug text,
+						content text,
+						CONSTRAINT fk_users_articles FOREIGN KEY (user_id) REFERENCES users (id)
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_articles_deleted_at ON articles (deleted_at)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS articles`,
+				},
+			},
+		},
+	},
+	{
+		Version: 3,
+		Name:    "add_users_role",
+		Up: []string{
+			`ALTER TABLE users ADD COLUMN IF NOT EXISTS role varchar(20) NOT NULL DEFAULT 'reader'`,
+		},
+		Down: []string{
+			`ALTER TABLE users DROP COLUMN IF EXISTS role`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					`ALTER TABLE users ADD COLUMN role varchar(20) NOT NULL DEFAULT 'reader'`,
+				},
+				Down: []string{
+					`ALTER TABLE users DROP COLUMN role`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					`ALTER TABLE users ADD COLUMN role varchar(20) NOT NULL DEFAULT 'reader'`,
+				},
+				Down: []string{
+					`ALTER TABLE users DROP COLUMN role`,
+				},
+			},
+		},
+	},
+	{
+		Version: 4,
+		Name:    "create_sessions",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS sessions (
+				id bigserial PRIMARY KEY,
+				created_at timestamptz,
+				updated_at timestamptz,
+				deleted_at timestamptz,
+				user_id bigint,
+				refresh_hash text,
+				expires_at timestamptz,
+				revoked_at timestamptz
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_sessions_deleted_at ON sessions (deleted_at)`,
+			`CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_sessions_refresh_hash ON sessions (refresh_hash)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS sessions`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS sessions (
+						id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+						created_at datetime(3) NULL,
+						updated_at datetime(3) NULL,
+						deleted_at datetime(3) NULL,
+						user_id bigint unsigned,
+						refresh_hash varchar(255),
+						expires_at datetime(3) NULL,
+						revoked_at datetime(3) NULL,
+						INDEX idx_sessions_deleted_at (deleted_at),
+						INDEX idx_sessions_user_id (user_id),
+						UNIQUE INDEX idx_sessions_refresh_hash (refresh_hash)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS sessions`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS sessions (
+						id integer PRIMARY KEY AUTOINCREMENT,
+						created_at datetime,
+						updated_at datetime,
+						deleted_at datetime,
+						user_id integer,
+						refresh_hash text,
+						expires_at datetime,
+						revoked_at datetime
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_sessions_deleted_at ON sessions (deleted_at)`,
+					`CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id)`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS idx_sessions_refresh_hash ON sessions (refresh_hash)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS sessions`,
+				},
+			},
+		},
+	},
+	{
+		Version: 5,
+		Name:    "add_unique_slugs",
+		Up: []string{
+			// Duplicate and empty Slugs of existing Rows are suffixed with the Row ID
+			`UPDATE users SET slug = COALESCE(NULLIF(slug, ''), 'user') || '-' || id
+				WHERE slug IS NULL OR slug = '' OR EXISTS (SELECT 1 FROM users other WHERE other.slug = users.slug AND other.id < users.id)`,
+			`UPDATE articles SET slug = COALESCE(NULLIF(slug, ''), 'article') || '-' || id
+				WHERE slug IS NULL OR slug = '' OR EXISTS (SELECT 1 FROM articles other WHERE other.slug = articles.slug AND other.id < articles.id)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_users_slug ON users (slug)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_slug ON articles (slug)`,
+		},
+		Down: []string{
+			`DROP INDEX IF EXISTS idx_articles_slug`,
+			`DROP INDEX IF EXISTS idx_users_slug`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					// MySQL cannot select from the updated Table in a Subquery unless it is derived
+					`UPDATE users SET slug = CONCAT(COALESCE(NULLIF(slug, ''), 'user'), '-', id)
+						WHERE id IN (SELECT id FROM (SELECT duplicate.id FROM users duplicate WHERE duplicate.slug IS NULL OR duplicate.slug = ''
+							OR EXISTS (SELECT 1 FROM users other WHERE other.slug = duplicate.slug AND other.id < duplicate.id)) AS duplicates)`,
+					`UPDATE articles SET slug = CONCAT(COALESCE(NULLIF(slug, ''), 'article'), '-', id)
+						WHERE id IN (SELECT id FROM (SELECT duplicate.id FROM articles duplicate WHERE duplicate.slug IS NULL OR duplicate.slug = ''
+							OR EXISTS (SELECT 1 FROM articles other WHERE other.slug = duplicate.slug AND other.id < duplicate.id)) AS duplicates)`,
+					`CREATE UNIQUE INDEX idx_users_slug ON users (slug)`,
+					`CREATE UNIQUE INDEX idx_articles_slug ON articles (slug)`,
+				},
+				Down: []string{
+					`DROP INDEX idx_articles_slug ON articles`,
+					`DROP INDEX idx_users_slug ON users`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					`UPDATE users SET slug = COALESCE(NULLIF(slug, ''), 'user') || '-' || id
+						WHERE slug IS NULL OR slug = '' OR EXISTS (SELECT 1 FROM users other WHERE other.slug = users.slug AND other.id < users.id)`,
+					`UPDATE articles SET slug = COALESCE(NULLIF(slug, ''), 'article') || '-' || id
+						WHERE slug IS NULL OR slug = '' OR EXISTS (SELECT 1 FROM articles other WHERE other.slug = articles.slug AND other.id < articles.id)`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS idx_users_slug ON users (slug)`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS idx_articles_slug ON articles (slug)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_articles_slug`,
+					`DROP INDEX IF EXISTS idx_users_slug`,
+				},
+			},
+		},
+	},
+	{
+		Version: 6,
+		Name:    "create_slug_redirects",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS slug_redirects (
+				id bigserial PRIMARY KEY,
+				created_at timestamptz,
+				entity varchar(20),
+				slug text,
+				target_id bigint
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_slug_redirects_entity_slug ON slug_redirects (entity, slug)`,
+			`CREATE INDEX IF NOT EXISTS idx_slug_redirects_target_id ON slug_redirects (target_id)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS slug_redirects`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS slug_redirects (
+						id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+						created_at datetime(3) NULL,
+						entity varchar(20),
+						slug varchar(255),
+						target_id bigint unsigned,
+						UNIQUE INDEX idx_slug_redirects_entity_slug (entity, slug),
+						INDEX idx_slug_redirects_target_id (target_id)
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS slug_redirects`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS slug_redirects (
+						id integer PRIMARY KEY AUTOINCREMENT,
+						created_at datetime,
+						entity varchar(20),
+						slug text,
+						target_id integer
+					)`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS idx_slug_redirects_entity_slug ON slug_redirects (entity, slug)`,
+					`CREATE INDEX IF NOT EXISTS idx_slug_redirects_target_id ON slug_redirects (target_id)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS slug_redirects`,
+				},
+			},
+		},
+	},
+	{
+		Version: 7,
+		Name:    "add_articles_status",
+		Up: []string{
+			`ALTER TABLE articles ADD COLUMN IF NOT EXISTS status varchar(20) NOT NULL DEFAULT 'draft'`,
+			`ALTER TABLE articles ADD COLUMN IF NOT EXISTS published_at timestamptz`,
+			// Existing Articles were public and stay published
+			`UPDATE articles SET status = 'published', published_at = created_at WHERE published_at IS NULL`,
+			`CREATE INDEX IF NOT EXISTS idx_articles_status ON articles (status)`,
+			`CREATE INDEX IF NOT EXISTS idx_articles_published_at ON articles (published_at)`,
+		},
+		Down: []string{
+			`DROP INDEX IF EXISTS idx_articles_published_at`,
+			`DROP INDEX IF EXISTS idx_articles_status`,
+			`ALTER TABLE articles DROP COLUMN IF EXISTS published_at`,
+			`ALTER TABLE articles DROP COLUMN IF EXISTS status`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					`ALTER TABLE articles ADD COLUMN status varchar(20) NOT NULL DEFAULT 'draft', ADD COLUMN published_at datetime(3) NULL`,
+					`UPDATE articles SET status = 'published', published_at = created_at WHERE published_at IS NULL`,
+					`CREATE INDEX idx_articles_status ON articles (status)`,
+					`CREATE INDEX idx_articles_published_at ON articles (published_at)`,
+				},
+				Down: []string{
+					`DROP INDEX idx_articles_published_at ON articles`,
+					`DROP INDEX idx_articles_status ON articles`,
+					`ALTER TABLE articles DROP COLUMN published_at, DROP COLUMN status`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					`ALTER TABLE articles ADD COLUMN status varchar(20) NOT NULL DEFAULT 'draft'`,
+					`ALTER TABLE articles ADD COLUMN published_at datetime`,
+					`UPDATE articles SET status = 'published', published_at = created_at WHERE published_at IS NULL`,
+					`CREATE INDEX IF NOT EXISTS idx_articles_status ON articles (status)`,
+					`CREATE INDEX IF NOT EXISTS idx_articles_published_at ON articles (published_at)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_articles_published_at`,
+					`DROP INDEX IF EXISTS idx_articles_status`,
+					`ALTER TABLE articles DROP COLUMN published_at`,
+					`ALTER TABLE articles DROP COLUMN status`,
+				},
+			},
+		},
+	},
+	{
+		Version: 8,
+		Name:    "create_article_revisions",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS article_revisions (
+				id bigserial PRIMARY KEY,
+				created_at timestamptz,
+				article_id bigint,
+				number bigint,
+				user_id bigint,
+				title text,
+				slug text,
+				content text,
+				status varchar(20),
+				restored_from bigint NOT NULL DEFAULT 0,
+				CONSTRAINT fk_articles_revisions FOREIGN KEY (article_id) REFERENCES articles(id)
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_article_revisions_article_number ON article_revisions (article_id, number)`,
+			// Existing Articles start their History with their current Fields
+			`INSERT INTO article_revisions (created_at, article_id, number, user_id, title, slug, content, status)
+				SELECT updated_at, id, 1, user_id, title, slug, content, status FROM articles`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS article_revisions`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS article_revisions (
+						id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+						created_at datetime(3) NULL,
+						article_id bigint unsigned,
+						number bigint,
+						user_id bigint unsigned,
+						title text,
+						slug varchar(255),
+						content longtext,
+						status varchar(20),
+						restored_from bigint NOT NULL DEFAULT 0,
+						UNIQUE INDEX idx_article_revisions_article_number (article_id, number),
+						CONSTRAINT fk_articles_revisions FOREIGN KEY (article_id) REFERENCES articles(id)
+					)`,
+					`INSERT INTO article_revisions (created_at, article_id, number, user_id, title, slug, content, status)
+						SELECT updated_at, id, 1, user_id, title, slug, content, status FROM articles`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS article_revisions`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS article_revisions (
+						id integer PRIMARY KEY AUTOINCREMENT,
+						created_at datetime,
+						article_id integer,
+						number integer,
+						user_id integer,
+						title text,
+						slug text,
+						content text,
+						status varchar(20),
+						restored_from integer NOT NULL DEFAULT 0,
+						CONSTRAINT fk_articles_revisions FOREIGN KEY (article_id) REFERENCES articles(id)
+					)`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS idx_article_revisions_article_number ON article_revisions (article_id, number)`,
+					`INSERT INTO article_revisions (created_at, article_id, number, user_id, title, slug, content, status)
+						SELECT updated_at, id, 1, user_id, title, slug, content, status FROM articles`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS article_revisions`,
+				},
+			},
+		},
+	},
+	{
+		Version: 9,
+		Name:    "create_tags_categories",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS tags (
+				id bigserial PRIMARY KEY,
+				created_at timestamptz,
+				updated_at timestamptz,
+				name text,
+				slug text
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_slug ON tags (slug)`,
+			`CREATE TABLE IF NOT EXISTS article_tags (
+				article_id bigint NOT NULL,
+				tag_id bigint NOT NULL,
+				PRIMARY KEY (article_id, tag_id),
+				CONSTRAINT fk_article_tags_article FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+				CONSTRAINT fk_article_tags_tag FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_article_tags_tag_id ON article_tags (tag_id)`,
+			`CREATE TABLE IF NOT EXISTS categories (
+				id bigserial PRIMARY KEY,
+				created_at timestamptz,
+				updated_at timestamptz,
+				parent_id bigint,
+				name text,
+				slug text,
+				description text,
+				CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories(id)
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_slug ON categories (slug)`,
+			`CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id)`,
+			// Deleted Articles release their Category
+			`ALTER TABLE articles ADD COLUMN IF NOT EXISTS category_id bigint
+				CONSTRAINT fk_articles_category REFERENCES categories(id) ON DELETE SET NULL`,
+			`CREATE INDEX IF NOT EXISTS idx_articles_category_id ON articles (category_id)`,
+		},
+		Down: []string{
+			`DROP INDEX IF EXISTS idx_articles_category_id`,
+			`ALTER TABLE articles DROP COLUMN IF EXISTS category_id`,
+			`DROP TABLE IF EXISTS article_tags`,
+			`DROP TABLE IF EXISTS tags`,
+			`DROP TABLE IF EXISTS categories`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS tags (
+						id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+						created_at datetime(3) NULL,
+						updated_at datetime(3) NULL,
+						name varchar(255),
+						slug varchar(255),
+						UNIQUE INDEX idx_tags_slug (slug)
+					)`,
+					`CREATE TABLE IF NOT EXISTS article_tags (
+						article_id bigint unsigned NOT NULL,
+						tag_id bigint unsigned NOT NULL,
+						PRIMARY KEY (article_id, tag_id),
+						INDEX idx_article_tags_tag_id (tag_id),
+						CONSTRAINT fk_article_tags_article FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+						CONSTRAINT fk_article_tags_tag FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+					)`,
+					`CREATE TABLE IF NOT EXISTS categories (
+						id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+						created_at datetime(3) NULL,
+						updated_at datetime(3) NULL,
+						parent_id bigint unsigned,
+						name varchar(255),
+						slug varchar(255),
+						description text,
+						UNIQUE INDEX idx_categories_slug (slug),
+						INDEX idx_categories_parent_id (parent_id),
+						CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories(id)
+					)`,
+					`ALTER TABLE articles ADD COLUMN category_id bigint unsigned NULL,
+						ADD INDEX idx_articles_category_id (category_id),
+						ADD CONSTRAINT fk_articles_category FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE SET NULL`,
+				},
+				Down: []string{
+					`ALTER TABLE articles DROP FOREIGN KEY fk_articles_category`,
+					`ALTER TABLE articles DROP INDEX idx_articles_category_id, DROP COLUMN category_id`,
+					`DROP TABLE IF EXISTS article_tags`,
+					`DROP TABLE IF EXISTS tags`,
+					`DROP TABLE IF EXISTS categories`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS tags (
+						id integer PRIMARY KEY AUTOINCREMENT,
+						created_at datetime,
+						updated_at datetime,
+						name text,
+						slug text
+					)`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_slug ON tags (slug)`,
+					`CREATE TABLE IF NOT EXISTS article_tags (
+						article_id integer NOT NULL,
+						tag_id integer NOT NULL,
+						PRIMARY KEY (article_id, tag_id),
+						CONSTRAINT fk_article_tags_article FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+						CONSTRAINT fk_article_tags_tag FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_article_tags_tag_id ON article_tags (tag_id)`,
+					`CREATE TABLE IF NOT EXISTS categories (
+						id integer PRIMARY KEY AUTOINCREMENT,
+						created_at datetime,
+						updated_at datetime,
+						parent_id integer,
+						name text,
+						slug text,
+						description text,
+						CONSTRAINT fk_categories_parent FOREIGN KEY (parent_id) REFERENCES categories(id)
+					)`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_slug ON categories (slug)`,
+					`CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id)`,
+					`ALTER TABLE articles ADD COLUMN category_id integer
+						CONSTRAINT fk_articles_category REFERENCES categories(id) ON DELETE SET NULL`,
+					`CREATE INDEX IF NOT EXISTS idx_articles_category_id ON articles (category_id)`,
+				},
+				Down: []string{
+					`DROP INDEX IF EXISTS idx_articles_category_id`,
+					`ALTER TABLE articles DROP COLUMN category_id`,
+					`DROP TABLE IF EXISTS article_tags`,
+					`DROP TABLE IF EXISTS tags`,
+					`DROP TABLE IF EXISTS categories`,
+				},
+			},
+		},
+	},
+	{
+		Version: 10,
+		Name:    "create_comments",
+		Up: []string{
+			`CREATE TABLE IF NOT EXISTS comments (
+				id bigserial PRIMARY KEY,
+				created_at timestamptz,
+				updated_at timestamptz,
+				article_id bigint NOT NULL,
+				parent_id bigint,
+				user_id bigint,
+				author_name text,
+				author_email text,
+				content text,
+				status varchar(20) NOT NULL DEFAULT 'pending',
+				CONSTRAINT fk_articles_comments FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+				CONSTRAINT fk_comments_replies FOREIGN KEY (parent_id) REFERENCES comments(id) ON DELETE CASCADE,
+				CONSTRAINT fk_users_comments FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS idx_comments_article_id ON comments (article_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON comments (parent_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_comments_user_id ON comments (user_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_comments_status ON comments (status)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS comments`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS comments (
+						id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+						created_at datetime(3) NULL,
+						updated_at datetime(3) NULL,
+						article_id bigint unsigned NOT NULL,
+						parent_id bigint unsigned,
+						user_id bigint unsigned,
+						author_name varchar(255),
+						author_email varchar(255),
+						content text,
+						status varchar(20) NOT NULL DEFAULT 'pending',
+						INDEX idx_comments_article_id (article_id),
+						INDEX idx_comments_parent_id (parent_id),
+						INDEX idx_comments_user_id (user_id),
+						INDEX idx_comments_status (status),
+						CONSTRAINT fk_articles_comments FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+						CONSTRAINT fk_comments_replies FOREIGN KEY (parent_id) REFERENCES comments(id) ON DELETE CASCADE,
+						CONSTRAINT fk_users_comments FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS comments`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS comments (
+						id integer PRIMARY KEY AUTOINCREMENT,
+						created_at datetime,
+						updated_at datetime,
+						article_id integer NOT NULL,
+						parent_id integer,
+						user_id integer,
+						author_name text,
+						author_email text,
+						content text,
+						status varchar(20) NOT NULL DEFAULT 'pending',
+						CONSTRAINT fk_articles_comments FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE CASCADE,
+						CONSTRAINT fk_comments_replies FOREIGN KEY (parent_id) REFERENCES comments(id) ON DELETE CASCADE,
+						CONSTRAINT fk_users_comments FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_comments_article_id ON comments (article_id)`,
+					`CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON comments (parent_id)`,
+					`CREATE INDEX IF NOT EXISTS idx_comments_user_id ON comments (user_id)`,
+					`CREATE INDEX IF NOT EXISTS idx_comments_status ON comments (status)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS comments`,
+				},
+			},
+		},
+	},
+	{
+		Version: 11,
+		Name:    "add_article_format",
+		Up: []string{
+			// Existing Articles keep being shown as plain Text while new Articles default to Markdown
+			`ALTER TABLE articles ADD COLUMN IF NOT EXISTS format varchar(20) NOT NULL DEFAULT 'plain'`,
+			`ALTER TABLE articles ALTER COLUMN format SET DEFAULT 'markdown'`,
+			`ALTER TABLE article_revisions ADD COLUMN IF NOT EXISTS format varchar(20)`,
+			`UPDATE article_revisions SET format = 'plain' WHERE format IS NULL`,
+		},
+		Down: []string{
+			`ALTER TABLE article_revisions DROP COLUMN IF EXISTS format`,
+			`ALTER TABLE articles DROP COLUMN IF EXISTS format`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					`ALTER TABLE articles ADD COLUMN format varchar(20) NOT NULL DEFAULT 'plain'`,
+					`ALTER TABLE articles ALTER COLUMN format SET DEFAULT 'markdown'`,
+					`ALTER TABLE article_revisions ADD COLUMN format varchar(20)`,
+					`UPDATE article_revisions SET format = 'plain' WHERE format IS NULL`,
+				},
+				Down: []string{
+					`ALTER TABLE article_revisions DROP COLUMN format`,
+					`ALTER TABLE articles DROP COLUMN format`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					// SQLite cannot change the Default of a Column so the existing Articles are set to plain Text
+					`ALTER TABLE articles ADD COLUMN format varchar(20) NOT NULL DEFAULT 'markdown'`,
+					`UPDATE articles SET format = 'plain'`,
+					`ALTER TABLE article_revisions ADD COLUMN format varchar(20)`,
+					`UPDATE article_revisions SET format = 'plain' WHERE format IS NULL`,
+				},
+				Down: []string{
+					`ALTER TABLE article_revisions DROP COLUMN format`,
+					`ALTER TABLE articles DROP COLUMN format`,
+				},
+			},
+		},
+	},
+	{
+		Version: 12,
+		Name:    "add_article_search",
+		Up: []string{
+			// The Search Vectors are built at Startup with the configured Text Search Configuration
+			`ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_vector tsvector`,
+			`ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_language varchar(64)`,
+			`CREATE INDEX IF NOT EXISTS idx_articles_search_vector ON articles USING GIN (search_vector)`,
+		},
+		Down: []string{
+			`DROP INDEX IF EXISTS idx_articles_search_vector`,
+			`ALTER TABLE articles DROP COLUMN IF EXISTS search_language`,
+			`ALTER TABLE articles DROP COLUMN IF EXISTS search_vector`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					`ALTER TABLE articles ADD COLUMN search_language varchar(64)`,
+				},
+				Down: []string{
+					`ALTER TABLE articles DROP COLUMN search_language`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					// The Articles are searched without Search Vectors outside of PostgreSQL
+					`ALTER TABLE articles ADD COLUMN search_language varchar(64)`,
+				},
+				Down: []string{
+					`ALTER TABLE articles DROP COLUMN search_language`,
+				},
+			},
+		},
+	},
+	{
+		Version: 13,
+		Name:    "create_media",
+		Up: []string{
+			// Purged Articles remove their Media before, the Foreign Key only guards against dangling References
+			`CREATE TABLE IF NOT EXISTS media (
+				id bigserial PRIMARY KEY,
+				created_at timestamptz,
+				updated_at timestamptz,
+				user_id bigint NOT NULL,
+				article_id bigint,
+				file_name text,
+				content_type varchar(100),
+				size bigint NOT NULL DEFAULT 0,
+				width bigint NOT NULL DEFAULT 0,
+				height bigint NOT NULL DEFAULT 0,
+				storage_key text NOT NULL,
+				thumbnail_key text,
+				CONSTRAINT fk_users_media FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+				CONSTRAINT fk_articles_media FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE SET NULL
+			)`,
+			`CREATE UNIQUE INDEX IF NOT EXISTS idx_media_storage_key ON media (storage_key)`,
+			`CREATE INDEX IF NOT EXISTS idx_media_user_id ON media (user_id)`,
+			`CREATE INDEX IF NOT EXISTS idx_media_article_id ON media (article_id)`,
+		},
+		Down: []string{
+			`DROP TABLE IF EXISTS media`,
+		},
+		Dialects: map[string]Statements{
+			"mysql": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS media (
+						id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+						created_at datetime(3) NULL,
+						updated_at datetime(3) NULL,
+						user_id bigint unsigned NOT NULL,
+						article_id bigint unsigned,
+						file_name varchar(255),
+						content_type varchar(100),
+						size bigint NOT NULL DEFAULT 0,
+						width bigint NOT NULL DEFAULT 0,
+						height bigint NOT NULL DEFAULT 0,
+						storage_key varchar(255) NOT NULL,
+						thumbnail_key varchar(255),
+						UNIQUE INDEX idx_media_storage_key (storage_key),
+						INDEX idx_media_user_id (user_id),
+						INDEX idx_media_article_id (article_id),
+						CONSTRAINT fk_users_media FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+						CONSTRAINT fk_articles_media FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE SET NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS media`,
+				},
+			},
+			"sqlite": {
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS media (
+						id integer PRIMARY KEY AUTOINCREMENT,
+						created_at datetime,
+						updated_at datetime,
+						user_id integer NOT NULL,
+						article_id integer,
+						file_name text,
+						content_type varchar(100),
+						size integer NOT NULL DEFAULT 0,
+						width integer NOT NULL DEFAULT 0,
+						height integer NOT NULL DEFAULT 0,
+						storage_key text NOT NULL,
+						thumbnail_key text,
+						CONSTRAINT fk_users_media FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+						CONSTRAINT fk_articles_media FOREIGN KEY (article_id) REFERENCES articles(id) ON DELETE SET NULL
+					)`,
+					`CREATE UNIQUE INDEX IF NOT EXISTS idx_media_storage_key ON media (storage_key)`,
+					`CREATE INDEX IF NOT EXISTS idx_media_user_id ON media (user_id)`,
+					`CREATE INDEX IF NOT EXISTS idx_media_article_id ON media (article_id)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS media`,
+				},
+			},
+		},
+	},
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"testing"
+)
+
+func TestCheckMigrations(t *testing.T) {
+	//-------------------------------------
+	// Test the registered Migrations
+
+	if err := CheckMigrations(MIGRATIONS); err != nil {
+		t.Errorf("Migrations: Check failed! Message: %v", err)
+	}
+
+	//-------------------------------------
+	// Test valid and invalid Migrations
+
+	dialects := map[string]Statements{
+		"mysql":  {Up: []string{"SELECT 1"}, Down: []string{"SELECT 1"}},
+		"sqlite": {Up: []string{"SELECT 1"}, Down: []string{"SELECT 1"}},
+	}
+
+	valid := []Migration{
+		{Version: 1, Name: "first", Up: []string{"SELECT 1"}, Down: []string{"SELECT 1"}, Dialects: dialects},
+		{Version: 2, Name: "second", Up: []string{"SELECT 1"}, Down: []string{"SELECT 1"}, Dialects: dialects},
+	}
+
+	if err := CheckMigrations(valid); err != nil {
+		t.Errorf("Migrations: Check of valid Migrations failed! Message: %v", err)
+	}
+
+	invalid := map[string][]Migration{
+		"Version Order": {
+			{Version: 2, Name: "second", Up: []string{"SELECT 1"}, Down: []string{"SELECT 1"}, Dialects: dialects},
+			{Version: 1, Name: "first", Up: []string{"SELECT 1"}, Down: []string{"SELECT 1"}, Dialects: dialects},
+		},
+		"Duplicate Version": {
+			{Version: 1, Name: "first", Up: []string{"SELECT 1"}, Down: []string{"SELECT 1"}, Dialects: dialects},
+			{Version: 1, Name: "second", Up: []string{"SELECT 1"}, Down: []string{"SELECT 1"}, Dialects: dialects},
+		},
+		"Down missing": {
+			{Version: 1, Name: "first", Up: []string{"SELECT 1"}, Dialects: dialects},
+		},
+		"Dialect missing": {
+			{Version: 1, Name: "first", Up: []string{"SELECT 1"}, Down: []string{"SELECT 1"},
+				Dialects: map[string]Statements{"mysql": dialects["mysql"]}},
+		},
+		"Dialect Down missing": {
+			{Version: 1, Name: "first", Up: []string{"SELECT 1"}, Down: []string{"SELECT 1"},
+				Dialects: map[string]Statements{"mysql": dialects["mysql"], "sqlite": {Up: []string{"SELECT 1"}}}},
+		},
+	}
+
+	for name, migrations := range invalid {
+		if err := CheckMigrations(migrations); err == nil {
+			t.Errorf("Migrations '%s': Check succeeded; expected an Error", name)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package migrations
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//==========================================================================
+// Structure Migration Declaration
+
+// Migration - Numbered Schema Change with the SQL Statements to apply and to revert it
+// The Up and Down Statements are written for PostgreSQL. The other Dialects like
+// "sqlite" and "mysql" give their own Statements.
+type Migration struct {
+	Version  uint
+	Name     string
+	Up       []string
+	Down     []string
+	Dialects map[string]Statements
+}
+
+// Statements - SQL Statements of a Migration in another Dialect than PostgreSQL
+type Statements struct {
+	Up   []string
+	Down []string
+}
+
+// SchemaMigration - Record of an applied Migration in the 'schema_migrations' Table
+type SchemaMigration struct {
+	Version   uint      `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// MigrationStatus - Migration together with the Time when it was applied
+// AppliedAt is nil for pending Migrations
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+func (migration *Migration) String() string {
+	return fmt.Sprintf("%04d_%s", migration.Version, migration.Name)
+}
+
+// DIALECT_POSTGRES - Dialect of the Up and Down Statements of the Migrations
+const DIALECT_POSTGRES string = "postgres"
+
+// DIALECTS - Dialects which every Migration must give Statements for
+var DIALECTS []string = []string{"mysql", "sqlite"}
+
+// SCHEMATABLES - Statement creating the 'schema_migrations' Table per Dialect
+var SCHEMATABLES map[string]string = map[string]string{
+	DIALECT_POSTGRES: `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint PRIMARY KEY,
+		name text NOT NULL,
+		applied_at timestamptz NOT NULL
+	)`,
+	"mysql": `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version bigint PRIMARY KEY,
+		name varchar(255) NOT NULL,
+		applied_at datetime(3) NOT NULL
+	)`,
+	"sqlite": `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version integer PRIMARY KEY,
+		name text NOT NULL,
+		applied_at datetime NOT NULL
+	)`,
+}
+
+// Statements - Up and Down Statements of the Migration in the Dialect
+func (migration *Migration) Statements(dialect string) (Statements, error) {
+	if dialect == DIALECT_POSTGRES {
+		return Statements{migration.Up, migration.Down}, nil
+	}
+
+	statements, ok := migration.Dialects[dialect]
+
+	if !ok {
+		return statements, fmt.Errorf("Migration '%s': Statements for Dialect '%s' are missing!", migration.String(), dialect)
+	}
+
+	return statements, nil
+}
+
+//==========================================================================
+// Structure Migrator Declaration
+
+// Migrator - Applies and reverts the Migrations on a Database Connection
+// The Statements are chosen by the Dialect of the Connection.
+type Migrator struct {
+	db         *gorm.DB
+	dialect    string
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) *Migrator {
+	return &Migrator{db, db.Dialector.Name(), MIGRATIONS}
+}
+
+// CheckMigrations - Migrations must have ascending Version Numbers and a Down Migration
+func CheckMigrations(migrations []Migration) error {
+	var lastVersion uint
+
+	for _, migration := range migrations {
+		if migration.Version <= lastVersion {
+			return fmt.Errorf("Migration '%s': Version must be greater than '%d'!", migration.String(), lastVersion)
+		}
+
+		if migration.Name == "" || len(migration.Up) == 0 || len(migration.Down) == 0 {
+			return fmt.Errorf("Migration '%s': Name, Up or Down Statements are missing!", migration.String())
+		}
+
+		for _, dialect := range DIALECTS {
+			statements, err := migration.Statements(dialect)
+
+			if err != nil {
+				return err
+			}
+
+			if len(statements.Up) == 0 || len(statements.Down) == 0 {
+				return fmt.Errorf("Migration '%s': Up or Down Statements for Dialect '%s' are missing!", migration.String(), dialect)
+			}
+		}
+
+		lastVersion = migration.Version
+	}
+
+	return nil
+}
+
+// Migrate - Applies all pending Migrations and returns the applied Migrations
+// Each Migration runs in its own Transaction which also records it
+func (migrator *Migrator) Migrate() ([]Migration, error) {
+	var applied []Migration
+
+	pending, err := migrator.Pending()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, migration := range pending {
+		statements, err := migration.Statements(migrator.dialect)
+
+		if err != nil {
+			return applied, err
+		}
+
+		err = migrator.db.Transaction(func(tx *gorm.DB) error {
+			if err := execStatements(tx, statements.Up); err != nil {
+				return err
+			}
+
+			return tx.Create(&SchemaMigration{migration.Version, migration.Name, time.Now()}).Error
+		})
+
+		if err != nil {
+			return applied, fmt.Errorf("Migration '%s': Migration failed! Message: %v", migration.String(), err)
+		}
+
+		applied = append(applied, migration)
+	}
+
+	return applied, nil
+}
+
+// Rollback - Reverts the last applied Migrations and returns the reverted Migrations
+func (migrator *Migrator) Rollback(steps int) ([]Migration, error) {
+	var reverted []Migration
+
+	statuses, err := migrator.Status()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := len(statuses) - 1; idx >= 0 && len(reverted) < steps; idx-- {
+		migration := statuses[idx].Migration
+
+		if statuses[idx].AppliedAt == nil {
+			continue
+		}
+
+		statements, err := migration.Statements(migrator.dialect)
+
+		if err != nil {
+			return reverted, err
+		}
+
+		err = migrator.db.Transaction(func(tx *gorm.DB) error {
+			if err := execStatements(tx, statements.Down); err != nil {
+				return err
+			}
+
+			return tx.Delete(&SchemaMigration{}, migration.Version).Error
+		})
+
+		if err != nil {
+			return reverted, fmt.Errorf("Migration '%s': Rollback failed! Message: %v", migration.String(), err)
+		}
+
+		reverted = append(reverted, migration)
+	}
+
+	return reverted, nil
+}
+
+// Status - Lists all known Migrations with the Time of their Application
+func (migrator *Migrator) Status() ([]MigrationStatus, error) {
+	var records []SchemaMigration
+	var statuses []MigrationStatus
+
+	if err := CheckMigrations(migrator.migrations); err != nil {
+		return nil, err
+	}
+
+	if err := migrator.createTable(); err != nil {
+		return nil, err
+	}
+
+	if err := migrator.db.Order("version").Find(&records).Error; err != nil {
+		return nil, err
+	}
+
+	appliedMap := make(map[uint]*SchemaMigration, len(records))
+
+	for idx := range records {
+		appliedMap[records[idx].Version] = &records[idx]
+	}
+
+	for _, migration := range migrator.migrations {
+		status := MigrationStatus{migration, nil}
+
+		if record, ok := appliedMap[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+
+			delete(appliedMap, migration.Version)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	for version := range appliedMap {
+		return nil, fmt.Errorf("Migration (Version: '%d'): Applied Migration is unknown!", version)
+	}
+
+	return statuses, nil
+}
+
+// Pending - Lists the Migrations which are not applied yet
+func (migrator *Migrator) Pending() ([]Migration, error) {
+	var pending []Migration
+
+	statuses, err := migrator.Status()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Migration)
+		}
+	}
+
+	return pending, nil
+}
+
+func (migrator *Migrator) createTable() error {
+	statement, ok := SCHEMATABLES[migrator.dialect]
+
+	if !ok {
+		return fmt.Errorf("Migrations: Dialect '%s' is not supported!", migrator.dialect)
+	}
+
+	return migrator.db.Exec(statement).Error
+}
+
+func execStatements(tx *gorm.DB, statements []string) error {
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -8,34 +9,90 @@ import (
 
 type Article struct {
 	gorm.Model
-	UserID  uint   `json:"user_id"`
-	Title   string `json:"title"`
-	Slug    string `json:"slug"`
-	Content string `json:"content"`
+	UserID      uint          `json:"user_id"`
+	Title       string        `json:"title"`
+	Slug        string        `json:"slug" gorm:"uniqueIndex"`
+	Content     string        `json:"content"`
+	Format      ContentFormat `json:"format" gorm:"size:20;not null;default:markdown"`
+	Status      ArticleStatus `json:"status" gorm:"size:20;not null;default:draft;index"`
+	PublishedAt *time.Time    `json:"published_at" gorm:"index"`
+	CategoryID  *uint         `json:"-" gorm:"index"`
+	// TagNames - Tags assigned by the Create and Update Routes which are kept unchanged when missing
+	TagNames []string `json:"tags" gorm:"-"`
+	// CategorySlug - Category assigned by the Create and Update Routes where an empty Slug removes the Category
+	CategorySlug *string `json:"category" gorm:"-"`
 }
 
+// ArticleStatus - Stage of an Article in the Editorial Workflow
+type ArticleStatus string
+
+const (
+	StatusDraft     ArticleStatus = "draft"
+	StatusReview    ArticleStatus = "review"
+	StatusScheduled ArticleStatus = "scheduled"
+	StatusPublished ArticleStatus = "published"
+	StatusArchived  ArticleStatus = "archived"
+)
+
+// ARTICLESTATUSES - All Stages of the Editorial Workflow
+var ARTICLESTATUSES []ArticleStatus = []ArticleStatus{StatusDraft, StatusReview, StatusScheduled, StatusPublished, StatusArchived}
+
+// DisplayedArticle - Article as shown to the Client
+// The Content is given as Source and as sanitized HTML with the Reading Time in Minutes
 type DisplayedArticle struct {
-	ID         uint   `json:"id"`
-	Author     string `json:"author"`
-	AuthorSlug string `json:"author_slug"`
-	Title      string `json:"title"`
-	Slug       string `json:"slug"`
-	Content    string `json:"content"`
-	CreateTime string `json:"create_time"`
-	UpdateTime string `json:"update_time"`
+	ID           uint           `json:"id"`
+	UserID       uint           `json:"user_id"`
+	Author       string         `json:"author"`
+	AuthorSlug   string         `json:"author_slug"`
+	Title        string         `json:"title"`
+	Slug         string         `json:"slug"`
+	Content      string         `json:"content"`
+	Format       ContentFormat  `json:"format"`
+	HTML         string         `json:"html"`
+	Excerpt      string         `json:"excerpt"`
+	TOC          []TOCEntry     `json:"toc"`
+	ReadingTime  int            `json:"reading_time"`
+	Status       ArticleStatus  `json:"status"`
+	Category     string         `json:"category"`
+	CategorySlug string         `json:"category_slug"`
+	Tags         []DisplayedTag `json:"tags"`
+	CommentCount int64          `json:"comment_count"`
+	PublishTime  string         `json:"publish_time"`
+	CreateTime   string         `json:"create_time"`
+	UpdateTime   string         `json:"update_time"`
 }
 
 func NewDisplayedArticle(article *Article) DisplayedArticle {
-	return DisplayedArticle{
+	rendered := RenderContent(article.Format, article.Content)
+
+	displayed := DisplayedArticle{
 		article.ID,
+		article.UserID,
 		"",
 		"",
 		article.Title,
 		article.Slug,
 		article.Content,
+		article.Format,
+		rendered.HTML,
+		rendered.Excerpt,
+		rendered.TOC,
+		rendered.ReadingTime,
+		article.Status,
+		"",
+		"",
+		[]DisplayedTag{},
+		0,
+		"",
 		article.CreatedAt.Format(time.RFC3339),
 		article.UpdatedAt.Format(time.RFC3339),
 	}
+
+	if article.PublishedAt != nil {
+		displayed.PublishTime = article.PublishedAt.Format(time.RFC3339)
+	}
+
+	return displayed
 }
 
 func (article *Article) Update(update *Article) {
@@ -54,4 +111,65 @@ func (article *Article) Update(update *Article) {
 	if update.Content != "" {
 		article.Content = update.Content
 	}
+
+	if update.Format != "" {
+		article.Format = update.Format
+	}
+
+	if update.Status != "" {
+		article.Status = update.Status
+	}
+
+	if update.PublishedAt != nil {
+		article.PublishedAt = update.PublishedAt
+	}
+}
+
+// IsValidArticleStatus - Checks whether the Status is one of the Workflow Stages
+func IsValidArticleStatus(status ArticleStatus) bool {
+	for _, known := range ARTICLESTATUSES {
+		if status == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsEditorial - Scheduled, published and archived Articles are managed by Editors
+func (status ArticleStatus) IsEditorial() bool {
+	return status == StatusScheduled || status == StatusPublished || status == StatusArchived
+}
+
+// IsPublic - Checks whether the Article is published
+// Scheduled Articles become public when the Publisher publishes them
+func (article *Article) IsPublic() bool {
+	return article.Status == StatusPublished
+}
+
+// NormalizeStatus - Completes the Publish Time of the Workflow Stage
+// A published Article without a Publish Time is published now and with a future Publish Time it is scheduled.
+func (article *Article) NormalizeStatus(now time.Time) error {
+	if article.Status == "" {
+		article.Status = StatusDraft
+	}
+
+	if !IsValidArticleStatus(article.Status) {
+		return fmt.Errorf("Article Status: Status '%s' is invalid! Allowed Status: %v", article.Status, ARTICLESTATUSES)
+	}
+
+	switch article.Status {
+	case StatusPublished:
+		if article.PublishedAt == nil {
+			article.PublishedAt = &now
+		} else if article.PublishedAt.After(now) {
+			article.Status = StatusScheduled
+		}
+	case StatusScheduled:
+		if article.PublishedAt == nil || !article.PublishedAt.After(now) {
+			return fmt.Errorf("Article Status: Scheduled Articles require a Publish Time in the future!")
+		}
+	}
+
+	return nil
 }
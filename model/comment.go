@@ -0,0 +1,171 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CommentStatus - Stage of a Comment in the Moderation Queue
+type CommentStatus string
+
+const (
+	CommentPending  CommentStatus = "pending"
+	CommentApproved CommentStatus = "approved"
+	CommentSpam     CommentStatus = "spam"
+)
+
+// COMMENTSTATUSES - All Stages of the Moderation Queue
+var COMMENTSTATUSES []CommentStatus = []CommentStatus{CommentPending, CommentApproved, CommentSpam}
+
+// MAXCOMMENTLENGTH - Largest Number of Characters of a Comment
+var MAXCOMMENTLENGTH int = 10000
+
+// MAXCOMMENTNAMELENGTH - Largest Number of Characters of the Name of an anonymous Author
+var MAXCOMMENTNAMELENGTH int = 100
+
+type (
+	// Comment - Response to an Article or Reply to another Comment of the Article
+	// Comments of anonymous Authors have no User ID
+	Comment struct {
+		ID          uint          `json:"id" gorm:"primarykey"`
+		CreatedAt   time.Time     `json:"created_at"`
+		UpdatedAt   time.Time     `json:"updated_at"`
+		ArticleID   uint          `json:"article_id" gorm:"index"`
+		ParentID    *uint         `json:"parent_id" gorm:"index"`
+		UserID      *uint         `json:"user_id" gorm:"index"`
+		AuthorName  string        `json:"author_name"`
+		AuthorEmail string        `json:"-"`
+		Content     string        `json:"content"`
+		Status      CommentStatus `json:"status" gorm:"size:20;not null;default:pending;index"`
+	}
+
+	// CommentData - Comment Fields which are accepted by the Create Route
+	// The Author Fields are only used for anonymous Authors
+	CommentData struct {
+		ParentID    *uint  `json:"parent_id"`
+		AuthorName  string `json:"author_name"`
+		AuthorEmail string `json:"author_email"`
+		Content     string `json:"content"`
+	}
+
+	// CommentModeration - Comment Fields which are accepted by the Moderation Route
+	CommentModeration struct {
+		Status CommentStatus `json:"status"`
+	}
+
+	// DisplayedComment - Comment with its Replies
+	DisplayedComment struct {
+		ID         uint               `json:"id"`
+		ArticleID  uint               `json:"article_id"`
+		ParentID   *uint              `json:"parent_id"`
+		UserID     *uint              `json:"user_id"`
+		Author     string             `json:"author"`
+		Content    string             `json:"content"`
+		Status     CommentStatus      `json:"status"`
+		CreateTime string             `json:"create_time"`
+		Replies    []DisplayedComment `json:"replies"`
+	}
+)
+
+func (data *CommentData) NewComment(articleID uint) Comment {
+	return Comment{
+		ArticleID:   articleID,
+		ParentID:    data.ParentID,
+		AuthorName:  strings.TrimSpace(data.AuthorName),
+		AuthorEmail: strings.TrimSpace(data.AuthorEmail),
+		Content:     strings.TrimSpace(data.Content),
+	}
+}
+
+func NewDisplayedComment(comment *Comment) DisplayedComment {
+	return DisplayedComment{
+		comment.ID,
+		comment.ArticleID,
+		comment.ParentID,
+		comment.UserID,
+		comment.AuthorName,
+		comment.Content,
+		comment.Status,
+		comment.CreatedAt.Format(time.RFC3339),
+		[]DisplayedComment{},
+	}
+}
+
+// NewCommentTree - Nests the Comments ordered by their Creation into the Replies of their Parents
+// Replies to Comments which are not listed are lifted to the Top Level, so that every Comment is shown.
+func NewCommentTree(comments []Comment) []DisplayedComment {
+	children := make(map[uint][]*Comment)
+	listed := make(map[uint]bool, len(comments))
+
+	for idx := range comments {
+		listed[comments[idx].ID] = true
+	}
+
+	for idx := range comments {
+		parentID := uint(0)
+
+		if comments[idx].ParentID != nil && listed[*comments[idx].ParentID] {
+			parentID = *comments[idx].ParentID
+		}
+
+		children[parentID] = append(children[parentID], &comments[idx])
+	}
+
+	var display func(parentID uint) []DisplayedComment
+
+	display = func(parentID uint) []DisplayedComment {
+		displayed := []DisplayedComment{}
+
+		for _, comment := range children[parentID] {
+			reply := NewDisplayedComment(comment)
+
+			reply.Replies = display(comment.ID)
+
+			displayed = append(displayed, reply)
+		}
+
+		return displayed
+	}
+
+	return display(0)
+}
+
+// IsValidCommentStatus - Checks whether the Status is one of the Moderation Stages
+func IsValidCommentStatus(status CommentStatus) bool {
+	for _, known := range COMMENTSTATUSES {
+		if status == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate - Checks the Content and the Author of a new Comment
+func (comment *Comment) Validate() error {
+	if comment.Content == "" {
+		return fmt.Errorf("Model 'Comment': Content is missing!")
+	}
+
+	if utf8.RuneCountInString(comment.Content) > MAXCOMMENTLENGTH {
+		return fmt.Errorf("Model 'Comment': Content is too long! Maximum Length: %d Characters", MAXCOMMENTLENGTH)
+	}
+
+	if comment.UserID == nil {
+		if comment.AuthorName == "" {
+			return fmt.Errorf("Model 'Comment': Author Name is missing!")
+		}
+
+		if utf8.RuneCountInString(comment.AuthorName) > MAXCOMMENTNAMELENGTH {
+			return fmt.Errorf("Model 'Comment': Author Name is too long! Maximum Length: %d Characters", MAXCOMMENTNAMELENGTH)
+		}
+
+		if comment.AuthorEmail != "" && !strings.Contains(comment.AuthorEmail, "@") {
+			return fmt.Errorf("Model 'Comment': Author Email '%s' is invalid!", comment.AuthorEmail)
+		}
+	}
+
+	return nil
+}
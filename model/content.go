@@ -0,0 +1,187 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	goldmarkhtml "github.com/yuin/goldmark/renderer/html"
+)
+
+// ContentFormat - Markup Language of the Article Content
+type ContentFormat string
+
+const (
+	FormatMarkdown ContentFormat = "markdown"
+	FormatHTML     ContentFormat = "html"
+	FormatPlain    ContentFormat = "plain"
+)
+
+// CONTENTFORMATS - All Markup Languages of the Article Content
+var CONTENTFORMATS []ContentFormat = []ContentFormat{FormatMarkdown, FormatHTML, FormatPlain}
+
+// EXCERPTLENGTH - Largest Number of Characters of an Excerpt before its Ellipsis
+var EXCERPTLENGTH int = 200
+
+// WORDSPERMINUTE - Reading Speed by which the Reading Time is estimated
+var WORDSPERMINUTE int = 200
+
+// MAXCONTENTSIZE - Largest Content of an Article in Bytes
+var MAXCONTENTSIZE int = 512 * 1024
+
+// MARKDOWN - Markdown Renderer with the GitHub Flavored Extensions
+// Raw HTML is passed on because the rendered HTML is sanitized afterwards.
+var MARKDOWN goldmark.Markdown = goldmark.New(
+	goldmark.WithExtensions(
+		extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+		extension.Strikethrough,
+		extension.Linkify,
+		extension.TaskList,
+	),
+	goldmark.WithRendererOptions(goldmarkhtml.WithUnsafe()),
+)
+
+var plainParagraphs *regexp.Regexp = regexp.MustCompile(`\n[ \t]*\n\s*`)
+
+type (
+	// TOCEntry - Heading of the Content with the Anchor ID which is assigned to it in the rendered HTML
+	TOCEntry struct {
+		Level int    `json:"level"`
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+
+	// RenderedContent - Sanitized HTML of the Content with the Fields derived from its Text
+	// The Reading Time is given in Minutes
+	RenderedContent struct {
+		HTML        string
+		Excerpt     string
+		TOC         []TOCEntry
+		ReadingTime int
+	}
+)
+
+// IsValidContentFormat - Checks whether the Format is one of the Markup Languages
+func IsValidContentFormat(format ContentFormat) bool {
+	for _, known := range CONTENTFORMATS {
+		if format == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckContentSize - Refuses Content which is larger than MAXCONTENTSIZE
+func (article *Article) CheckContentSize() error {
+	if len(article.Content) > MAXCONTENTSIZE {
+		return fmt.Errorf("Article Content: Content of %d Bytes exceeds the Limit of %d Bytes!", len(article.Content), MAXCONTENTSIZE)
+	}
+
+	return nil
+}
+
+// NormalizeFormat - Completes the Content Format of the Article which defaults to Markdown
+func (article *Article) NormalizeFormat() error {
+	if article.Format == "" {
+		article.Format = FormatMarkdown
+	}
+
+	if !IsValidContentFormat(article.Format) {
+		return fmt.Errorf("Article Format: Format '%s' is invalid! Allowed Formats: %v", article.Format, CONTENTFORMATS)
+	}
+
+	return nil
+}
+
+// RenderContent - Converts the Content of the Format into sanitized HTML
+// Content of unknown Formats is treated as plain Text.
+func RenderContent(format ContentFormat, content string) RenderedContent {
+	var source string
+
+	switch format {
+	case FormatMarkdown, "":
+		var buffer bytes.Buffer
+
+		if err := MARKDOWN.Convert([]byte(content), &buffer); err != nil {
+			// Content which cannot be converted is still shown as Text
+			source = renderPlain(content)
+		} else {
+			source = buffer.String()
+		}
+	case FormatHTML:
+		source = content
+	default:
+		source = renderPlain(content)
+	}
+
+	document := SanitizeHTML(source)
+	words := strings.Fields(document.Text)
+
+	return RenderedContent{
+		document.HTML,
+		excerpt(words),
+		document.TOC,
+		readingTime(len(words)),
+	}
+}
+
+// renderPlain - Escapes the Text and separates its Paragraphs at empty Lines
+func renderPlain(content string) string {
+	var rendered strings.Builder
+
+	content = strings.TrimSpace(strings.ReplaceAll(content, "\r\n", "\n"))
+
+	if content == "" {
+		return ""
+	}
+
+	for _, paragraph := range plainParagraphs.Split(content, -1) {
+		lines := strings.Split(html.EscapeString(strings.TrimSpace(paragraph)), "\n")
+
+		rendered.WriteString("<p>" + strings.Join(lines, "<br>\n") + "</p>\n")
+	}
+
+	return rendered.String()
+}
+
+// excerpt - Joins the leading Words up to the Excerpt Length and marks a shortened Text with an Ellipsis
+func excerpt(words []string) string {
+	var excerpt strings.Builder
+
+	for _, word := range words {
+		length := utf8.RuneCountInString(excerpt.String())
+
+		if length != 0 && length+1+utf8.RuneCountInString(word) > EXCERPTLENGTH {
+			return strings.TrimRight(excerpt.String(), ".,;:!?-") + "…"
+		}
+
+		if length != 0 {
+			excerpt.WriteString(" ")
+		}
+
+		excerpt.WriteString(word)
+	}
+
+	if utf8.RuneCountInString(excerpt.String()) > EXCERPTLENGTH {
+		// A single Word exceeds the Excerpt Length
+		return string([]rune(excerpt.String())[:EXCERPTLENGTH]) + "…"
+	}
+
+	return excerpt.String()
+}
+
+// readingTime - Estimates the Minutes needed to read the Words where any Text takes at least one Minute
+func readingTime(wordCount int) int {
+	if wordCount == 0 {
+		return 0
+	}
+
+	return int(math.Ceil(float64(wordCount) / float64(WORDSPERMINUTE)))
+}
@@ -0,0 +1,265 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DIFFCONTEXTLINES - Unchanged Lines shown around each Change of a Unified Diff
+var DIFFCONTEXTLINES int = 3
+
+// DIFFMAXCOMPARISONS - Largest Product of the changed Lines of both Texts which is compared
+// It bounds the Time of a Diff as the Comparison grows with both Numbers of Lines.
+var DIFFMAXCOMPARISONS int64 = 25000000
+
+//==========================================================================
+// Structure diffLine Declaration
+
+// diffLine - Line of a Diff with its Operation ' ', '-' or '+'
+type diffLine struct {
+	operation byte
+	text      string
+}
+
+// UnifiedDiff - Compares two Texts line by line in the Unified Diff Format
+// Identical Texts produce an empty Diff
+func UnifiedDiff(fromName string, toName string, from string, to string) (string, error) {
+	var diff strings.Builder
+
+	lines, err := diffLines(splitLines(from), splitLines(to))
+
+	if err != nil {
+		return "", err
+	}
+
+	for start := 0; start < len(lines); {
+		// Find the next Change
+		for start < len(lines) && lines[start].operation == ' ' {
+			start++
+		}
+
+		if start == len(lines) {
+			break
+		}
+
+		// Extend the Hunk while the Changes are close to each other
+		hunkStart := maxInt(start-DIFFCONTEXTLINES, 0)
+		hunkEnd := start
+
+		for end := start; end < len(lines); end++ {
+			if lines[end].operation != ' ' {
+				hunkEnd = end + 1
+			} else if end-hunkEnd >= 2*DIFFCONTEXTLINES {
+				break
+			}
+		}
+
+		hunkEnd = minInt(hunkEnd+DIFFCONTEXTLINES, len(lines))
+
+		if diff.Len() == 0 {
+			fmt.Fprintf(&diff, "--- %s\n+++ %s\n", fromName, toName)
+		}
+
+		writeHunk(&diff, lines, hunkStart, hunkEnd)
+
+		start = hunkEnd
+	}
+
+	return diff.String(), nil
+}
+
+// writeHunk - Writes the Lines with the Hunk Header of their Line Ranges
+func writeHunk(diff *strings.Builder, lines []diffLine, start int, end int) {
+	fromStart, toStart := 1, 1
+
+	for _, line := range lines[:start] {
+		if line.operation != '+' {
+			fromStart++
+		}
+
+		if line.operation != '-' {
+			toStart++
+		}
+	}
+
+	fromCount, toCount := 0, 0
+
+	for _, line := range lines[start:end] {
+		if line.operation != '+' {
+			fromCount++
+		}
+
+		if line.operation != '-' {
+			toCount++
+		}
+	}
+
+	// Empty Ranges start at the Line before the Hunk
+	if fromCount == 0 {
+		fromStart--
+	}
+
+	if toCount == 0 {
+		toStart--
+	}
+
+	fmt.Fprintf(diff, "@@ -%d,%d +%d,%d @@\n", fromStart, fromCount, toStart, toCount)
+
+	for _, line := range lines[start:end] {
+		diff.WriteByte(line.operation)
+		diff.WriteString(line.text)
+		diff.WriteString("\n")
+	}
+}
+
+// diffLines - Computes the Line Operations from the Longest Common Subsequence of the Lines
+// The common Prefix and Suffix are matched first. The remaining Lines are compared with
+// the Algorithm of Hirschberg which needs Memory linear to the Number of Lines.
+func diffLines(from []string, to []string) ([]diffLine, error) {
+	prefix := 0
+
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+
+	for suffix < len(from)-prefix && suffix < len(to)-prefix && from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+
+	fromChanged := from[prefix : len(from)-suffix]
+	toChanged := to[prefix : len(to)-suffix]
+
+	if int64(len(fromChanged))*int64(len(toChanged)) > DIFFMAXCOMPARISONS {
+		return nil, fmt.Errorf("Diff: %d and %d changed Lines are too many to be compared!", len(fromChanged), len(toChanged))
+	}
+
+	lines := make([]diffLine, 0, len(from)+len(toChanged))
+
+	for _, text := range from[:prefix] {
+		lines = append(lines, diffLine{' ', text})
+	}
+
+	lines = diffChangedLines(lines, fromChanged, toChanged)
+
+	for _, text := range from[len(from)-suffix:] {
+		lines = append(lines, diffLine{' ', text})
+	}
+
+	return lines, nil
+}
+
+// diffChangedLines - Appends the Line Operations by splitting the Lines at the Middle of the Subsequence
+func diffChangedLines(lines []diffLine, from []string, to []string) []diffLine {
+	switch {
+	case len(from) == 0:
+		for _, text := range to {
+			lines = append(lines, diffLine{'+', text})
+		}
+	case len(to) == 0:
+		for _, text := range from {
+			lines = append(lines, diffLine{'-', text})
+		}
+	case len(from) == 1:
+		match := -1
+
+		for index, text := range to {
+			if text == from[0] {
+				match = index
+
+				break
+			}
+		}
+
+		if match == -1 {
+			lines = append(lines, diffLine{'-', from[0]})
+			match = len(to)
+		}
+
+		for _, text := range to[:match] {
+			lines = append(lines, diffLine{'+', text})
+		}
+
+		if match < len(to) {
+			lines = append(lines, diffLine{' ', from[0]})
+
+			for _, text := range to[match+1:] {
+				lines = append(lines, diffLine{'+', text})
+			}
+		}
+	default:
+		middle := len(from) / 2
+		leading := commonLengths(from[:middle], to, false)
+		trailing := commonLengths(from[middle:], to, true)
+		split, longest := 0, -1
+
+		for index := 0; index <= len(to); index++ {
+			if length := leading[index] + trailing[len(to)-index]; length > longest {
+				split, longest = index, length
+			}
+		}
+
+		lines = diffChangedLines(lines, from[:middle], to[:split])
+		lines = diffChangedLines(lines, from[middle:], to[split:])
+	}
+
+	return lines
+}
+
+// commonLengths - Lengths of the Longest Common Subsequence of the Lines with each Prefix of the other Lines
+// Reversed it compares the Lines from their End with each Suffix of the other Lines.
+func commonLengths(from []string, to []string, reversed bool) []int {
+	previous := make([]int, len(to)+1)
+	current := make([]int, len(to)+1)
+
+	for fromIndex := range from {
+		fromText := from[fromIndex]
+
+		if reversed {
+			fromText = from[len(from)-1-fromIndex]
+		}
+
+		for toIndex := 1; toIndex <= len(to); toIndex++ {
+			toText := to[toIndex-1]
+
+			if reversed {
+				toText = to[len(to)-toIndex]
+			}
+
+			if fromText == toText {
+				current[toIndex] = previous[toIndex-1] + 1
+			} else {
+				current[toIndex] = maxInt(previous[toIndex], current[toIndex-1])
+			}
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func maxInt(left int, right int) int {
+	if left > right {
+		return left
+	}
+
+	return right
+}
+
+func minInt(left int, right int) int {
+	if left < right {
+		return left
+	}
+
+	return right
+}
@@ -0,0 +1,300 @@
+package model
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"time"
+)
+
+// JSONFEEDVERSION - Version URL of the JSON Feed Specification
+const JSONFEEDVERSION string = "https://jsonfeed.org/version/1.1"
+
+type (
+	//==========================================================================
+	// Structure Feed Declaration
+
+	// Feed - Syndication Feed of an Article List which is rendered as RSS, Atom or JSON Feed
+	// The Link points to the Article List and the Feed Link to the Feed itself
+	Feed struct {
+		Title       string
+		Description string
+		Link        string
+		FeedLink    string
+		Updated     time.Time
+		Items       []FeedItem
+	}
+
+	// FeedItem - Entry of an Article in a Feed
+	// The Summary is the Excerpt and the Content the sanitized HTML of the Article
+	FeedItem struct {
+		ID        string
+		Link      string
+		Title     string
+		Author    string
+		Summary   string
+		Content   string
+		Tags      []string
+		Published time.Time
+		Updated   time.Time
+	}
+)
+
+type (
+	//==========================================================================
+	// RSS 2.0 Document Declaration
+
+	rssDocument struct {
+		XMLName       xml.Name   `xml:"rss"`
+		Version       string     `xml:"version,attr"`
+		AtomNamespace string     `xml:"xmlns:atom,attr"`
+		ContentNS     string     `xml:"xmlns:content,attr"`
+		DublinCoreNS  string     `xml:"xmlns:dc,attr"`
+		Channel       rssChannel `xml:"channel"`
+	}
+
+	rssChannel struct {
+		Title         string    `xml:"title"`
+		Link          string    `xml:"link"`
+		Description   string    `xml:"description"`
+		SelfLink      rssLink   `xml:"atom:link"`
+		LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+		Items         []rssItem `xml:"item"`
+	}
+
+	rssLink struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+		Type string `xml:"type,attr"`
+	}
+
+	rssItem struct {
+		Title       string   `xml:"title"`
+		Link        string   `xml:"link"`
+		GUID        rssGUID  `xml:"guid"`
+		Creator     string   `xml:"dc:creator,omitempty"`
+		PubDate     string   `xml:"pubDate"`
+		Categories  []string `xml:"category"`
+		Description string   `xml:"description"`
+		Content     string   `xml:"content:encoded"`
+	}
+
+	rssGUID struct {
+		IsPermaLink bool   `xml:"isPermaLink,attr"`
+		Value       string `xml:",chardata"`
+	}
+
+	//==========================================================================
+	// Atom Document Declaration
+
+	atomDocument struct {
+		XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+		Title    string      `xml:"title"`
+		Subtitle string      `xml:"subtitle,omitempty"`
+		ID       string      `xml:"id"`
+		Updated  string      `xml:"updated"`
+		Links    []atomLink  `xml:"link"`
+		Entries  []atomEntry `xml:"entry"`
+	}
+
+	atomLink struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+		Type string `xml:"type,attr,omitempty"`
+	}
+
+	atomEntry struct {
+		Title      string         `xml:"title"`
+		ID         string         `xml:"id"`
+		Link       atomLink       `xml:"link"`
+		Published  string         `xml:"published"`
+		Updated    string         `xml:"updated"`
+		Author     atomAuthor     `xml:"author"`
+		Categories []atomCategory `xml:"category"`
+		Summary    string         `xml:"summary"`
+		Content    atomContent    `xml:"content"`
+	}
+
+	atomAuthor struct {
+		Name string `xml:"name"`
+	}
+
+	atomCategory struct {
+		Term string `xml:"term,attr"`
+	}
+
+	atomContent struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	}
+
+	//==========================================================================
+	// JSON Feed Document Declaration
+
+	jsonFeedDocument struct {
+		Version     string         `json:"version"`
+		Title       string         `json:"title"`
+		HomePageURL string         `json:"home_page_url"`
+		FeedURL     string         `json:"feed_url"`
+		Description string         `json:"description,omitempty"`
+		Items       []jsonFeedItem `json:"items"`
+	}
+
+	jsonFeedItem struct {
+		ID            string           `json:"id"`
+		URL           string           `json:"url"`
+		Title         string           `json:"title"`
+		ContentHTML   string           `json:"content_html"`
+		Summary       string           `json:"summary,omitempty"`
+		DatePublished string           `json:"date_published"`
+		DateModified  string           `json:"date_modified"`
+		Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+		Tags          []string         `json:"tags,omitempty"`
+	}
+
+	jsonFeedAuthor struct {
+		Name string `json:"name"`
+	}
+)
+
+// NewFeedItem - Builds the Feed Entry of the Article with its Link
+// Articles without Publish Time are dated by their Creation.
+func NewFeedItem(article *Article, displayed *DisplayedArticle, link string) FeedItem {
+	item := FeedItem{
+		link,
+		link,
+		displayed.Title,
+		displayed.Author,
+		displayed.Excerpt,
+		displayed.HTML,
+		[]string{},
+		article.CreatedAt,
+		article.UpdatedAt,
+	}
+
+	if article.PublishedAt != nil {
+		item.Published = *article.PublishedAt
+	}
+
+	for _, tag := range displayed.Tags {
+		item.Tags = append(item.Tags, tag.Name)
+	}
+
+	return item
+}
+
+// RSS - Renders the Feed as RSS 2.0 Document
+func (feed *Feed) RSS() ([]byte, error) {
+	document := rssDocument{
+		Version:       "2.0",
+		AtomNamespace: "http://www.w3.org/2005/Atom",
+		ContentNS:     "http://purl.org/rss/1.0/modules/content/",
+		DublinCoreNS:  "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:       feed.Title,
+			Link:        feed.Link,
+			Description: feed.Description,
+			SelfLink:    rssLink{feed.FeedLink, "self", "application/rss+xml"},
+			Items:       []rssItem{},
+		},
+	}
+
+	if !feed.Updated.IsZero() {
+		document.Channel.LastBuildDate = feed.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, item := range feed.Items {
+		document.Channel.Items = append(document.Channel.Items, rssItem{
+			item.Title,
+			item.Link,
+			rssGUID{true, item.ID},
+			item.Author,
+			item.Published.UTC().Format(time.RFC1123Z),
+			item.Tags,
+			item.Summary,
+			item.Content,
+		})
+	}
+
+	return marshalXML(document)
+}
+
+// Atom - Renders the Feed as Atom Document
+func (feed *Feed) Atom() ([]byte, error) {
+	document := atomDocument{
+		Title:    feed.Title,
+		Subtitle: feed.Description,
+		ID:       feed.FeedLink,
+		Updated:  feed.Updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{feed.FeedLink, "self", "application/atom+xml"},
+			{feed.Link, "alternate", ""},
+		},
+		Entries: []atomEntry{},
+	}
+
+	for _, item := range feed.Items {
+		entry := atomEntry{
+			item.Title,
+			item.ID,
+			atomLink{item.Link, "alternate", ""},
+			item.Published.UTC().Format(time.RFC3339),
+			item.Updated.UTC().Format(time.RFC3339),
+			atomAuthor{item.Author},
+			[]atomCategory{},
+			item.Summary,
+			atomContent{"html", item.Content},
+		}
+
+		for _, tag := range item.Tags {
+			entry.Categories = append(entry.Categories, atomCategory{tag})
+		}
+
+		document.Entries = append(document.Entries, entry)
+	}
+
+	return marshalXML(document)
+}
+
+// JSONFeed - Renders the Feed as JSON Feed Document
+func (feed *Feed) JSONFeed() ([]byte, error) {
+	document := jsonFeedDocument{
+		JSONFEEDVERSION,
+		feed.Title,
+		feed.Link,
+		feed.FeedLink,
+		feed.Description,
+		[]jsonFeedItem{},
+	}
+
+	for _, item := range feed.Items {
+		jsonItem := jsonFeedItem{
+			item.ID,
+			item.Link,
+			item.Title,
+			item.Content,
+			item.Summary,
+			item.Published.UTC().Format(time.RFC3339),
+			item.Updated.UTC().Format(time.RFC3339),
+			nil,
+			item.Tags,
+		}
+
+		if item.Author != "" {
+			jsonItem.Authors = []jsonFeedAuthor{{item.Author}}
+		}
+
+		document.Items = append(document.Items, jsonItem)
+	}
+
+	return json.Marshal(document)
+}
+
+func marshalXML(document interface{}) ([]byte, error) {
+	body, err := xml.Marshal(document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,238 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MEDIATYPES - Content Types which are accepted for Uploads with their File Extension
+var MEDIATYPES map[string]string = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// THUMBNAILSIZE - Largest Width and Height of a generated Thumbnail in Pixels
+var THUMBNAILSIZE int = 320
+
+// MAXIMAGEPIXELS - Largest Number of Pixels of an Image which is decoded for its Thumbnail
+var MAXIMAGEPIXELS int = 40 * 1000 * 1000
+
+type (
+	// Media - Uploaded File of a User which can be attached to an Article
+	// The Storage Keys locate the File and its Thumbnail in the Media Storage
+	Media struct {
+		ID           uint      `json:"id" gorm:"primarykey"`
+		CreatedAt    time.Time `json:"created_at"`
+		UpdatedAt    time.Time `json:"updated_at"`
+		UserID       uint      `json:"user_id" gorm:"index"`
+		ArticleID    *uint     `json:"article_id" gorm:"index"`
+		FileName     string    `json:"file_name"`
+		ContentType  string    `json:"content_type" gorm:"size:100"`
+		Size         int64     `json:"size"`
+		Width        int       `json:"width"`
+		Height       int       `json:"height"`
+		StorageKey   string    `json:"-" gorm:"uniqueIndex"`
+		ThumbnailKey string    `json:"-"`
+	}
+
+	// DisplayedMedia - Media with the URLs of the File and its Thumbnail
+	DisplayedMedia struct {
+		ID           uint   `json:"id"`
+		UserID       uint   `json:"user_id"`
+		ArticleID    *uint  `json:"article_id"`
+		FileName     string `json:"file_name"`
+		ContentType  string `json:"content_type"`
+		Size         int64  `json:"size"`
+		Width        int    `json:"width"`
+		Height       int    `json:"height"`
+		URL          string `json:"url"`
+		ThumbnailURL string `json:"thumbnail_url"`
+		CreateTime   string `json:"create_time"`
+	}
+
+	// Thumbnail - Downscaled Copy of an Image
+	Thumbnail struct {
+		Data        []byte
+		ContentType string
+	}
+)
+
+// NewDisplayedMedia - Builds the View of the Media whose Files are served below the Web Root
+func NewDisplayedMedia(media *Media, webRoot string) DisplayedMedia {
+	displayed := DisplayedMedia{
+		media.ID,
+		media.UserID,
+		media.ArticleID,
+		media.FileName,
+		media.ContentType,
+		media.Size,
+		media.Width,
+		media.Height,
+		fmt.Sprintf("%smedia/%d/file", webRoot, media.ID),
+		"",
+		media.CreatedAt.Format(time.RFC3339),
+	}
+
+	if media.ThumbnailKey != "" {
+		displayed.ThumbnailURL = fmt.Sprintf("%smedia/%d/thumbnail", webRoot, media.ID)
+	}
+
+	return displayed
+}
+
+// DetectMediaType - Sniffs the Content Type from the File Data and checks whether it is accepted
+// The Content Type declared by the Client is not trusted.
+func DetectMediaType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+
+	if separator := strings.Index(contentType, ";"); separator != -1 {
+		contentType = contentType[:separator]
+	}
+
+	if _, ok := MEDIATYPES[contentType]; !ok {
+		var accepted []string
+
+		for acceptedType := range MEDIATYPES {
+			accepted = append(accepted, acceptedType)
+		}
+
+		sort.Strings(accepted)
+
+		return contentType, fmt.Errorf("Media: Content Type '%s' is not accepted! Accepted Types: %s", contentType, strings.Join(accepted, ", "))
+	}
+
+	return contentType, nil
+}
+
+// IsImage - Checks whether the Media is an Image which is shown inline
+func (media *Media) IsImage() bool {
+	return strings.HasPrefix(media.ContentType, "image/")
+}
+
+// CleanFileName - Reduces the File Name given by the Client to its Base Name
+func CleanFileName(fileName string) string {
+	fileName = path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+
+	if fileName == "." || fileName == "/" {
+		return ""
+	}
+
+	return fileName
+}
+
+// NewThumbnail - Reads the Image Dimensions and downscales the Image to fit the Thumbnail Size
+// Images which are too large to be decoded or cannot be decoded by the Standard Library get no Thumbnail.
+func NewThumbnail(data []byte, contentType string) (int, int, *Thumbnail, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+
+	if err != nil {
+		// WebP Images are stored without Dimensions
+		if contentType == "image/webp" {
+			return 0, 0, nil, nil
+		}
+
+		return 0, 0, nil, fmt.Errorf("Media: Image cannot be read! Message: %v", err)
+	}
+
+	if config.Width*config.Height > MAXIMAGEPIXELS {
+		return config.Width, config.Height, nil, nil
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("Media: Image cannot be decoded! Message: %v", err)
+	}
+
+	thumbnail := &Thumbnail{}
+	scaled := scaleImage(source, THUMBNAILSIZE)
+
+	var encoded bytes.Buffer
+
+	if contentType == "image/jpeg" {
+		thumbnail.ContentType = "image/jpeg"
+		err = jpeg.Encode(&encoded, scaled, &jpeg.Options{Quality: 85})
+	} else {
+		// PNG keeps the Transparency of PNG and GIF Images
+		thumbnail.ContentType = "image/png"
+		err = png.Encode(&encoded, scaled)
+	}
+
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("Media: Thumbnail cannot be encoded! Message: %v", err)
+	}
+
+	thumbnail.Data = encoded.Bytes()
+
+	return config.Width, config.Height, thumbnail, nil
+}
+
+// scaleImage - Downscales the Image by averaging the Source Pixels of each Target Pixel
+// Images which fit the Size are copied unscaled.
+func scaleImage(source image.Image, size int) *image.RGBA {
+	bounds := source.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > size || height > size {
+		if width >= height {
+			width, height = size, maxInt(1, bounds.Dy()*size/bounds.Dx())
+		} else {
+			width, height = maxInt(1, bounds.Dx()*size/bounds.Dy()), size
+		}
+	}
+
+	target := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	if width == bounds.Dx() && height == bounds.Dy() {
+		draw.Draw(target, target.Bounds(), source, bounds.Min, draw.Src)
+
+		return target
+	}
+
+	for y := 0; y < height; y++ {
+		top := bounds.Min.Y + y*bounds.Dy()/height
+		bottom := maxInt(top+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+
+		for x := 0; x < width; x++ {
+			left := bounds.Min.X + x*bounds.Dx()/width
+			right := maxInt(left+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			var red, green, blue, alpha, count uint64
+
+			for sourceY := top; sourceY < bottom; sourceY++ {
+				for sourceX := left; sourceX < right; sourceX++ {
+					r, g, b, a := source.At(sourceX, sourceY).RGBA()
+
+					red += uint64(r)
+					green += uint64(g)
+					blue += uint64(b)
+					alpha += uint64(a)
+					count++
+				}
+			}
+
+			target.SetRGBA(x, y, color.RGBA{
+				uint8(red / count >> 8),
+				uint8(green / count >> 8),
+				uint8(blue / count >> 8),
+				uint8(alpha / count >> 8),
+			})
+		}
+	}
+
+	return target
+}
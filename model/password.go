@@ -0,0 +1,165 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+//==========================================================================
+// Structure PasswordParams Declaration
+
+// PasswordParams - Cost Parameters of the Argon2id Password Hashes
+type PasswordParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+// PASSWORDPARAMS - Parameters for new Password Hashes
+// Hashes created with different Parameters are renewed on the next Login
+var PASSWORDPARAMS PasswordParams = PasswordParams{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// PASSWORDLIMITS - Bounds of the Parameters which a stored Argon2id Hash may use
+// Hashes outside of the Bounds are refused before they are computed.
+var PASSWORDLIMITS = struct {
+	MaxMemory     uint32
+	MaxIterations uint32
+	MinSaltLength int
+	MinKeyLength  int
+	MaxKeyLength  int
+}{
+	MaxMemory:     256 * 1024,
+	MaxIterations: 16,
+	MinSaltLength: 8,
+	MinKeyLength:  16,
+	MaxKeyLength:  128,
+}
+
+// ErrInvalidHash - The stored Password Hash cannot be parsed
+var ErrInvalidHash = errors.New("Password Hash: Hash Format is invalid!")
+
+// HashPassword - Creates an Argon2id Hash in the PHC String Format with a random Salt
+func HashPassword(password string) (string, error) {
+	params := PASSWORDPARAMS
+	salt := make([]byte, params.SaltLength)
+
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("Password Hash: Salt could not be created! Message: %v", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// IsPasswordHash - Checks whether the stored Password is a Hash which can be verified on Login
+// Passwords from Requests are always hashed even when they look like a Hash.
+func IsPasswordHash(password string) bool {
+	return strings.HasPrefix(password, "*") ||
+		strings.HasPrefix(password, "$argon2id$") ||
+		isBcryptHash(password)
+}
+
+// VerifyPassword - Checks the Password against the stored Hash
+// The second Result reports a Hash in an outdated Format or with outdated Parameters
+// Legacy Hashes with a leading '*' are checked with the global Salt
+func VerifyPassword(hash string, password string, salt string) (bool, bool, error) {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		return verifyArgon2id(hash, password)
+	case isBcryptHash(hash):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+
+		if err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, false, nil
+			}
+
+			return false, false, err
+		}
+
+		return true, false, nil
+	case strings.HasPrefix(hash, "*"):
+		encrypted := EncryptPassword(password, salt)
+
+		// Legacy Hashes are always renewed
+		return subtle.ConstantTimeCompare([]byte(hash), []byte(encrypted)) == 1, true, nil
+	}
+
+	return false, false, ErrInvalidHash
+}
+
+func verifyArgon2id(hash string, password string) (bool, bool, error) {
+	var params PasswordParams
+	var version int
+
+	// Format: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+	fields := strings.Split(hash, "$")
+
+	if len(fields) != 6 {
+		return false, false, ErrInvalidHash
+	}
+
+	if _, err := fmt.Sscanf(fields[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, false, ErrInvalidHash
+	}
+
+	if _, err := fmt.Sscanf(fields[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return false, false, ErrInvalidHash
+	}
+
+	// Refuse Parameters which argon2 cannot compute or which would exhaust the Server
+	if params.Iterations < 1 || params.Iterations > PASSWORDLIMITS.MaxIterations ||
+		params.Parallelism < 1 || params.Memory > PASSWORDLIMITS.MaxMemory {
+		return false, false, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(fields[4])
+
+	if err != nil {
+		return false, false, ErrInvalidHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(fields[5])
+
+	if err != nil {
+		return false, false, ErrInvalidHash
+	}
+
+	if len(salt) < PASSWORDLIMITS.MinSaltLength || len(key) < PASSWORDLIMITS.MinKeyLength || len(key) > PASSWORDLIMITS.MaxKeyLength {
+		return false, false, ErrInvalidHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	compare := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	if subtle.ConstantTimeCompare(key, compare) != 1 {
+		return false, false, nil
+	}
+
+	return true, params != PASSWORDPARAMS, nil
+}
+
+func isBcryptHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
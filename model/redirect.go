@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	RedirectArticle string = "article"
+	RedirectUser    string = "user"
+)
+
+// SlugRedirect - Former Slug of an Article or User which redirects to the current Slug
+// The Redirect refers to the Entity by its ID, so it follows further Slug Changes
+type SlugRedirect struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	CreatedAt time.Time `json:"created_at"`
+	Entity    string    `json:"entity" gorm:"size:20;uniqueIndex:idx_slug_redirects_entity_slug"`
+	Slug      string    `json:"slug" gorm:"uniqueIndex:idx_slug_redirects_entity_slug"`
+	TargetID  uint      `json:"target_id" gorm:"index"`
+}
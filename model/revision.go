@@ -0,0 +1,101 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ArticleRevision - Immutable Copy of the Article Fields after each Save
+// The Revision Number counts the Revisions of each Article starting at 1
+type ArticleRevision struct {
+	ID           uint          `json:"id" gorm:"primarykey"`
+	CreatedAt    time.Time     `json:"created_at"`
+	ArticleID    uint          `json:"article_id" gorm:"uniqueIndex:idx_article_revisions_article_number"`
+	Number       uint          `json:"number" gorm:"uniqueIndex:idx_article_revisions_article_number"`
+	UserID       uint          `json:"user_id"`
+	Title        string        `json:"title"`
+	Slug         string        `json:"slug"`
+	Content      string        `json:"content"`
+	Format       ContentFormat `json:"format" gorm:"size:20"`
+	Status       ArticleStatus `json:"status" gorm:"size:20"`
+	RestoredFrom uint          `json:"restored_from"`
+}
+
+//==========================================================================
+// Structure DisplayedRevision Declaration
+
+// DisplayedRevision - Revision as shown to the Client
+// The Editor is the User who saved the Revision
+type DisplayedRevision struct {
+	Number       uint          `json:"number"`
+	ArticleID    uint          `json:"article_id"`
+	UserID       uint          `json:"user_id"`
+	Editor       string        `json:"editor"`
+	Title        string        `json:"title"`
+	Slug         string        `json:"slug"`
+	Content      string        `json:"content"`
+	Format       ContentFormat `json:"format"`
+	Status       ArticleStatus `json:"status"`
+	RestoredFrom uint          `json:"restored_from,omitempty"`
+	CreateTime   string        `json:"create_time"`
+}
+
+// NewArticleRevision - Copies the current Fields of the Article into a new Revision
+func NewArticleRevision(article *Article, editorID uint) ArticleRevision {
+	return ArticleRevision{
+		ArticleID: article.ID,
+		UserID:    editorID,
+		Title:     article.Title,
+		Slug:      article.Slug,
+		Content:   article.Content,
+		Format:    article.Format,
+		Status:    article.Status,
+	}
+}
+
+func NewDisplayedRevision(revision *ArticleRevision, editor string) DisplayedRevision {
+	return DisplayedRevision{
+		revision.Number,
+		revision.ArticleID,
+		revision.UserID,
+		editor,
+		revision.Title,
+		revision.Slug,
+		revision.Content,
+		revision.Format,
+		revision.Status,
+		revision.RestoredFrom,
+		revision.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// Document - Renders the Revision Fields as Text for the Comparison of Revisions
+func (revision *ArticleRevision) Document() string {
+	var document strings.Builder
+
+	fmt.Fprintf(&document, "Title: %s\n", revision.Title)
+	fmt.Fprintf(&document, "Slug: %s\n", revision.Slug)
+	fmt.Fprintf(&document, "Format: %s\n", revision.Format)
+	fmt.Fprintf(&document, "Status: %s\n", revision.Status)
+	document.WriteString("\n")
+	document.WriteString(revision.Content)
+
+	if revision.Content != "" && !strings.HasSuffix(revision.Content, "\n") {
+		document.WriteString("\n")
+	}
+
+	return document.String()
+}
+
+// Restore - Applies the Title, Slug, Content and Format of the Revision to the Article
+// The Status is left to the Editorial Workflow
+func (revision *ArticleRevision) Restore(article *Article) {
+	article.Title = revision.Title
+	article.Slug = revision.Slug
+	article.Content = revision.Content
+
+	if revision.Format != "" {
+		article.Format = revision.Format
+	}
+}
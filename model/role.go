@@ -0,0 +1,90 @@
+package model
+
+// Role - Role of a User which grants the Permissions
+type Role string
+
+// Permission - Action which a Role can be allowed to perform
+type Permission string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleEditor Role = "editor"
+	RoleAuthor Role = "author"
+	RoleReader Role = "reader"
+)
+
+const (
+	// PermissionReadUsers - List and view all User Accounts
+	PermissionReadUsers Permission = "users:read"
+	// PermissionManageUsers - Create, modify and delete all User Accounts
+	PermissionManageUsers Permission = "users:manage"
+	// PermissionWriteArticles - Create Articles and modify the own Articles
+	PermissionWriteArticles Permission = "articles:write"
+	// PermissionEditArticles - Modify and delete the Articles of all Authors
+	PermissionEditArticles Permission = "articles:edit"
+	// PermissionPublishArticles - Schedule, publish and archive Articles and view all unpublished Articles
+	PermissionPublishArticles Permission = "articles:publish"
+	// PermissionManageTaxonomy - Create, modify and delete Tags and Categories
+	PermissionManageTaxonomy Permission = "taxonomy:manage"
+)
+
+// DEFAULTROLE - Role of new Users which were not given a Role
+var DEFAULTROLE Role = RoleReader
+
+// ROLEPERMISSIONS - Permissions granted by each Role
+var ROLEPERMISSIONS map[Role][]Permission = map[Role][]Permission{
+	RoleAdmin:  {PermissionReadUsers, PermissionManageUsers, PermissionWriteArticles, PermissionEditArticles, PermissionPublishArticles, PermissionManageTaxonomy},
+	RoleEditor: {PermissionReadUsers, PermissionWriteArticles, PermissionEditArticles, PermissionPublishArticles, PermissionManageTaxonomy},
+	RoleAuthor: {PermissionWriteArticles},
+	RoleReader: {},
+}
+
+// IsValidRole - Checks whether the Role is one of the known Roles
+func IsValidRole(role Role) bool {
+	_, ok := ROLEPERMISSIONS[role]
+
+	return ok
+}
+
+// Can - Checks whether the Role of the User grants the Permission
+func (user *User) Can(permission Permission) bool {
+	for _, granted := range ROLEPERMISSIONS[user.Role] {
+		if granted == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanModifyArticle - Authors can modify their own Articles while Editors can modify any Article
+func (user *User) CanModifyArticle(article *Article) bool {
+	if user.Can(PermissionEditArticles) {
+		return true
+	}
+
+	return user.Can(PermissionWriteArticles) && article.UserID == user.ID
+}
+
+// CanViewArticle - Published Articles are public while unpublished Articles are only visible
+// to their Author and to Editors
+func (user *User) CanViewArticle(article *Article) bool {
+	if article.IsPublic() {
+		return true
+	}
+
+	if user == nil {
+		return false
+	}
+
+	return user.Can(PermissionPublishArticles) || article.UserID == user.ID
+}
+
+// CanDeleteMedia - Users can delete their own Uploads while Editors can delete any Upload
+func (user *User) CanDeleteMedia(media *Media) bool {
+	if user.Can(PermissionEditArticles) {
+		return true
+	}
+
+	return media.UserID == user.ID
+}
@@ -0,0 +1,356 @@
+package model
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// SANITIZEDTAGS - Elements which are kept with their allowed Attributes
+// All other Elements are replaced by their Content.
+var SANITIZEDTAGS map[string][]string = map[string][]string{
+	"a":          {"href", "title"},
+	"abbr":       {"title"},
+	"b":          {},
+	"blockquote": {"cite"},
+	"br":         {},
+	"caption":    {},
+	"cite":       {},
+	"code":       {"class"},
+	"dd":         {},
+	"del":        {},
+	"div":        {},
+	"dl":         {},
+	"dt":         {},
+	"em":         {},
+	"figcaption": {},
+	"figure":     {},
+	"h1":         {},
+	"h2":         {},
+	"h3":         {},
+	"h4":         {},
+	"h5":         {},
+	"h6":         {},
+	"hr":         {},
+	"i":          {},
+	"img":        {"src", "alt", "title", "width", "height"},
+	"input":      {"type", "checked", "disabled"},
+	"ins":        {},
+	"kbd":        {},
+	"li":         {},
+	"mark":       {},
+	"ol":         {"start"},
+	"p":          {},
+	"pre":        {},
+	"q":          {"cite"},
+	"s":          {},
+	"small":      {},
+	"span":       {},
+	"strong":     {},
+	"sub":        {},
+	"sup":        {},
+	"table":      {},
+	"tbody":      {},
+	"td":         {"align", "colspan", "rowspan"},
+	"tfoot":      {},
+	"th":         {"align", "colspan", "rowspan"},
+	"thead":      {},
+	"tr":         {},
+	"u":          {},
+	"ul":         {},
+}
+
+// REMOVEDTAGS - Elements which are removed together with their Content
+var REMOVEDTAGS map[string]bool = map[string]bool{
+	"applet":   true,
+	"audio":    true,
+	"base":     true,
+	"button":   true,
+	"embed":    true,
+	"form":     true,
+	"frame":    true,
+	"frameset": true,
+	"head":     true,
+	"iframe":   true,
+	"link":     true,
+	"math":     true,
+	"meta":     true,
+	"noscript": true,
+	"object":   true,
+	"script":   true,
+	"select":   true,
+	"style":    true,
+	"svg":      true,
+	"template": true,
+	"textarea": true,
+	"title":    true,
+	"video":    true,
+}
+
+// SANITIZEDSCHEMES - URL Schemes which are allowed in Links and Images besides relative URLs
+var SANITIZEDSCHEMES map[string]bool = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+var (
+	codeLanguageClass *regexp.Regexp = regexp.MustCompile(`^language-[A-Za-z0-9_+#-]+$`)
+	numericAttribute  *regexp.Regexp = regexp.MustCompile(`^[0-9]{1,4}$`)
+)
+
+var textBlocks map[atom.Atom]bool = map[atom.Atom]bool{
+	atom.Blockquote: true, atom.Br: true, atom.Dd: true, atom.Div: true, atom.Dt: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Li: true, atom.P: true, atom.Pre: true, atom.Td: true, atom.Th: true, atom.Tr: true,
+}
+
+// SanitizedDocument - HTML which only contains the allowed Elements and Attributes
+// The Headings get unique Anchor IDs which are listed in the Table of Contents.
+type SanitizedDocument struct {
+	HTML string
+	Text string
+	TOC  []TOCEntry
+}
+
+// SanitizeHTML - Removes all Elements, Attributes and URLs from the HTML Fragment which are not allowed
+// Links to other Sites are marked with 'nofollow'.
+func SanitizeHTML(source string) SanitizedDocument {
+	var rendered strings.Builder
+	var text strings.Builder
+
+	body := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+
+	nodes, err := html.ParseFragment(strings.NewReader(source), body)
+
+	if err != nil {
+		// Unparsable HTML is shown as Text
+		nodes = []*html.Node{{Type: html.TextNode, Data: source}}
+	}
+
+	for _, node := range nodes {
+		body.AppendChild(node)
+	}
+
+	sanitizeChildren(body)
+
+	document := SanitizedDocument{TOC: []TOCEntry{}}
+
+	anchorHeadings(body, make(map[string]bool), &document.TOC)
+
+	for node := body.FirstChild; node != nil; node = node.NextSibling {
+		html.Render(&rendered, node)
+		writeText(&text, node)
+	}
+
+	document.HTML = rendered.String()
+	document.Text = strings.Join(strings.Fields(text.String()), " ")
+
+	return document
+}
+
+func sanitizeChildren(parent *html.Node) {
+	for node := parent.FirstChild; node != nil; {
+		next := node.NextSibling
+
+		switch node.Type {
+		case html.TextNode:
+		case html.ElementNode:
+			if allowed, ok := SANITIZEDTAGS[node.Data]; ok {
+				node.Attr = sanitizeAttributes(node, allowed)
+
+				sanitizeChildren(node)
+			} else if REMOVEDTAGS[node.Data] {
+				parent.RemoveChild(node)
+			} else {
+				// The Content of unknown Elements is kept in their Place
+				sanitizeChildren(node)
+
+				for child := node.FirstChild; child != nil; child = node.FirstChild {
+					node.RemoveChild(child)
+					parent.InsertBefore(child, node)
+				}
+
+				parent.RemoveChild(node)
+			}
+		default:
+			parent.RemoveChild(node)
+		}
+
+		node = next
+	}
+}
+
+func sanitizeAttributes(node *html.Node, allowed []string) []html.Attribute {
+	var attributes []html.Attribute
+
+	for _, attribute := range node.Attr {
+		if attribute.Namespace != "" || !containsString(allowed, attribute.Key) {
+			continue
+		}
+
+		switch attribute.Key {
+		case "href", "src", "cite":
+			if !isSafeURL(attribute.Val) {
+				continue
+			}
+		case "class":
+			if !codeLanguageClass.MatchString(attribute.Val) {
+				continue
+			}
+		case "width", "height", "colspan", "rowspan", "start":
+			if !numericAttribute.MatchString(attribute.Val) {
+				continue
+			}
+		case "align":
+			if attribute.Val != "left" && attribute.Val != "center" && attribute.Val != "right" {
+				continue
+			}
+		case "type":
+			if attribute.Val != "checkbox" {
+				continue
+			}
+		}
+
+		attributes = append(attributes, attribute)
+	}
+
+	switch node.Data {
+	case "a":
+		if link, err := url.Parse(attributeValue(attributes, "href")); err == nil && link.Host != "" {
+			attributes = append(attributes, html.Attribute{Key: "rel", Val: "nofollow noopener"})
+		}
+	case "input":
+		// Only the disabled Checkboxes of Task Lists are kept
+		if attributeValue(attributes, "type") != "checkbox" {
+			return []html.Attribute{{Key: "type", Val: "checkbox"}, {Key: "disabled", Val: ""}}
+		}
+
+		if !hasAttribute(attributes, "disabled") {
+			attributes = append(attributes, html.Attribute{Key: "disabled", Val: ""})
+		}
+	}
+
+	return attributes
+}
+
+// isSafeURL - Allows relative URLs and the URLs of the sanitized Schemes
+func isSafeURL(value string) bool {
+	link, err := url.Parse(strings.TrimSpace(value))
+
+	if err != nil {
+		return false
+	}
+
+	return link.Scheme == "" || SANITIZEDSCHEMES[strings.ToLower(link.Scheme)]
+}
+
+// anchorHeadings - Assigns unique Anchor IDs derived from their Text to the Headings
+func anchorHeadings(parent *html.Node, anchors map[string]bool, toc *[]TOCEntry) {
+	for node := parent.FirstChild; node != nil; node = node.NextSibling {
+		if node.Type != html.ElementNode {
+			continue
+		}
+
+		level := headingLevel(node.DataAtom)
+
+		if level == 0 {
+			anchorHeadings(node, anchors, toc)
+
+			continue
+		}
+
+		var text strings.Builder
+
+		writeText(&text, node)
+
+		title := strings.Join(strings.Fields(text.String()), " ")
+		base := Slugify(title)
+
+		if base == "" {
+			base = "section"
+		}
+
+		anchor := base
+
+		for suffix := 2; anchors[anchor]; suffix++ {
+			anchor = fmt.Sprintf("%s-%d", base, suffix)
+		}
+
+		anchors[anchor] = true
+
+		node.Attr = append(node.Attr, html.Attribute{Key: "id", Val: anchor})
+
+		*toc = append(*toc, TOCEntry{level, anchor, title})
+	}
+}
+
+func headingLevel(element atom.Atom) int {
+	switch element {
+	case atom.H1:
+		return 1
+	case atom.H2:
+		return 2
+	case atom.H3:
+		return 3
+	case atom.H4:
+		return 4
+	case atom.H5:
+		return 5
+	case atom.H6:
+		return 6
+	}
+
+	return 0
+}
+
+// writeText - Collects the Text of the Node where Blocks are separated by Spaces
+func writeText(text *strings.Builder, node *html.Node) {
+	if node.Type == html.TextNode {
+		text.WriteString(node.Data)
+
+		return
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeText(text, child)
+	}
+
+	if node.Type == html.ElementNode && textBlocks[node.DataAtom] {
+		text.WriteString(" ")
+	}
+}
+
+func attributeValue(attributes []html.Attribute, key string) string {
+	for _, attribute := range attributes {
+		if attribute.Key == key {
+			return attribute.Val
+		}
+	}
+
+	return ""
+}
+
+func hasAttribute(attributes []html.Attribute, key string) bool {
+	for _, attribute := range attributes {
+		if attribute.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, listed := range values {
+		if listed == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"html"
+	"strings"
+)
+
+// HIGHLIGHTSTART - Private Use Character which opens a matching Word in a Search Snippet
+const HIGHLIGHTSTART string = "\uE000"
+
+// HIGHLIGHTSTOP - Private Use Character which closes a matching Word in a Search Snippet
+const HIGHLIGHTSTOP string = "\uE001"
+
+// DisplayedSearchResult - Article found by the Search with its Rank and its highlighted Snippet
+type DisplayedSearchResult struct {
+	Article DisplayedArticle `json:"article"`
+	Rank    float64          `json:"rank"`
+	Snippet string           `json:"snippet"`
+}
+
+// HighlightSnippet - Escapes the Snippet Text and encloses its matching Words in <mark> Elements
+func HighlightSnippet(snippet string) string {
+	highlighted := html.EscapeString(strings.Join(strings.Fields(snippet), " "))
+
+	highlighted = strings.ReplaceAll(highlighted, HIGHLIGHTSTART, "<mark>")
+
+	return strings.ReplaceAll(highlighted, HIGHLIGHTSTOP, "</mark>")
+}
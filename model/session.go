@@ -0,0 +1,49 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Session - A Login Session which the Access and Refresh Tokens belong to
+// Revoking the Session invalidates both Tokens
+type Session struct {
+	gorm.Model
+	UserID      uint       `json:"user_id" gorm:"index"`
+	RefreshHash string     `json:"-" gorm:"uniqueIndex"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+}
+
+// NewRefreshToken - Creates a random Refresh Token and the Hash to store for it
+func NewRefreshToken() (string, string, error) {
+	tokenData := make([]byte, 32)
+
+	if _, err := rand.Read(tokenData); err != nil {
+		return "", "", fmt.Errorf("Refresh Token: Token could not be created! Message: %v", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(tokenData)
+
+	return token, HashRefreshToken(token), nil
+}
+
+// HashRefreshToken - Refresh Tokens are only stored as SHA-256 Hash
+func HashRefreshToken(token string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(token)))
+}
+
+func (session *Session) IsActive(now time.Time) bool {
+	return session.RevokedAt == nil && now.Before(session.ExpiresAt)
+}
+
+func (session *Session) Revoke(now time.Time) {
+	if session.RevokedAt == nil {
+		session.RevokedAt = &now
+	}
+}
@@ -0,0 +1,125 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MAXSLUGLENGTH - Maximum Length of a generated Slug including its numeric Suffix
+var MAXSLUGLENGTH int = 100
+
+// MAXSLUGSUFFIX - Highest numeric Suffix which is tried to make a Slug unique
+var MAXSLUGSUFFIX int = 1000
+
+// SLUGTRANSLITERATIONS - Letters which are not transliterated by removing their Diacritics
+var SLUGTRANSLITERATIONS map[rune]string = map[rune]string{
+	'ä': "ae",
+	'ö': "oe",
+	'ü': "ue",
+	'ß': "ss",
+	'æ': "ae",
+	'œ': "oe",
+	'ø': "o",
+	'ł': "l",
+	'đ': "d",
+	'ð': "d",
+	'þ': "th",
+	'ı': "i",
+}
+
+// Slugify - Transliterates the Text into a lowercase URL-safe Slug
+// Letters with Diacritics lose them and all other Characters become single Hyphens
+func Slugify(text string) string {
+	var slug strings.Builder
+
+	separate := false
+
+	for _, char := range norm.NFC.String(strings.ToLower(text)) {
+		var transliterated string
+
+		if replacement, ok := SLUGTRANSLITERATIONS[char]; ok {
+			transliterated = replacement
+		} else {
+			for _, decomposed := range norm.NFD.String(string(char)) {
+				if !unicode.Is(unicode.Mn, decomposed) {
+					transliterated += string(decomposed)
+				}
+			}
+		}
+
+		for _, ascii := range transliterated {
+			if (ascii >= 'a' && ascii <= 'z') || (ascii >= '0' && ascii <= '9') {
+				if separate && slug.Len() != 0 {
+					slug.WriteRune('-')
+				}
+
+				slug.WriteRune(ascii)
+
+				separate = false
+			} else {
+				separate = true
+			}
+		}
+
+		if transliterated == "" {
+			separate = true
+		}
+	}
+
+	return truncateSlug(slug.String(), MAXSLUGLENGTH)
+}
+
+// IsValidSlug - Checks whether the Slug only consists of lowercase Letters, Digits and single inner Hyphens
+func IsValidSlug(slug string) bool {
+	if slug == "" || len(slug) > MAXSLUGLENGTH || slug[0] == '-' || slug[len(slug)-1] == '-' {
+		return false
+	}
+
+	for idx, char := range slug {
+		if char == '-' {
+			if slug[idx-1] == '-' {
+				return false
+			}
+		} else if !(char >= 'a' && char <= 'z') && !(char >= '0' && char <= '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// UniqueSlug - Finds the first Slug which is not taken by appending the numeric Suffixes '-2', '-3', ...
+func UniqueSlug(base string, isTaken func(slug string) (bool, error)) (string, error) {
+	for suffix := 1; suffix <= MAXSLUGSUFFIX; suffix++ {
+		slug := base
+
+		if suffix > 1 {
+			suffixString := fmt.Sprintf("-%d", suffix)
+
+			slug = truncateSlug(base, MAXSLUGLENGTH-len(suffixString)) + suffixString
+		}
+
+		taken, err := isTaken(slug)
+
+		if err != nil {
+			return "", err
+		}
+
+		if !taken {
+			return slug, nil
+		}
+	}
+
+	return "", fmt.Errorf("Slug '%s': No unique Slug was found!", base)
+}
+
+func truncateSlug(slug string, length int) string {
+	if len(slug) > length {
+		slug = slug[:length]
+	}
+
+	return strings.TrimRight(slug, "-")
+}
@@ -0,0 +1,220 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	// Tag - Keyword which is assigned to any Number of Articles
+	Tag struct {
+		ID        uint      `json:"id" gorm:"primarykey"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+		Name      string    `json:"name"`
+		Slug      string    `json:"slug" gorm:"uniqueIndex"`
+	}
+
+	// ArticleTag - Assignment of a Tag to an Article
+	ArticleTag struct {
+		ArticleID uint `gorm:"primaryKey"`
+		TagID     uint `gorm:"primaryKey;index"`
+	}
+
+	// Category - Section of the Blog which can be nested into a Parent Category
+	Category struct {
+		ID          uint      `json:"id" gorm:"primarykey"`
+		CreatedAt   time.Time `json:"created_at"`
+		UpdatedAt   time.Time `json:"updated_at"`
+		ParentID    *uint     `json:"parent_id" gorm:"index"`
+		Name        string    `json:"name"`
+		Slug        string    `json:"slug" gorm:"uniqueIndex"`
+		Description string    `json:"description"`
+	}
+
+	// CategoryData - Category Fields which are accepted by the Create and Update Routes
+	// The Parent is given by its Slug and an empty Parent moves the Category to the Top Level
+	CategoryData struct {
+		Name        string  `json:"name"`
+		Slug        string  `json:"slug"`
+		Description string  `json:"description"`
+		Parent      *string `json:"parent"`
+	}
+
+	DisplayedTag struct {
+		ID   uint   `json:"id"`
+		Name string `json:"name"`
+		Slug string `json:"slug"`
+	}
+
+	// TagCount - Tag with the Number of its Articles for a Tag Cloud
+	TagCount struct {
+		DisplayedTag
+		ArticleCount int64 `json:"article_count"`
+	}
+
+	// DisplayedCategory - Category with its Subcategories
+	DisplayedCategory struct {
+		ID          uint                `json:"id"`
+		ParentID    *uint               `json:"parent_id"`
+		Name        string              `json:"name"`
+		Slug        string              `json:"slug"`
+		Description string              `json:"description"`
+		Path        []string            `json:"path"`
+		Children    []DisplayedCategory `json:"children"`
+	}
+)
+
+func NewDisplayedTag(tag *Tag) DisplayedTag {
+	return DisplayedTag{
+		tag.ID,
+		tag.Name,
+		tag.Slug,
+	}
+}
+
+func NewDisplayedTags(tags []Tag) []DisplayedTag {
+	displayed := make([]DisplayedTag, 0, len(tags))
+
+	for idx := range tags {
+		displayed = append(displayed, NewDisplayedTag(&tags[idx]))
+	}
+
+	return displayed
+}
+
+// NewTag - Creates the Tag for the Name with the Slug of the Name
+func NewTag(name string) Tag {
+	return Tag{Name: name, Slug: Slugify(name)}
+}
+
+func (data *CategoryData) NewCategory() Category {
+	return Category{
+		Name:        data.Name,
+		Slug:        data.Slug,
+		Description: data.Description,
+	}
+}
+
+func (category *Category) Update(update *CategoryData) {
+	if update.Name != "" {
+		category.Name = update.Name
+	}
+
+	if update.Slug != "" {
+		category.Slug = update.Slug
+	}
+
+	if update.Description != "" {
+		category.Description = update.Description
+	}
+}
+
+//==========================================================================
+// Structure CategoryTree Declaration
+
+// CategoryTree - All Categories indexed by their ID and by their Parent
+type CategoryTree struct {
+	Categories map[uint]*Category
+	Children   map[uint][]*Category
+}
+
+// NewCategoryTree - Indexes the Categories which are ordered by their Name
+// Top Level Categories are the Children of the ID 0
+func NewCategoryTree(categories []Category) *CategoryTree {
+	tree := &CategoryTree{make(map[uint]*Category), make(map[uint][]*Category)}
+
+	for idx := range categories {
+		category := &categories[idx]
+		parentID := uint(0)
+
+		if category.ParentID != nil {
+			parentID = *category.ParentID
+		}
+
+		tree.Categories[category.ID] = category
+		tree.Children[parentID] = append(tree.Children[parentID], category)
+	}
+
+	return tree
+}
+
+// Descendants - The IDs of the Category and of all its nested Subcategories
+func (tree *CategoryTree) Descendants(categoryID uint) []uint {
+	categoryIDs := []uint{categoryID}
+	seen := map[uint]bool{categoryID: true}
+
+	for idx := 0; idx < len(categoryIDs); idx++ {
+		for _, child := range tree.Children[categoryIDs[idx]] {
+			if !seen[child.ID] {
+				seen[child.ID] = true
+				categoryIDs = append(categoryIDs, child.ID)
+			}
+		}
+	}
+
+	return categoryIDs
+}
+
+// Path - The Slugs of the Category and its Parents starting at the Top Level
+func (tree *CategoryTree) Path(categoryID uint) []string {
+	var path []string
+
+	for category := tree.Categories[categoryID]; category != nil && len(path) <= len(tree.Categories); {
+		path = append([]string{category.Slug}, path...)
+
+		if category.ParentID == nil {
+			break
+		}
+
+		category = tree.Categories[*category.ParentID]
+	}
+
+	return path
+}
+
+// CheckParent - Checks whether the Category can be nested into the Parent
+// A Category cannot be nested into itself or into its own Subcategories
+func (tree *CategoryTree) CheckParent(category *Category, parentID uint) error {
+	if category.ID == 0 {
+		return nil
+	}
+
+	for _, descendantID := range tree.Descendants(category.ID) {
+		if descendantID == parentID {
+			return fmt.Errorf("Category (Slug: '%s'): Category cannot be nested into itself or its Subcategories!", category.Slug)
+		}
+	}
+
+	return nil
+}
+
+// Display - Builds the View of the Category with all its nested Subcategories
+func (tree *CategoryTree) Display(category *Category) DisplayedCategory {
+	displayed := DisplayedCategory{
+		category.ID,
+		category.ParentID,
+		category.Name,
+		category.Slug,
+		category.Description,
+		tree.Path(category.ID),
+		[]DisplayedCategory{},
+	}
+
+	for _, child := range tree.Children[category.ID] {
+		displayed.Children = append(displayed.Children, tree.Display(child))
+	}
+
+	return displayed
+}
+
+// DisplayAll - Builds the Views of all Top Level Categories
+func (tree *CategoryTree) DisplayAll() []DisplayedCategory {
+	displayed := []DisplayedCategory{}
+
+	for _, category := range tree.Children[0] {
+		displayed = append(displayed, tree.Display(category))
+	}
+
+	return displayed
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+)
+
+type (
+	// TrashedArticle - Summary of a deleted Article in the Trash
+	// The Purge Time is empty when the Trash is kept forever
+	TrashedArticle struct {
+		ID         uint          `json:"id"`
+		UserID     uint          `json:"user_id"`
+		Title      string        `json:"title"`
+		Slug       string        `json:"slug"`
+		Status     ArticleStatus `json:"status"`
+		DeleteTime string        `json:"delete_time"`
+		PurgeTime  string        `json:"purge_time"`
+	}
+
+	// TrashedUser - Summary of a deleted User in the Trash
+	TrashedUser struct {
+		ID         uint   `json:"id"`
+		Name       string `json:"name"`
+		Slug       string `json:"slug"`
+		Login      string `json:"login"`
+		Role       Role   `json:"role"`
+		DeleteTime string `json:"delete_time"`
+		PurgeTime  string `json:"purge_time"`
+	}
+)
+
+func NewTrashedArticle(article *Article, retention time.Duration) TrashedArticle {
+	return TrashedArticle{
+		article.ID,
+		article.UserID,
+		article.Title,
+		article.Slug,
+		article.Status,
+		article.DeletedAt.Time.Format(time.RFC3339),
+		purgeTime(article.DeletedAt.Time, retention),
+	}
+}
+
+func NewTrashedUser(user *User, retention time.Duration) TrashedUser {
+	return TrashedUser{
+		user.ID,
+		user.Name,
+		user.Slug,
+		user.Login,
+		user.Role,
+		user.DeletedAt.Time.Format(time.RFC3339),
+		purgeTime(user.DeletedAt.Time, retention),
+	}
+}
+
+// purgeTime - Time when the Retention Job removes the deleted Entity
+func purgeTime(deleted time.Time, retention time.Duration) string {
+	if retention <= 0 {
+		return ""
+	}
+
+	return deleted.Add(retention).Format(time.RFC3339)
+}
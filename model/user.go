@@ -3,7 +3,7 @@ package model
 import (
 	"crypto/sha512"
 	"fmt"
-	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -11,18 +11,47 @@ import (
 type (
 	User struct {
 		gorm.Model
+		Name     string    `json:"name"`
+		Slug     string    `json:"slug"`
+		Login    string    `json:"login"`
+		Email    string    `json:"email"`
+		Password string    `json:"-"`
+		Role     Role      `json:"role" gorm:"size:20;not null;default:reader"`
+		Articles []Article `json:"-"`
+	}
+
+	// UserData - User Fields which are accepted by the Create and Update Routes
+	UserData struct {
 		Name     string `json:"name"`
 		Slug     string `json:"slug"`
 		Login    string `json:"login"`
 		Email    string `json:"email"`
 		Password string `json:"password"`
-		Articles []Article
+		Role     Role   `json:"role"`
 	}
 
+	// DisplayedUser - User Profile shown to Editors
 	DisplayedUser struct {
-		Name     string `json:"name"`
+		ID         uint   `json:"id"`
+		Name       string `json:"name"`
 		Slug       string `json:"slug"`
 		Email      string `json:"email"`
+		Role       Role   `json:"role"`
+		CreateTime string `json:"create_time"`
+		UpdateTime string `json:"update_time"`
+	}
+
+	// DisplayedAccount - User Profile shown to the Account Owner and Administrators
+	DisplayedAccount struct {
+		DisplayedUser
+		Login string `json:"login"`
+	}
+
+	DisplayedAuthor struct {
+		ID          uint   `json:"id"`
+		Name        string `json:"name"`
+		Slug        string `json:"slug"`
+		MemberSince string `json:"member_since"`
 	}
 
 	Login struct {
@@ -31,18 +60,49 @@ type (
 	}
 )
 
-var ENCRYPTIONSALT string = "gin-blog"
-var ENCRYPTIONKEY []byte = []byte("gin-blog")
-
 func NewDisplayedUser(user *User) DisplayedUser {
 	return DisplayedUser{
+		user.ID,
 		user.Name,
 		user.Slug,
 		user.Email,
+		user.Role,
+		user.CreatedAt.Format(time.RFC3339),
+		user.UpdatedAt.Format(time.RFC3339),
 	}
 }
 
-func (user *User) Update(update *User) {
+func NewDisplayedAccount(user *User) DisplayedAccount {
+	return DisplayedAccount{
+		NewDisplayedUser(user),
+		user.Login,
+	}
+}
+
+// NewUser - Creates a User from the submitted Fields
+func (data *UserData) NewUser() User {
+	return User{
+		Name:     data.Name,
+		Slug:     data.Slug,
+		Login:    data.Login,
+		Email:    data.Email,
+		Password: data.Password,
+		Role:     data.Role,
+	}
+}
+
+func NewDisplayedAuthor(user *User) DisplayedAuthor {
+	return DisplayedAuthor{
+		user.ID,
+		user.Name,
+		user.Slug,
+		user.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func (user *User) Update(update *User) error {
+	var err error
+
 	if update.Name != "" {
 		user.Name = update.Name
 	}
@@ -59,26 +119,42 @@ func (user *User) Update(update *User) {
 		user.Email = update.Email
 	}
 
-	if update.Password != "" {
-		user.Password = update.Password
+	if update.Role != "" {
+		user.Role = update.Role
+	}
 
-		if !strings.HasPrefix(user.Password, "*") {
-			user.Password = EncryptPassword(user.Password, ENCRYPTIONSALT)
-		}
+	if update.Password != "" {
+		// The Password is hashed even when it looks like a Hash
+		user.Password, err = HashPassword(update.Password)
 	}
+
+	return err
 }
 
+// Auth - Checks the Login Credentials against the stored Password Hash
+// A Hash in an outdated Format is replaced with a new Hash on a successful Login,
+// so the caller must persist the User when its Password has changed
 func (user *User) Auth(login string, password string, salt string) bool {
 	if user.Login != login {
 		return false
 	}
 
-	encrypted := EncryptPassword(password, salt)
+	if !IsPasswordHash(user.Password) {
+		return false
+	}
+
+	valid, outdated, err := VerifyPassword(user.Password, password, salt)
 
-	if user.Password != encrypted {
+	if err != nil || !valid {
 		return false
 	}
 
+	if outdated {
+		if rehashed, err := HashPassword(password); err == nil {
+			user.Password = rehashed
+		}
+	}
+
 	return true
 }
 
@@ -86,6 +162,8 @@ func (user *User) AuthLogin(logindata *Login, salt string) bool {
 	return user.Auth(logindata.Login, logindata.Password, salt)
 }
 
+// EncryptPassword - Creates a Legacy Password Hash with the global Salt
+// Deprecated: New Passwords are hashed with HashPassword; it is kept to verify Legacy Hashes
 func EncryptPassword(password string, salt string) string {
 	length := len(password)
 
@@ -0,0 +1,394 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gin-blog/model"
+)
+
+// SEARCHVECTOR - SQL Expression of the Search Vector which weights the Title above the Content
+// It takes the Text Search Configuration twice as Parameter.
+const SEARCHVECTOR string = "setweight(to_tsvector(?::regconfig, coalesce(title, '')), 'A') || " +
+	"setweight(to_tsvector(?::regconfig, coalesce(content, '')), 'B')"
+
+// LIKEESCAPE - Escape Character of the LIKE Patterns
+// It is given as Parameter since MySQL reads a Backslash in a String Literal as Escape as well.
+const LIKEESCAPE string = `\`
+
+// likeEscaper - Escapes the Wildcards of a LIKE Pattern and the Escape Character itself
+var likeEscaper = strings.NewReplacer(LIKEESCAPE, LIKEESCAPE+LIKEESCAPE, "%", LIKEESCAPE+"%", "_", LIKEESCAPE+"_")
+
+//==========================================================================
+// Structure GormArticleRepository Declaration
+
+// GormArticleRepository - Article Storage backed by a GORM Database Connection
+type GormArticleRepository struct {
+	db *gorm.DB
+}
+
+func NewGormArticleRepository(db *gorm.DB) *GormArticleRepository {
+	return &GormArticleRepository{db}
+}
+
+func (repo *GormArticleRepository) GetByID(articleID uint) (*model.Article, error) {
+	var articles []model.Article
+
+	if err := repo.db.Find(&articles, []uint{articleID}).Error; err != nil {
+		return nil, err
+	}
+
+	if len(articles) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("Article (ID: '%d'): Article does not exist!", articleID)}
+	}
+
+	return &articles[0], nil
+}
+
+func (repo *GormArticleRepository) GetByIDs(articleIDs []uint) ([]model.Article, error) {
+	var articles []model.Article
+
+	if len(articleIDs) == 0 {
+		return articles, nil
+	}
+
+	err := repo.db.Find(&articles, articleIDs).Error
+
+	return articles, err
+}
+
+func (repo *GormArticleRepository) GetBySlug(articleSlug string) (*model.Article, error) {
+	var articles []model.Article
+
+	if err := repo.db.Find(&articles, "slug = ?", articleSlug).Error; err != nil {
+		return nil, err
+	}
+
+	if len(articles) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("Article (Slug: '%s'): Article does not exist!", articleSlug)}
+	}
+
+	return &articles[0], nil
+}
+
+func (repo *GormArticleRepository) GetByUserID(userID uint) ([]model.Article, error) {
+	var articles []model.Article
+
+	err := repo.db.Find(&articles, "user_id = ?", userID).Error
+
+	return articles, err
+}
+
+func (repo *GormArticleRepository) List() ([]model.Article, error) {
+	var articles []model.Article
+
+	err := repo.db.Find(&articles).Error
+
+	return articles, err
+}
+
+func (repo *GormArticleRepository) Find(query *ArticleQuery) ([]model.Article, int64, error) {
+	var articles []model.Article
+	var total int64
+
+	if err := repo.filter(query).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	tx := repo.filter(query).Order(query.OrderClause())
+
+	if query.PerPage > 0 {
+		tx = tx.Limit(query.PerPage).Offset(query.Offset())
+	}
+
+	err := tx.Find(&articles).Error
+
+	return articles, total, err
+}
+
+// PublishScheduled - Publishes the scheduled Articles whose Publish Time has come
+func (repo *GormArticleRepository) PublishScheduled(now time.Time) (int64, error) {
+	tx := repo.db.Model(&model.Article{}).
+		Where("status = ? AND published_at <= ?", model.StatusScheduled, now).
+		Update("status", model.StatusPublished)
+
+	return tx.RowsAffected, tx.Error
+}
+
+// CountTags - Counts the Articles matching the Query per Tag
+func (repo *GormArticleRepository) CountTags(query *ArticleQuery) (map[uint]int64, error) {
+	var rows []struct {
+		TagID        uint
+		ArticleCount int64
+	}
+
+	counts := make(map[uint]int64)
+
+	err := repo.filter(query).Select("article_tags.tag_id, COUNT(*) AS article_count").
+		Joins("JOIN article_tags ON article_tags.article_id = articles.id").
+		Group("article_tags.tag_id").Scan(&rows).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.TagID] = row.ArticleCount
+	}
+
+	return counts, nil
+}
+
+// Search - Finds the Articles matching the Search Terms ordered by their Rank
+// The Title is weighted above the Content and the Snippets are taken from the Content.
+func (repo *GormArticleRepository) Search(query *SearchQuery) ([]SearchResult, int64, error) {
+	if !repo.hasSearchVector() {
+		return repo.searchText(query)
+	}
+
+	var hits []struct {
+		ID      uint
+		Rank    float64
+		Snippet string
+	}
+	var results []SearchResult
+	var total int64
+
+	tsquery := "websearch_to_tsquery(?::regconfig, ?)"
+	headline := fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxWords=35, MinWords=15, MaxFragments=2`, model.HIGHLIGHTSTART, model.HIGHLIGHTSTOP)
+
+	matches := func() *gorm.DB {
+		return repo.filter(&query.ArticleQuery).Where("search_vector @@ "+tsquery, SEARCHLANGUAGE, query.Terms)
+	}
+
+	if err := matches().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	tx := matches().Select("articles.id, ts_rank(search_vector, "+tsquery+") AS rank, "+
+		"ts_headline(?::regconfig, coalesce(content, ''), "+tsquery+", ?) AS snippet",
+		SEARCHLANGUAGE, query.Terms, SEARCHLANGUAGE, SEARCHLANGUAGE, query.Terms, headline).
+		Order("rank DESC, articles.id DESC")
+
+	if query.PerPage > 0 {
+		tx = tx.Limit(query.PerPage).Offset(query.Offset())
+	}
+
+	if err := tx.Scan(&hits).Error; err != nil {
+		return nil, 0, err
+	}
+
+	articleIDs := make([]uint, 0, len(hits))
+
+	for _, hit := range hits {
+		articleIDs = append(articleIDs, hit.ID)
+	}
+
+	articles, err := repo.GetByIDs(articleIDs)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	articleMap := make(map[uint]model.Article)
+
+	for _, article := range articles {
+		articleMap[article.ID] = article
+	}
+
+	for _, hit := range hits {
+		if article, ok := articleMap[hit.ID]; ok {
+			results = append(results, SearchResult{article, hit.Rank, hit.Snippet})
+		}
+	}
+
+	return results, total, nil
+}
+
+// searchText - Finds the Articles containing all Search Terms without a Search Vector
+// The Articles are ranked like in Memory since only PostgreSQL provides a Full Text Search.
+func (repo *GormArticleRepository) searchText(query *SearchQuery) ([]SearchResult, int64, error) {
+	var articles []model.Article
+	var results []SearchResult
+
+	terms := searchTerms(query.Terms)
+
+	if len(terms) == 0 {
+		return results, 0, nil
+	}
+
+	tx := repo.filter(&query.ArticleQuery)
+
+	for _, term := range terms {
+		pattern := "%" + likeEscaper.Replace(term) + "%"
+
+		tx = tx.Where("(LOWER(title) LIKE ? ESCAPE ? OR LOWER(content) LIKE ? ESCAPE ?)", pattern, LIKEESCAPE, pattern, LIKEESCAPE)
+	}
+
+	if err := tx.Find(&articles).Error; err != nil {
+		return nil, 0, err
+	}
+
+	for _, article := range articles {
+		if rank := searchRank(&article, terms); rank != 0 {
+			results = append(results, SearchResult{article, rank, searchSnippet(article.Content, terms)})
+		}
+	}
+
+	return rankSearchResults(results, query)
+}
+
+// ReindexSearch - Rebuilds the Search Vectors which were built with another Text Search Configuration
+// Articles without Search Vector are indexed as well.
+func (repo *GormArticleRepository) ReindexSearch() (int64, error) {
+	if !repo.hasSearchVector() {
+		return 0, nil
+	}
+
+	tx := repo.db.Exec("UPDATE articles SET search_vector = "+SEARCHVECTOR+", search_language = ? "+
+		"WHERE search_language IS DISTINCT FROM ?", SEARCHLANGUAGE, SEARCHLANGUAGE, SEARCHLANGUAGE, SEARCHLANGUAGE)
+
+	return tx.RowsAffected, tx.Error
+}
+
+// IsSlugTaken - Checks whether another Article uses the Slug
+// Deleted Articles keep their Slug reserved
+func (repo *GormArticleRepository) IsSlugTaken(articleSlug string, exceptID uint) (bool, error) {
+	var count int64
+
+	err := repo.db.Unscoped().Model(&model.Article{}).Where("slug = ? AND id <> ?", articleSlug, exceptID).Count(&count).Error
+
+	return count != 0, err
+}
+
+func (repo *GormArticleRepository) Create(article *model.Article) error {
+	if err := repo.db.Create(article).Error; err != nil {
+		return translateError(err, slugConflictMessage("Article", article.Slug))
+	}
+
+	return repo.index(article)
+}
+
+func (repo *GormArticleRepository) Save(article *model.Article) error {
+	if err := repo.db.Save(article).Error; err != nil {
+		return translateError(err, slugConflictMessage("Article", article.Slug))
+	}
+
+	return repo.index(article)
+}
+
+func (repo *GormArticleRepository) Delete(article *model.Article) error {
+	return repo.db.Delete(article, article.ID).Error
+}
+
+// ListDeleted - Lists the Articles in the Trash which were deleted before the Time
+// The latest Deletions are listed first
+func (repo *GormArticleRepository) ListDeleted(before time.Time) ([]model.Article, error) {
+	var articles []model.Article
+
+	err := repo.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at DESC").Order("id").Find(&articles).Error
+
+	return articles, err
+}
+
+func (repo *GormArticleRepository) GetDeletedByID(articleID uint) (*model.Article, error) {
+	var articles []model.Article
+
+	if err := repo.db.Unscoped().Where("deleted_at IS NOT NULL").Find(&articles, []uint{articleID}).Error; err != nil {
+		return nil, err
+	}
+
+	if len(articles) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("Article (ID: '%d'): Article is not in the Trash!", articleID)}
+	}
+
+	return &articles[0], nil
+}
+
+func (repo *GormArticleRepository) Restore(article *model.Article) error {
+	err := repo.db.Unscoped().Model(&model.Article{}).Where("id = ?", article.ID).Update("deleted_at", nil).Error
+
+	if err == nil {
+		article.DeletedAt = gorm.DeletedAt{}
+	}
+
+	return err
+}
+
+// Purge - Removes the Article permanently with its Revisions, former Slugs and Media Records
+// Tags and Comments are removed by the Database
+func (repo *GormArticleRepository) Purge(article *model.Article) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("article_id = ?", article.ID).Delete(&model.Media{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("article_id = ?", article.ID).Delete(&model.ArticleRevision{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("entity = ? AND target_id = ?", model.RedirectArticle, article.ID).Delete(&model.SlugRedirect{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(&model.Article{}, article.ID).Error
+	})
+}
+
+// hasSearchVector - Checks whether the Database provides the Full Text Search of PostgreSQL
+func (repo *GormArticleRepository) hasSearchVector() bool {
+	return repo.db.Dialector.Name() == "postgres"
+}
+
+// index - Updates the Search Vector of the stored Article
+func (repo *GormArticleRepository) index(article *model.Article) error {
+	if !repo.hasSearchVector() {
+		return nil
+	}
+
+	return repo.db.Exec("UPDATE articles SET search_vector = "+SEARCHVECTOR+", search_language = ? WHERE id = ?",
+		SEARCHLANGUAGE, SEARCHLANGUAGE, SEARCHLANGUAGE, article.ID).Error
+}
+
+// filter - Builds the Statement selecting the Articles matching the Query
+func (repo *GormArticleRepository) filter(query *ArticleQuery) *gorm.DB {
+	tx := repo.db.Model(&model.Article{})
+
+	if query.UserID != 0 {
+		tx = tx.Where("user_id = ?", query.UserID)
+	}
+
+	if !query.CreatedAfter.IsZero() {
+		tx = tx.Where("created_at >= ?", query.CreatedAfter)
+	}
+
+	if !query.CreatedBefore.IsZero() {
+		tx = tx.Where("created_at < ?", query.CreatedBefore)
+	}
+
+	if query.Status != "" {
+		tx = tx.Where("status = ?", query.Status)
+	}
+
+	if query.TagID != 0 {
+		tx = tx.Where("articles.id IN (SELECT article_id FROM article_tags WHERE tag_id = ?)", query.TagID)
+	}
+
+	if query.CategoryIDs != nil {
+		tx = tx.Where("category_id IN ?", query.CategoryIDs)
+	}
+
+	if query.PublicOnly {
+		if query.OwnerID != 0 {
+			tx = tx.Where("(status = ? OR user_id = ?)", model.StatusPublished, query.OwnerID)
+		} else {
+			tx = tx.Where("status = ?", model.StatusPublished)
+		}
+	}
+
+	return tx
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure GormCategoryRepository Declaration
+
+// GormCategoryRepository - Category Storage backed by a GORM Database Connection
+type GormCategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCategoryRepository(db *gorm.DB) *GormCategoryRepository {
+	return &GormCategoryRepository{db}
+}
+
+func (repo *GormCategoryRepository) GetByID(categoryID uint) (*model.Category, error) {
+	var categories []model.Category
+
+	if err := repo.db.Find(&categories, []uint{categoryID}).Error; err != nil {
+		return nil, err
+	}
+
+	if len(categories) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("Category (ID: '%d'): Category does not exist!", categoryID)}
+	}
+
+	return &categories[0], nil
+}
+
+func (repo *GormCategoryRepository) GetBySlug(categorySlug string) (*model.Category, error) {
+	var categories []model.Category
+
+	if err := repo.db.Find(&categories, "slug = ?", categorySlug).Error; err != nil {
+		return nil, err
+	}
+
+	if len(categories) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("Category (Slug: '%s'): Category does not exist!", categorySlug)}
+	}
+
+	return &categories[0], nil
+}
+
+func (repo *GormCategoryRepository) List() ([]model.Category, error) {
+	var categories []model.Category
+
+	err := repo.db.Order("name, id").Find(&categories).Error
+
+	return categories, err
+}
+
+func (repo *GormCategoryRepository) IsSlugTaken(categorySlug string, exceptID uint) (bool, error) {
+	var count int64
+
+	err := repo.db.Model(&model.Category{}).Where("slug = ? AND id <> ?", categorySlug, exceptID).Count(&count).Error
+
+	return count != 0, err
+}
+
+func (repo *GormCategoryRepository) Create(category *model.Category) error {
+	return translateError(repo.db.Create(category).Error, slugConflictMessage("Category", category.Slug))
+}
+
+func (repo *GormCategoryRepository) Save(category *model.Category) error {
+	return translateError(repo.db.Save(category).Error, slugConflictMessage("Category", category.Slug))
+}
+
+func (repo *GormCategoryRepository) Delete(category *model.Category) error {
+	return repo.db.Delete(category, category.ID).Error
+}
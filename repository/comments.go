@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure GormCommentRepository Declaration
+
+// GormCommentRepository - Comment Storage backed by a GORM Database Connection
+type GormCommentRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCommentRepository(db *gorm.DB) *GormCommentRepository {
+	return &GormCommentRepository{db}
+}
+
+func (repo *GormCommentRepository) GetByID(commentID uint) (*model.Comment, error) {
+	var comments []model.Comment
+
+	if err := repo.db.Find(&comments, []uint{commentID}).Error; err != nil {
+		return nil, err
+	}
+
+	if len(comments) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("Comment (ID: '%d'): Comment does not exist!", commentID)}
+	}
+
+	return &comments[0], nil
+}
+
+// GetByArticleID - Lists the Comments of the Article in the Order of their Creation
+// An empty Status lists the Comments of all Stages
+func (repo *GormCommentRepository) GetByArticleID(articleID uint, status model.CommentStatus) ([]model.Comment, error) {
+	var comments []model.Comment
+
+	tx := repo.db.Where("article_id = ?", articleID)
+
+	if status != "" {
+		tx = tx.Where("status = ?", status)
+	}
+
+	err := tx.Order("created_at, id").Find(&comments).Error
+
+	return comments, err
+}
+
+// CountByArticleIDs - Counts the Comments of the Status for all Articles with one Query
+func (repo *GormCommentRepository) CountByArticleIDs(articleIDs []uint, status model.CommentStatus) (map[uint]int64, error) {
+	var rows []struct {
+		ArticleID    uint
+		CommentCount int64
+	}
+
+	counts := make(map[uint]int64)
+
+	if len(articleIDs) == 0 {
+		return counts, nil
+	}
+
+	err := repo.db.Model(&model.Comment{}).Select("article_id, COUNT(*) AS comment_count").
+		Where("article_id IN ? AND status = ?", articleIDs, status).
+		Group("article_id").Scan(&rows).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		counts[row.ArticleID] = row.CommentCount
+	}
+
+	return counts, nil
+}
+
+func (repo *GormCommentRepository) Create(comment *model.Comment) error {
+	return repo.db.Create(comment).Error
+}
+
+func (repo *GormCommentRepository) Save(comment *model.Comment) error {
+	return repo.db.Save(comment).Error
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//==========================================================================
+// Interface FileStore Declaration
+
+// FileStore - Storage Interface for the Files of the uploaded Media
+// The Keys are relative Paths with Slashes as Separators
+type FileStore interface {
+	Put(key string, data []byte, contentType string) error
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+//==========================================================================
+// Structure LocalFileStore Declaration
+
+// LocalFileStore - File Storage within a Directory of the local Filesystem
+type LocalFileStore struct {
+	directory string
+}
+
+// NewLocalFileStore - Creates the File Storage and its Directory
+func NewLocalFileStore(directory string) (*LocalFileStore, error) {
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return nil, fmt.Errorf("Media Directory '%s': Directory cannot be created! Message: %v", directory, err)
+	}
+
+	return &LocalFileStore{directory}, nil
+}
+
+func (store *LocalFileStore) Put(key string, data []byte, contentType string) error {
+	filePath, err := store.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+
+	// Write to a temporary File first so that incomplete Files are never served
+	temporary := filePath + ".upload"
+
+	if err = ioutil.WriteFile(temporary, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(temporary, filePath)
+}
+
+func (store *LocalFileStore) Open(key string) (io.ReadCloser, error) {
+	filePath, err := store.path(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, &NotFoundError{fmt.Sprintf("File (Key: '%s'): File does not exist!", key)}
+	}
+
+	return file, err
+}
+
+func (store *LocalFileStore) Delete(key string) error {
+	filePath, err := store.path(key)
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// path - Resolves the Key within the Directory and refuses Keys which leave it
+func (store *LocalFileStore) path(key string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(key))
+
+	if key == "" || filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("File (Key: '%s'): Key is invalid!", key)
+	}
+
+	return filepath.Join(store.directory, cleaned), nil
+}
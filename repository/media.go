@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure GormMediaRepository Declaration
+
+// GormMediaRepository - Media Storage backed by a GORM Database Connection
+type GormMediaRepository struct {
+	db *gorm.DB
+}
+
+func NewGormMediaRepository(db *gorm.DB) *GormMediaRepository {
+	return &GormMediaRepository{db}
+}
+
+func (repo *GormMediaRepository) GetByID(mediaID uint) (*model.Media, error) {
+	var media []model.Media
+
+	if err := repo.db.Find(&media, []uint{mediaID}).Error; err != nil {
+		return nil, err
+	}
+
+	if len(media) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("Media (ID: '%d'): Media does not exist!", mediaID)}
+	}
+
+	return &media[0], nil
+}
+
+// GetByArticleID - Lists the Media attached to the Article in the Order of their Upload
+func (repo *GormMediaRepository) GetByArticleID(articleID uint) ([]model.Media, error) {
+	var media []model.Media
+
+	err := repo.db.Where("article_id = ?", articleID).Order("id").Find(&media).Error
+
+	return media, err
+}
+
+// GetByUserID - Lists the Media uploaded by the User in the Order of their Upload
+func (repo *GormMediaRepository) GetByUserID(userID uint) ([]model.Media, error) {
+	var media []model.Media
+
+	err := repo.db.Where("user_id = ?", userID).Order("id").Find(&media).Error
+
+	return media, err
+}
+
+func (repo *GormMediaRepository) Create(media *model.Media) error {
+	return repo.db.Create(media).Error
+}
+
+func (repo *GormMediaRepository) Delete(media *model.Media) error {
+	return repo.db.Delete(media, media.ID).Error
+}
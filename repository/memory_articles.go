@@ -0,0 +1,533 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure MemoryArticleRepository Declaration
+
+// MemoryArticleRepository - Article Storage kept in Memory for Tests and Development
+type MemoryArticleRepository struct {
+	mutex    sync.RWMutex
+	articles map[uint]*model.Article
+	nextID   uint
+	// tags - Tag Assignments for the Tag Filter
+	tags *MemoryTagRepository
+	// media - Media Records which are purged with their Article
+	media *MemoryMediaRepository
+}
+
+func NewMemoryArticleRepository() *MemoryArticleRepository {
+	return &MemoryArticleRepository{articles: make(map[uint]*model.Article), nextID: 1}
+}
+
+func (repo *MemoryArticleRepository) GetByID(articleID uint) (*model.Article, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if article, ok := repo.articles[articleID]; ok && !article.DeletedAt.Valid {
+		match := *article
+
+		return &match, nil
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("Article (ID: '%d'): Article does not exist!", articleID)}
+}
+
+func (repo *MemoryArticleRepository) GetByIDs(articleIDs []uint) ([]model.Article, error) {
+	var articles []model.Article
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, articleID := range articleIDs {
+		if article, ok := repo.articles[articleID]; ok && !article.DeletedAt.Valid {
+			articles = append(articles, *article)
+		}
+	}
+
+	return articles, nil
+}
+
+func (repo *MemoryArticleRepository) GetBySlug(articleSlug string) (*model.Article, error) {
+	for _, article := range repo.sorted() {
+		if article.Slug == articleSlug {
+			return &article, nil
+		}
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("Article (Slug: '%s'): Article does not exist!", articleSlug)}
+}
+
+func (repo *MemoryArticleRepository) GetByUserID(userID uint) ([]model.Article, error) {
+	var articles []model.Article
+
+	for _, article := range repo.sorted() {
+		if article.UserID == userID {
+			articles = append(articles, article)
+		}
+	}
+
+	return articles, nil
+}
+
+func (repo *MemoryArticleRepository) List() ([]model.Article, error) {
+	return repo.sorted(), nil
+}
+
+func (repo *MemoryArticleRepository) Find(query *ArticleQuery) ([]model.Article, int64, error) {
+	var articles []model.Article
+
+	for _, article := range repo.sorted() {
+		if query.Matches(&article) && repo.isTagged(article.ID, query.TagID) {
+			articles = append(articles, article)
+		}
+	}
+
+	sortArticles(articles, query.Sort)
+
+	total := int64(len(articles))
+
+	if query.PerPage > 0 {
+		start := query.Offset()
+
+		if start > len(articles) {
+			start = len(articles)
+		}
+
+		end := start + query.PerPage
+
+		if end > len(articles) {
+			end = len(articles)
+		}
+
+		articles = articles[start:end]
+	}
+
+	return articles, total, nil
+}
+
+func (repo *MemoryArticleRepository) Create(article *model.Article) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if article.ID == 0 {
+		article.ID = repo.nextID
+	}
+
+	if _, ok := repo.articles[article.ID]; ok {
+		return fmt.Errorf("Article (ID: '%d'): Article does already exist!", article.ID)
+	}
+
+	if repo.isSlugTaken(article.Slug, article.ID) {
+		return &ConflictError{slugConflictMessage("Article", article.Slug)}
+	}
+
+	if article.ID >= repo.nextID {
+		repo.nextID = article.ID + 1
+	}
+
+	now := time.Now()
+
+	article.CreatedAt = now
+	article.UpdatedAt = now
+
+	if article.Status == "" {
+		article.Status = model.StatusDraft
+	}
+
+	stored := *article
+
+	repo.articles[article.ID] = &stored
+
+	return nil
+}
+
+func (repo *MemoryArticleRepository) Save(article *model.Article) error {
+	if article.ID == 0 {
+		return repo.Create(article)
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.isSlugTaken(article.Slug, article.ID) {
+		return &ConflictError{slugConflictMessage("Article", article.Slug)}
+	}
+
+	article.UpdatedAt = time.Now()
+
+	stored := *article
+
+	repo.articles[article.ID] = &stored
+
+	return nil
+}
+
+func (repo *MemoryArticleRepository) Delete(article *model.Article) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if stored, ok := repo.articles[article.ID]; ok && !stored.DeletedAt.Valid {
+		stored.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	}
+
+	return nil
+}
+
+// PublishScheduled - Publishes the scheduled Articles whose Publish Time has come
+func (repo *MemoryArticleRepository) PublishScheduled(now time.Time) (int64, error) {
+	var published int64
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for _, article := range repo.articles {
+		if !article.DeletedAt.Valid && article.Status == model.StatusScheduled && article.PublishedAt != nil && !article.PublishedAt.After(now) {
+			article.Status = model.StatusPublished
+			article.UpdatedAt = now
+
+			published++
+		}
+	}
+
+	return published, nil
+}
+
+// CountTags - Counts the Articles matching the Query per Tag
+func (repo *MemoryArticleRepository) CountTags(query *ArticleQuery) (map[uint]int64, error) {
+	counts := make(map[uint]int64)
+
+	if repo.tags == nil {
+		return counts, nil
+	}
+
+	for _, article := range repo.sorted() {
+		if query.Matches(&article) && repo.isTagged(article.ID, query.TagID) {
+			for _, tagID := range repo.tags.ArticleTagIDs(article.ID) {
+				counts[tagID]++
+			}
+		}
+	}
+
+	return counts, nil
+}
+
+// Search - Finds the Articles containing all Search Terms ordered by their Rank
+// Matches in the Title count twice as much as Matches in the Content.
+func (repo *MemoryArticleRepository) Search(query *SearchQuery) ([]SearchResult, int64, error) {
+	var results []SearchResult
+
+	terms := searchTerms(query.Terms)
+
+	for _, article := range repo.sorted() {
+		if len(terms) == 0 || !query.Matches(&article) || !repo.isTagged(article.ID, query.TagID) {
+			continue
+		}
+
+		if rank := searchRank(&article, terms); rank != 0 {
+			results = append(results, SearchResult{article, rank, searchSnippet(article.Content, terms)})
+		}
+	}
+
+	return rankSearchResults(results, query)
+}
+
+// ReindexSearch - Articles in Memory are searched without Search Vectors
+func (repo *MemoryArticleRepository) ReindexSearch() (int64, error) {
+	return 0, nil
+}
+
+// searchTerms - Splits the Search into lowercase Words
+func searchTerms(search string) []string {
+	return strings.FieldsFunc(strings.ToLower(search), func(char rune) bool {
+		return !unicode.IsLetter(char) && !unicode.IsDigit(char)
+	})
+}
+
+// searchRank - Counts the Matches of the Search Terms where a Match in the Title counts twice
+// Articles missing one of the Terms are ranked 0.
+func searchRank(article *model.Article, terms []string) float64 {
+	title := strings.ToLower(article.Title)
+	content := strings.ToLower(article.Content)
+	rank := 0.0
+
+	for _, term := range terms {
+		matches := 2*strings.Count(title, term) + strings.Count(content, term)
+
+		if matches == 0 {
+			return 0
+		}
+
+		rank += float64(matches)
+	}
+
+	return rank
+}
+
+// rankSearchResults - Orders the Search Results by their Rank and cuts out the Page of the Query
+// It returns the Page with the Total of all Search Results.
+func rankSearchResults(results []SearchResult, query *SearchQuery) ([]SearchResult, int64, error) {
+	sort.SliceStable(results, func(left, right int) bool {
+		if results[left].Rank != results[right].Rank {
+			return results[left].Rank > results[right].Rank
+		}
+
+		return results[left].Article.ID > results[right].Article.ID
+	})
+
+	total := int64(len(results))
+
+	if query.PerPage > 0 {
+		start := query.Offset()
+
+		if start > len(results) {
+			start = len(results)
+		}
+
+		end := start + query.PerPage
+
+		if end > len(results) {
+			end = len(results)
+		}
+
+		results = results[start:end]
+	}
+
+	return results, total, nil
+}
+
+// searchSnippet - Cuts the Words around the first Match out of the Content and marks the matching Words
+func searchSnippet(content string, terms []string) string {
+	words := strings.Fields(content)
+	first := -1
+
+	isMatch := func(word string) bool {
+		for _, term := range terms {
+			if strings.Contains(strings.ToLower(word), term) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for idx, word := range words {
+		if isMatch(word) {
+			first = idx
+
+			break
+		}
+	}
+
+	if first == -1 {
+		first = 0
+	}
+
+	start := first - 10
+
+	if start < 0 {
+		start = 0
+	}
+
+	end := start + 35
+
+	if end > len(words) {
+		end = len(words)
+	}
+
+	snippet := make([]string, 0, end-start)
+
+	for _, word := range words[start:end] {
+		if isMatch(word) {
+			word = model.HIGHLIGHTSTART + word + model.HIGHLIGHTSTOP
+		}
+
+		snippet = append(snippet, word)
+	}
+
+	return strings.Join(snippet, " ")
+}
+
+// isTagged - Checks the Tag Filter where no Tag matches all Articles
+func (repo *MemoryArticleRepository) isTagged(articleID uint, tagID uint) bool {
+	if tagID == 0 {
+		return true
+	}
+
+	return repo.tags != nil && repo.tags.IsTagged(articleID, tagID)
+}
+
+// ListDeleted - Lists the Articles in the Trash which were deleted before the Time
+// The latest Deletions are listed first
+func (repo *MemoryArticleRepository) ListDeleted(before time.Time) ([]model.Article, error) {
+	var articles []model.Article
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, article := range repo.articles {
+		if article.DeletedAt.Valid && article.DeletedAt.Time.Before(before) {
+			articles = append(articles, *article)
+		}
+	}
+
+	sort.Slice(articles, func(i, j int) bool {
+		if !articles[i].DeletedAt.Time.Equal(articles[j].DeletedAt.Time) {
+			return articles[i].DeletedAt.Time.After(articles[j].DeletedAt.Time)
+		}
+
+		return articles[i].ID < articles[j].ID
+	})
+
+	return articles, nil
+}
+
+func (repo *MemoryArticleRepository) GetDeletedByID(articleID uint) (*model.Article, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if article, ok := repo.articles[articleID]; ok && article.DeletedAt.Valid {
+		match := *article
+
+		return &match, nil
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("Article (ID: '%d'): Article is not in the Trash!", articleID)}
+}
+
+func (repo *MemoryArticleRepository) Restore(article *model.Article) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if stored, ok := repo.articles[article.ID]; ok {
+		stored.DeletedAt = gorm.DeletedAt{}
+	}
+
+	article.DeletedAt = gorm.DeletedAt{}
+
+	return nil
+}
+
+// Purge - Removes the Article permanently with the Media Records
+func (repo *MemoryArticleRepository) Purge(article *model.Article) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.media != nil {
+		repo.media.deleteByArticleID(article.ID)
+	}
+
+	delete(repo.articles, article.ID)
+
+	return nil
+}
+
+// countByUserID - Counts the Articles of the User in the Blog and in the Trash
+func (repo *MemoryArticleRepository) countByUserID(userID uint) int {
+	var count int
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, stored := range repo.articles {
+		if stored.UserID == userID {
+			count++
+		}
+	}
+
+	return count
+}
+
+// IsSlugTaken - Checks whether another Article uses the Slug
+// Deleted Articles keep their Slug reserved
+func (repo *MemoryArticleRepository) IsSlugTaken(articleSlug string, exceptID uint) (bool, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	return repo.isSlugTaken(articleSlug, exceptID), nil
+}
+
+// isSlugTaken - The Caller must hold the Mutex
+func (repo *MemoryArticleRepository) isSlugTaken(articleSlug string, exceptID uint) bool {
+	for _, stored := range repo.articles {
+		if stored.ID != exceptID && stored.Slug == articleSlug {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sorted - Returns copies of all active Articles ordered by their ID
+func (repo *MemoryArticleRepository) sorted() []model.Article {
+	var articles []model.Article
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, article := range repo.articles {
+		if !article.DeletedAt.Valid {
+			articles = append(articles, *article)
+		}
+	}
+
+	sort.Slice(articles, func(i, j int) bool { return articles[i].ID < articles[j].ID })
+
+	return articles
+}
+
+// sortArticles - Orders the Articles like the SQL Order Clause of the Sort Parameter
+func sortArticles(articles []model.Article, sortParam string) {
+	field, descending, err := ParseSort(sortParam)
+
+	if err != nil || field == "" {
+		field = "id"
+	}
+
+	less := func(left *model.Article, right *model.Article) bool {
+		switch field {
+		case "created_at":
+			if !left.CreatedAt.Equal(right.CreatedAt) {
+				return left.CreatedAt.Before(right.CreatedAt)
+			}
+		case "updated_at":
+			if !left.UpdatedAt.Equal(right.UpdatedAt) {
+				return left.UpdatedAt.Before(right.UpdatedAt)
+			}
+		case "published_at":
+			// Unpublished Articles follow the published Articles like NULL Values in the Database
+			if left.PublishedAt == nil || right.PublishedAt == nil {
+				if (left.PublishedAt == nil) != (right.PublishedAt == nil) {
+					return right.PublishedAt == nil
+				}
+			} else if !left.PublishedAt.Equal(*right.PublishedAt) {
+				return left.PublishedAt.Before(*right.PublishedAt)
+			}
+		case "title":
+			if left.Title != right.Title {
+				return left.Title < right.Title
+			}
+		}
+
+		return left.ID < right.ID
+	}
+
+	sort.SliceStable(articles, func(i, j int) bool {
+		if descending {
+			return less(&articles[j], &articles[i])
+		}
+
+		return less(&articles[i], &articles[j])
+	})
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure MemoryCategoryRepository Declaration
+
+// MemoryCategoryRepository - Category Storage kept in Memory for Tests and Development
+type MemoryCategoryRepository struct {
+	mutex      sync.RWMutex
+	categories map[uint]*model.Category
+	nextID     uint
+}
+
+func NewMemoryCategoryRepository() *MemoryCategoryRepository {
+	return &MemoryCategoryRepository{categories: make(map[uint]*model.Category), nextID: 1}
+}
+
+func (repo *MemoryCategoryRepository) GetByID(categoryID uint) (*model.Category, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if category, ok := repo.categories[categoryID]; ok {
+		match := *category
+
+		return &match, nil
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("Category (ID: '%d'): Category does not exist!", categoryID)}
+}
+
+func (repo *MemoryCategoryRepository) GetBySlug(categorySlug string) (*model.Category, error) {
+	for _, category := range repo.sorted() {
+		if category.Slug == categorySlug {
+			return &category, nil
+		}
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("Category (Slug: '%s'): Category does not exist!", categorySlug)}
+}
+
+func (repo *MemoryCategoryRepository) List() ([]model.Category, error) {
+	return repo.sorted(), nil
+}
+
+func (repo *MemoryCategoryRepository) IsSlugTaken(categorySlug string, exceptID uint) (bool, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	return repo.isSlugTaken(categorySlug, exceptID), nil
+}
+
+func (repo *MemoryCategoryRepository) Create(category *model.Category) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.isSlugTaken(category.Slug, 0) {
+		return &ConflictError{slugConflictMessage("Category", category.Slug)}
+	}
+
+	category.ID = repo.nextID
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = category.CreatedAt
+
+	repo.nextID++
+
+	stored := *category
+
+	repo.categories[category.ID] = &stored
+
+	return nil
+}
+
+func (repo *MemoryCategoryRepository) Save(category *model.Category) error {
+	if category.ID == 0 {
+		return repo.Create(category)
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.isSlugTaken(category.Slug, category.ID) {
+		return &ConflictError{slugConflictMessage("Category", category.Slug)}
+	}
+
+	category.UpdatedAt = time.Now()
+
+	stored := *category
+
+	repo.categories[category.ID] = &stored
+
+	return nil
+}
+
+func (repo *MemoryCategoryRepository) Delete(category *model.Category) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	delete(repo.categories, category.ID)
+
+	return nil
+}
+
+// isSlugTaken - The Caller must hold the Mutex
+func (repo *MemoryCategoryRepository) isSlugTaken(categorySlug string, exceptID uint) bool {
+	for _, stored := range repo.categories {
+		if stored.ID != exceptID && stored.Slug == categorySlug {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sorted - Returns copies of all Categories ordered by their Name
+func (repo *MemoryCategoryRepository) sorted() []model.Category {
+	var categories []model.Category
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, category := range repo.categories {
+		categories = append(categories, *category)
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Name != categories[j].Name {
+			return categories[i].Name < categories[j].Name
+		}
+
+		return categories[i].ID < categories[j].ID
+	})
+
+	return categories
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure MemoryCommentRepository Declaration
+
+// MemoryCommentRepository - Comment Storage kept in Memory for Tests and Development
+type MemoryCommentRepository struct {
+	mutex    sync.RWMutex
+	comments map[uint]*model.Comment
+	nextID   uint
+}
+
+func NewMemoryCommentRepository() *MemoryCommentRepository {
+	return &MemoryCommentRepository{comments: make(map[uint]*model.Comment), nextID: 1}
+}
+
+func (repo *MemoryCommentRepository) GetByID(commentID uint) (*model.Comment, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if comment, ok := repo.comments[commentID]; ok {
+		match := *comment
+
+		return &match, nil
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("Comment (ID: '%d'): Comment does not exist!", commentID)}
+}
+
+// GetByArticleID - Lists the Comments of the Article in the Order of their Creation
+// An empty Status lists the Comments of all Stages
+func (repo *MemoryCommentRepository) GetByArticleID(articleID uint, status model.CommentStatus) ([]model.Comment, error) {
+	var comments []model.Comment
+
+	for _, comment := range repo.sorted() {
+		if comment.ArticleID == articleID && (status == "" || comment.Status == status) {
+			comments = append(comments, comment)
+		}
+	}
+
+	return comments, nil
+}
+
+// CountByArticleIDs - Counts the Comments of the Status for all Articles
+func (repo *MemoryCommentRepository) CountByArticleIDs(articleIDs []uint, status model.CommentStatus) (map[uint]int64, error) {
+	counts := make(map[uint]int64)
+
+	for _, comment := range repo.sorted() {
+		if comment.Status != status {
+			continue
+		}
+
+		for _, articleID := range articleIDs {
+			if comment.ArticleID == articleID {
+				counts[articleID]++
+
+				break
+			}
+		}
+	}
+
+	return counts, nil
+}
+
+func (repo *MemoryCommentRepository) Create(comment *model.Comment) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	comment.ID = repo.nextID
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = comment.CreatedAt
+
+	if comment.Status == "" {
+		comment.Status = model.CommentPending
+	}
+
+	repo.nextID++
+
+	stored := *comment
+
+	repo.comments[comment.ID] = &stored
+
+	return nil
+}
+
+func (repo *MemoryCommentRepository) Save(comment *model.Comment) error {
+	if comment.ID == 0 {
+		return repo.Create(comment)
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	comment.UpdatedAt = time.Now()
+
+	stored := *comment
+
+	repo.comments[comment.ID] = &stored
+
+	return nil
+}
+
+// sorted - Returns copies of all Comments ordered by their ID
+func (repo *MemoryCommentRepository) sorted() []model.Comment {
+	var comments []model.Comment
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, comment := range repo.comments {
+		comments = append(comments, *comment)
+	}
+
+	sort.Slice(comments, func(i, j int) bool { return comments[i].ID < comments[j].ID })
+
+	return comments
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+)
+
+//==========================================================================
+// Structure MemoryFileStore Declaration
+
+// MemoryFileStore - File Storage kept in Memory for Tests and Development
+type MemoryFileStore struct {
+	mutex sync.RWMutex
+	files map[string][]byte
+}
+
+func NewMemoryFileStore() *MemoryFileStore {
+	return &MemoryFileStore{files: make(map[string][]byte)}
+}
+
+func (store *MemoryFileStore) Put(key string, data []byte, contentType string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.files[key] = append([]byte(nil), data...)
+
+	return nil
+}
+
+func (store *MemoryFileStore) Open(key string) (io.ReadCloser, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	data, ok := store.files[key]
+
+	if !ok {
+		return nil, &NotFoundError{fmt.Sprintf("File (Key: '%s'): File does not exist!", key)}
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (store *MemoryFileStore) Delete(key string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	delete(store.files, key)
+
+	return nil
+}
+
+// Count - Number of stored Files
+func (store *MemoryFileStore) Count() int {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return len(store.files)
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure MemoryMediaRepository Declaration
+
+// MemoryMediaRepository - Media Storage kept in Memory for Tests and Development
+type MemoryMediaRepository struct {
+	mutex  sync.RWMutex
+	media  map[uint]*model.Media
+	nextID uint
+}
+
+func NewMemoryMediaRepository() *MemoryMediaRepository {
+	return &MemoryMediaRepository{media: make(map[uint]*model.Media), nextID: 1}
+}
+
+func (repo *MemoryMediaRepository) GetByID(mediaID uint) (*model.Media, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if media, ok := repo.media[mediaID]; ok {
+		match := *media
+
+		return &match, nil
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("Media (ID: '%d'): Media does not exist!", mediaID)}
+}
+
+// GetByArticleID - Lists the Media attached to the Article in the Order of their Upload
+func (repo *MemoryMediaRepository) GetByArticleID(articleID uint) ([]model.Media, error) {
+	var media []model.Media
+
+	for _, stored := range repo.sorted() {
+		if stored.ArticleID != nil && *stored.ArticleID == articleID {
+			media = append(media, stored)
+		}
+	}
+
+	return media, nil
+}
+
+// GetByUserID - Lists the Media uploaded by the User in the Order of their Upload
+func (repo *MemoryMediaRepository) GetByUserID(userID uint) ([]model.Media, error) {
+	var media []model.Media
+
+	for _, stored := range repo.sorted() {
+		if stored.UserID == userID {
+			media = append(media, stored)
+		}
+	}
+
+	return media, nil
+}
+
+func (repo *MemoryMediaRepository) Create(media *model.Media) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	media.ID = repo.nextID
+	media.CreatedAt = time.Now()
+	media.UpdatedAt = media.CreatedAt
+
+	repo.nextID++
+
+	stored := *media
+
+	repo.media[media.ID] = &stored
+
+	return nil
+}
+
+func (repo *MemoryMediaRepository) Delete(media *model.Media) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	delete(repo.media, media.ID)
+
+	return nil
+}
+
+// deleteByUserID - Removes the Media uploaded by the User
+func (repo *MemoryMediaRepository) deleteByUserID(userID uint) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for mediaID, stored := range repo.media {
+		if stored.UserID == userID {
+			delete(repo.media, mediaID)
+		}
+	}
+}
+
+// deleteByArticleID - Removes the Media attached to the Article
+func (repo *MemoryMediaRepository) deleteByArticleID(articleID uint) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	for mediaID, stored := range repo.media {
+		if stored.ArticleID != nil && *stored.ArticleID == articleID {
+			delete(repo.media, mediaID)
+		}
+	}
+}
+
+// sorted - Returns copies of all Media ordered by their ID
+func (repo *MemoryMediaRepository) sorted() []model.Media {
+	var media []model.Media
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, stored := range repo.media {
+		media = append(media, *stored)
+	}
+
+	sort.Slice(media, func(i, j int) bool { return media[i].ID < media[j].ID })
+
+	return media
+}
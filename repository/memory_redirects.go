@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure MemoryRedirectRepository Declaration
+
+// MemoryRedirectRepository - Slug Redirect Storage kept in Memory for Tests and Development
+type MemoryRedirectRepository struct {
+	mutex     sync.RWMutex
+	redirects map[string]*model.SlugRedirect
+	nextID    uint
+}
+
+func NewMemoryRedirectRepository() *MemoryRedirectRepository {
+	return &MemoryRedirectRepository{redirects: make(map[string]*model.SlugRedirect), nextID: 1}
+}
+
+func (repo *MemoryRedirectRepository) GetBySlug(entity string, slug string) (*model.SlugRedirect, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if redirect, ok := repo.redirects[redirectKey(entity, slug)]; ok {
+		match := *redirect
+
+		return &match, nil
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("Redirect (Slug: '%s'): Slug was never used by a %s", slug, entity)}
+}
+
+// Save - Records the Redirect or points an existing Redirect of the Slug to the new Target
+func (repo *MemoryRedirectRepository) Save(redirect *model.SlugRedirect) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	key := redirectKey(redirect.Entity, redirect.Slug)
+
+	if stored, ok := repo.redirects[key]; ok {
+		redirect.ID = stored.ID
+	} else {
+		redirect.ID = repo.nextID
+		repo.nextID++
+	}
+
+	redirect.CreatedAt = time.Now()
+
+	stored := *redirect
+
+	repo.redirects[key] = &stored
+
+	return nil
+}
+
+func (repo *MemoryRedirectRepository) DeleteBySlug(entity string, slug string) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	delete(repo.redirects, redirectKey(entity, slug))
+
+	return nil
+}
+
+func redirectKey(entity string, slug string) string {
+	return entity + "/" + slug
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure MemoryRevisionRepository Declaration
+
+// MemoryRevisionRepository - Article Revision Storage kept in Memory for Tests and Development
+type MemoryRevisionRepository struct {
+	mutex     sync.RWMutex
+	revisions map[uint][]model.ArticleRevision
+	nextID    uint
+}
+
+func NewMemoryRevisionRepository() *MemoryRevisionRepository {
+	return &MemoryRevisionRepository{revisions: make(map[uint][]model.ArticleRevision), nextID: 1}
+}
+
+func (repo *MemoryRevisionRepository) GetByArticleID(articleID uint) ([]model.ArticleRevision, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	return append([]model.ArticleRevision(nil), repo.revisions[articleID]...), nil
+}
+
+func (repo *MemoryRevisionRepository) GetByNumber(articleID uint, number uint) (*model.ArticleRevision, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	// Revision Numbers start at 1 without Gaps
+	if revisions := repo.revisions[articleID]; number > 0 && int(number) <= len(revisions) {
+		match := revisions[number-1]
+
+		return &match, nil
+	}
+
+	return nil, &NotFoundError{revisionNotFoundMessage(articleID, number)}
+}
+
+// Create - Stores the Revision with the next Revision Number of its Article
+func (repo *MemoryRevisionRepository) Create(revision *model.ArticleRevision) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	revision.ID = repo.nextID
+	revision.Number = uint(len(repo.revisions[revision.ArticleID]) + 1)
+	revision.CreatedAt = time.Now()
+
+	repo.nextID++
+
+	repo.revisions[revision.ArticleID] = append(repo.revisions[revision.ArticleID], *revision)
+
+	return nil
+}
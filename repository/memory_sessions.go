@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure MemorySessionRepository Declaration
+
+// MemorySessionRepository - Session Storage kept in Memory for Tests and Development
+type MemorySessionRepository struct {
+	mutex    sync.RWMutex
+	sessions map[uint]*model.Session
+	nextID   uint
+}
+
+func NewMemorySessionRepository() *MemorySessionRepository {
+	return &MemorySessionRepository{sessions: make(map[uint]*model.Session), nextID: 1}
+}
+
+func (repo *MemorySessionRepository) GetByID(sessionID uint) (*model.Session, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if session, ok := repo.sessions[sessionID]; ok {
+		match := *session
+
+		return &match, nil
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("Session (ID: '%d'): Session does not exist!", sessionID)}
+}
+
+func (repo *MemorySessionRepository) GetByRefreshHash(refreshHash string) (*model.Session, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, session := range repo.sessions {
+		if session.RefreshHash == refreshHash {
+			match := *session
+
+			return &match, nil
+		}
+	}
+
+	return nil, &NotFoundError{"Session: Refresh Token is unknown!"}
+}
+
+func (repo *MemorySessionRepository) Create(session *model.Session) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if session.ID == 0 {
+		session.ID = repo.nextID
+	}
+
+	if _, ok := repo.sessions[session.ID]; ok {
+		return fmt.Errorf("Session (ID: '%d'): Session does already exist!", session.ID)
+	}
+
+	if session.ID >= repo.nextID {
+		repo.nextID = session.ID + 1
+	}
+
+	now := time.Now()
+
+	session.CreatedAt = now
+	session.UpdatedAt = now
+
+	stored := *session
+
+	repo.sessions[session.ID] = &stored
+
+	return nil
+}
+
+func (repo *MemorySessionRepository) Save(session *model.Session) error {
+	if session.ID == 0 {
+		return repo.Create(session)
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	session.UpdatedAt = time.Now()
+
+	stored := *session
+
+	repo.sessions[session.ID] = &stored
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure MemoryTagRepository Declaration
+
+// MemoryTagRepository - Tag Storage kept in Memory for Tests and Development
+// It also keeps the Assignments of the Tags to the Articles
+type MemoryTagRepository struct {
+	mutex       sync.RWMutex
+	tags        map[uint]*model.Tag
+	articleTags map[uint][]uint
+	nextID      uint
+}
+
+func NewMemoryTagRepository() *MemoryTagRepository {
+	return &MemoryTagRepository{tags: make(map[uint]*model.Tag), articleTags: make(map[uint][]uint), nextID: 1}
+}
+
+func (repo *MemoryTagRepository) GetBySlug(tagSlug string) (*model.Tag, error) {
+	for _, tag := range repo.sorted() {
+		if tag.Slug == tagSlug {
+			return &tag, nil
+		}
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("Tag (Slug: '%s'): Tag does not exist!", tagSlug)}
+}
+
+func (repo *MemoryTagRepository) GetBySlugs(tagSlugs []string) ([]model.Tag, error) {
+	var tags []model.Tag
+
+	for _, tag := range repo.sorted() {
+		for _, tagSlug := range tagSlugs {
+			if tag.Slug == tagSlug {
+				tags = append(tags, tag)
+
+				break
+			}
+		}
+	}
+
+	return tags, nil
+}
+
+// GetByArticleIDs - Looks up the Tags of all Articles
+func (repo *MemoryTagRepository) GetByArticleIDs(articleIDs []uint) (map[uint][]model.Tag, error) {
+	tagMap := make(map[uint][]model.Tag)
+
+	for _, tag := range repo.sorted() {
+		for _, articleID := range articleIDs {
+			if repo.IsTagged(articleID, tag.ID) {
+				tagMap[articleID] = append(tagMap[articleID], tag)
+			}
+		}
+	}
+
+	return tagMap, nil
+}
+
+func (repo *MemoryTagRepository) List() ([]model.Tag, error) {
+	return repo.sorted(), nil
+}
+
+func (repo *MemoryTagRepository) IsSlugTaken(tagSlug string, exceptID uint) (bool, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	return repo.isSlugTaken(tagSlug, exceptID), nil
+}
+
+func (repo *MemoryTagRepository) Create(tag *model.Tag) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.isSlugTaken(tag.Slug, 0) {
+		return &ConflictError{slugConflictMessage("Tag", tag.Slug)}
+	}
+
+	tag.ID = repo.nextID
+	tag.CreatedAt = time.Now()
+	tag.UpdatedAt = tag.CreatedAt
+
+	repo.nextID++
+
+	stored := *tag
+
+	repo.tags[tag.ID] = &stored
+
+	return nil
+}
+
+func (repo *MemoryTagRepository) Save(tag *model.Tag) error {
+	if tag.ID == 0 {
+		return repo.Create(tag)
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.isSlugTaken(tag.Slug, tag.ID) {
+		return &ConflictError{slugConflictMessage("Tag", tag.Slug)}
+	}
+
+	tag.UpdatedAt = time.Now()
+
+	stored := *tag
+
+	repo.tags[tag.ID] = &stored
+
+	return nil
+}
+
+// Delete - Removes the Tag together with its Assignments to Articles
+func (repo *MemoryTagRepository) Delete(tag *model.Tag) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	delete(repo.tags, tag.ID)
+
+	for articleID, tagIDs := range repo.articleTags {
+		repo.articleTags[articleID] = removeID(tagIDs, tag.ID)
+	}
+
+	return nil
+}
+
+// SetArticleTags - Replaces the Tags assigned to the Article
+func (repo *MemoryTagRepository) SetArticleTags(articleID uint, tagIDs []uint) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	repo.articleTags[articleID] = append([]uint(nil), tagIDs...)
+
+	return nil
+}
+
+// IsTagged - Checks whether the Tag is assigned to the Article
+func (repo *MemoryTagRepository) IsTagged(articleID uint, tagID uint) bool {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, assigned := range repo.articleTags[articleID] {
+		if assigned == tagID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ArticleTagIDs - The IDs of the Tags assigned to the Article
+func (repo *MemoryTagRepository) ArticleTagIDs(articleID uint) []uint {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	return append([]uint(nil), repo.articleTags[articleID]...)
+}
+
+// isSlugTaken - The Caller must hold the Mutex
+func (repo *MemoryTagRepository) isSlugTaken(tagSlug string, exceptID uint) bool {
+	for _, stored := range repo.tags {
+		if stored.ID != exceptID && stored.Slug == tagSlug {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sorted - Returns copies of all Tags ordered by their Name
+func (repo *MemoryTagRepository) sorted() []model.Tag {
+	var tags []model.Tag
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, tag := range repo.tags {
+		tags = append(tags, *tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Name != tags[j].Name {
+			return tags[i].Name < tags[j].Name
+		}
+
+		return tags[i].ID < tags[j].ID
+	})
+
+	return tags
+}
+
+func removeID(ids []uint, removed uint) []uint {
+	var kept []uint
+
+	for _, id := range ids {
+		if id != removed {
+			kept = append(kept, id)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure MemoryUserRepository Declaration
+
+// MemoryUserRepository - User Storage kept in Memory for Tests and Development
+type MemoryUserRepository struct {
+	mutex  sync.RWMutex
+	users  map[uint]*model.User
+	nextID uint
+	// articles - Articles which keep their Author from being purged
+	articles *MemoryArticleRepository
+	// media - Media Records which are purged with their Uploader
+	media *MemoryMediaRepository
+}
+
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{users: make(map[uint]*model.User), nextID: 1}
+}
+
+func (repo *MemoryUserRepository) GetByID(userID uint) (*model.User, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if user, ok := repo.users[userID]; ok && !user.DeletedAt.Valid {
+		match := *user
+
+		return &match, nil
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("User (ID: '%d'): User does not exist!", userID)}
+}
+
+func (repo *MemoryUserRepository) GetByIDs(userIDs []uint) ([]model.User, error) {
+	var users []model.User
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, userID := range userIDs {
+		if user, ok := repo.users[userID]; ok && !user.DeletedAt.Valid {
+			users = append(users, *user)
+		}
+	}
+
+	return users, nil
+}
+
+func (repo *MemoryUserRepository) GetByLogin(userLogin string) (*model.User, error) {
+	for _, user := range repo.sorted() {
+		if user.Login == userLogin {
+			return &user, nil
+		}
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("User (Login: '%s'): User does not exist", userLogin)}
+}
+
+func (repo *MemoryUserRepository) GetBySlug(userSlug string) (*model.User, error) {
+	for _, user := range repo.sorted() {
+		if user.Slug == userSlug {
+			return &user, nil
+		}
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("User (Slug: '%s'): User does not exist", userSlug)}
+}
+
+func (repo *MemoryUserRepository) List() ([]model.User, error) {
+	return repo.sorted(), nil
+}
+
+func (repo *MemoryUserRepository) Create(user *model.User) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if user.ID == 0 {
+		user.ID = repo.nextID
+	}
+
+	if _, ok := repo.users[user.ID]; ok {
+		return fmt.Errorf("User (ID: '%d'): User does already exist!", user.ID)
+	}
+
+	if repo.isSlugTaken(user.Slug, user.ID) {
+		return &ConflictError{slugConflictMessage("User", user.Slug)}
+	}
+
+	if user.ID >= repo.nextID {
+		repo.nextID = user.ID + 1
+	}
+
+	now := time.Now()
+
+	user.CreatedAt = now
+	user.UpdatedAt = now
+
+	stored := *user
+
+	repo.users[user.ID] = &stored
+
+	return nil
+}
+
+func (repo *MemoryUserRepository) Save(user *model.User) error {
+	if user.ID == 0 {
+		return repo.Create(user)
+	}
+
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.isSlugTaken(user.Slug, user.ID) {
+		return &ConflictError{slugConflictMessage("User", user.Slug)}
+	}
+
+	user.UpdatedAt = time.Now()
+
+	stored := *user
+
+	repo.users[user.ID] = &stored
+
+	return nil
+}
+
+func (repo *MemoryUserRepository) Delete(user *model.User) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if stored, ok := repo.users[user.ID]; ok && !stored.DeletedAt.Valid {
+		stored.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	}
+
+	return nil
+}
+
+// ListDeleted - Lists the Users in the Trash which were deleted before the Time
+// The latest Deletions are listed first
+func (repo *MemoryUserRepository) ListDeleted(before time.Time) ([]model.User, error) {
+	var users []model.User
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, user := range repo.users {
+		if user.DeletedAt.Valid && user.DeletedAt.Time.Before(before) {
+			users = append(users, *user)
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].DeletedAt.Time.Equal(users[j].DeletedAt.Time) {
+			return users[i].DeletedAt.Time.After(users[j].DeletedAt.Time)
+		}
+
+		return users[i].ID < users[j].ID
+	})
+
+	return users, nil
+}
+
+func (repo *MemoryUserRepository) GetDeletedByID(userID uint) (*model.User, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	if user, ok := repo.users[userID]; ok && user.DeletedAt.Valid {
+		match := *user
+
+		return &match, nil
+	}
+
+	return nil, &NotFoundError{fmt.Sprintf("User (ID: '%d'): User is not in the Trash!", userID)}
+}
+
+func (repo *MemoryUserRepository) Restore(user *model.User) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if stored, ok := repo.users[user.ID]; ok {
+		stored.DeletedAt = gorm.DeletedAt{}
+	}
+
+	user.DeletedAt = gorm.DeletedAt{}
+
+	return nil
+}
+
+// Purge - Removes the User permanently with the Media Records
+// Users who still have Articles in the Blog or in the Trash cannot be purged
+func (repo *MemoryUserRepository) Purge(user *model.User) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	if repo.articles != nil {
+		if count := repo.articles.countByUserID(user.ID); count != 0 {
+			return &ConflictError{fmt.Sprintf("User (ID: '%d'): User still has %d Articles!", user.ID, count)}
+		}
+	}
+
+	if repo.media != nil {
+		repo.media.deleteByUserID(user.ID)
+	}
+
+	delete(repo.users, user.ID)
+
+	return nil
+}
+
+// IsSlugTaken - Checks whether another User uses the Slug
+// Deleted Users keep their Slug reserved
+func (repo *MemoryUserRepository) IsSlugTaken(userSlug string, exceptID uint) (bool, error) {
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	return repo.isSlugTaken(userSlug, exceptID), nil
+}
+
+// isSlugTaken - The Caller must hold the Mutex
+func (repo *MemoryUserRepository) isSlugTaken(userSlug string, exceptID uint) bool {
+	for _, stored := range repo.users {
+		if stored.ID != exceptID && stored.Slug == userSlug {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sorted - Returns copies of all active Users ordered by their ID
+func (repo *MemoryUserRepository) sorted() []model.User {
+	var users []model.User
+
+	repo.mutex.RLock()
+	defer repo.mutex.RUnlock()
+
+	for _, user := range repo.users {
+		if !user.DeletedAt.Valid {
+			users = append(users, *user)
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	return users
+}
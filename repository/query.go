@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure ArticleQuery Declaration
+
+// ArticleQuery - Filter, Sort Order and Page of an Article List
+type ArticleQuery struct {
+	Page          int
+	PerPage       int
+	Sort          string
+	UserID        uint
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Status        model.ArticleStatus
+	TagID         uint
+	// CategoryIDs - Restricts the List to the Articles of the Categories
+	CategoryIDs []uint
+	// PublicOnly - Restricts the List to published Articles and the Articles of the Owner
+	PublicOnly bool
+	OwnerID    uint
+}
+
+// ArticleSortFields - Article Fields by which an Article List can be sorted
+var ArticleSortFields = []string{"id", "created_at", "updated_at", "published_at", "title"}
+
+// ParseSort - Splits a Sort Parameter like "-updated_at" into the Field and the Direction
+func ParseSort(sort string) (string, bool, error) {
+	descending := strings.HasPrefix(sort, "-")
+	field := strings.TrimPrefix(strings.TrimPrefix(sort, "-"), "+")
+
+	for _, allowed := range ArticleSortFields {
+		if field == allowed {
+			return field, descending, nil
+		}
+	}
+
+	return "", false, fmt.Errorf("Sort '%s': Field is not sortable! Allowed Fields: %s", sort, strings.Join(ArticleSortFields, ", "))
+}
+
+// Offset - Number of Entries before the requested Page
+func (query *ArticleQuery) Offset() int {
+	if query.Page < 1 || query.PerPage < 1 {
+		return 0
+	}
+
+	return (query.Page - 1) * query.PerPage
+}
+
+// OrderClause - SQL Order Clause for the Sort Field with the ID as Tie Breaker
+func (query *ArticleQuery) OrderClause() string {
+	field, descending, err := ParseSort(query.Sort)
+
+	if err != nil || field == "" {
+		field = "id"
+	}
+
+	direction := "ASC"
+
+	if descending {
+		direction = "DESC"
+	}
+
+	if field == "id" {
+		return "id " + direction
+	}
+
+	return field + " " + direction + ", id " + direction
+}
+
+// Matches - Checks whether the Article passes the Filters of the Query
+func (query *ArticleQuery) Matches(article *model.Article) bool {
+	if query.UserID != 0 && article.UserID != query.UserID {
+		return false
+	}
+
+	if !query.CreatedAfter.IsZero() && article.CreatedAt.Before(query.CreatedAfter) {
+		return false
+	}
+
+	if !query.CreatedBefore.IsZero() && !article.CreatedAt.Before(query.CreatedBefore) {
+		return false
+	}
+
+	if query.Status != "" && article.Status != query.Status {
+		return false
+	}
+
+	if query.CategoryIDs != nil && !containsCategory(query.CategoryIDs, article.CategoryID) {
+		return false
+	}
+
+	if query.PublicOnly && article.Status != model.StatusPublished && (query.OwnerID == 0 || article.UserID != query.OwnerID) {
+		return false
+	}
+
+	return true
+}
+
+func containsCategory(categoryIDs []uint, categoryID *uint) bool {
+	if categoryID == nil {
+		return false
+	}
+
+	for _, listed := range categoryIDs {
+		if listed == *categoryID {
+			return true
+		}
+	}
+
+	return false
+}
+
+//==========================================================================
+// Structure SearchQuery Declaration
+
+// SEARCHLANGUAGE - PostgreSQL Text Search Configuration of the Article Search
+var SEARCHLANGUAGE string = "english"
+
+// SearchQuery - Search Terms with the Filters and the Page of the Article Search
+// The Results are ordered by their Rank instead of the Sort Field
+type SearchQuery struct {
+	ArticleQuery
+	Terms string
+}
+
+// SearchResult - Article matching the Search Terms with its Rank and its Snippet
+// The Snippet marks the matching Words with model.HIGHLIGHTSTART and model.HIGHLIGHTSTOP
+type SearchResult struct {
+	Article model.Article
+	Rank    float64
+	Snippet string
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure GormRedirectRepository Declaration
+
+// GormRedirectRepository - Slug Redirect Storage backed by a GORM Database Connection
+type GormRedirectRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRedirectRepository(db *gorm.DB) *GormRedirectRepository {
+	return &GormRedirectRepository{db}
+}
+
+func (repo *GormRedirectRepository) GetBySlug(entity string, slug string) (*model.SlugRedirect, error) {
+	var redirects []model.SlugRedirect
+
+	if err := repo.db.Find(&redirects, "entity = ? AND slug = ?", entity, slug).Error; err != nil {
+		return nil, err
+	}
+
+	if len(redirects) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("Redirect (Slug: '%s'): Slug was never used by a %s", slug, entity)}
+	}
+
+	return &redirects[0], nil
+}
+
+// Save - Records the Redirect or points an existing Redirect of the Slug to the new Target
+func (repo *GormRedirectRepository) Save(redirect *model.SlugRedirect) error {
+	return repo.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity"}, {Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"target_id", "created_at"}),
+	}).Create(redirect).Error
+}
+
+func (repo *GormRedirectRepository) DeleteBySlug(entity string, slug string) error {
+	return repo.db.Where("entity = ? AND slug = ?", entity, slug).Delete(&model.SlugRedirect{}).Error
+}
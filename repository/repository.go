@@ -0,0 +1,275 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gin-blog/model"
+)
+
+type (
+	//==========================================================================
+	// Interface UserRepository Declaration
+
+	// UserRepository - Storage Interface for the User Entities
+	// Deleted Users are kept in the Trash until they are restored or purged
+	UserRepository interface {
+		GetByID(userID uint) (*model.User, error)
+		GetByIDs(userIDs []uint) ([]model.User, error)
+		GetByLogin(userLogin string) (*model.User, error)
+		GetBySlug(userSlug string) (*model.User, error)
+		IsSlugTaken(userSlug string, exceptID uint) (bool, error)
+		List() ([]model.User, error)
+		Create(user *model.User) error
+		Save(user *model.User) error
+		Delete(user *model.User) error
+		ListDeleted(before time.Time) ([]model.User, error)
+		GetDeletedByID(userID uint) (*model.User, error)
+		Restore(user *model.User) error
+		Purge(user *model.User) error
+	}
+
+	//==========================================================================
+	// Interface ArticleRepository Declaration
+
+	// ArticleRepository - Storage Interface for the Article Entities
+	// Deleted Articles are kept in the Trash until they are restored or purged
+	ArticleRepository interface {
+		GetByID(articleID uint) (*model.Article, error)
+		GetByIDs(articleIDs []uint) ([]model.Article, error)
+		GetBySlug(articleSlug string) (*model.Article, error)
+		IsSlugTaken(articleSlug string, exceptID uint) (bool, error)
+		GetByUserID(userID uint) ([]model.Article, error)
+		List() ([]model.Article, error)
+		Find(query *ArticleQuery) ([]model.Article, int64, error)
+		PublishScheduled(now time.Time) (int64, error)
+		CountTags(query *ArticleQuery) (map[uint]int64, error)
+		Search(query *SearchQuery) ([]SearchResult, int64, error)
+		ReindexSearch() (int64, error)
+		Create(article *model.Article) error
+		Save(article *model.Article) error
+		Delete(article *model.Article) error
+		ListDeleted(before time.Time) ([]model.Article, error)
+		GetDeletedByID(articleID uint) (*model.Article, error)
+		Restore(article *model.Article) error
+		Purge(article *model.Article) error
+	}
+
+	//==========================================================================
+	// Interface SessionRepository Declaration
+
+	// SessionRepository - Storage Interface for the Login Sessions
+	SessionRepository interface {
+		GetByID(sessionID uint) (*model.Session, error)
+		GetByRefreshHash(refreshHash string) (*model.Session, error)
+		Create(session *model.Session) error
+		Save(session *model.Session) error
+	}
+
+	//==========================================================================
+	// Interface RedirectRepository Declaration
+
+	// RedirectRepository - Storage Interface for the former Slugs of Articles and Users
+	RedirectRepository interface {
+		GetBySlug(entity string, slug string) (*model.SlugRedirect, error)
+		Save(redirect *model.SlugRedirect) error
+		DeleteBySlug(entity string, slug string) error
+	}
+
+	//==========================================================================
+	// Interface RevisionRepository Declaration
+
+	// RevisionRepository - Storage Interface for the Article Revisions
+	// Revisions are immutable and can only be added
+	RevisionRepository interface {
+		GetByArticleID(articleID uint) ([]model.ArticleRevision, error)
+		GetByNumber(articleID uint, number uint) (*model.ArticleRevision, error)
+		Create(revision *model.ArticleRevision) error
+	}
+
+	//==========================================================================
+	// Interface TagRepository Declaration
+
+	// TagRepository - Storage Interface for the Tags and their Assignments to Articles
+	TagRepository interface {
+		GetBySlug(tagSlug string) (*model.Tag, error)
+		GetBySlugs(tagSlugs []string) ([]model.Tag, error)
+		GetByArticleIDs(articleIDs []uint) (map[uint][]model.Tag, error)
+		List() ([]model.Tag, error)
+		IsSlugTaken(tagSlug string, exceptID uint) (bool, error)
+		Create(tag *model.Tag) error
+		Save(tag *model.Tag) error
+		Delete(tag *model.Tag) error
+		SetArticleTags(articleID uint, tagIDs []uint) error
+	}
+
+	//==========================================================================
+	// Interface CategoryRepository Declaration
+
+	// CategoryRepository - Storage Interface for the Category Entities
+	CategoryRepository interface {
+		GetByID(categoryID uint) (*model.Category, error)
+		GetBySlug(categorySlug string) (*model.Category, error)
+		List() ([]model.Category, error)
+		IsSlugTaken(categorySlug string, exceptID uint) (bool, error)
+		Create(category *model.Category) error
+		Save(category *model.Category) error
+		Delete(category *model.Category) error
+	}
+
+	//==========================================================================
+	// Interface CommentRepository Declaration
+
+	// CommentRepository - Storage Interface for the Comments on Articles
+	CommentRepository interface {
+		GetByID(commentID uint) (*model.Comment, error)
+		GetByArticleID(articleID uint, status model.CommentStatus) ([]model.Comment, error)
+		CountByArticleIDs(articleIDs []uint, status model.CommentStatus) (map[uint]int64, error)
+		Create(comment *model.Comment) error
+		Save(comment *model.Comment) error
+	}
+
+	//==========================================================================
+	// Interface MediaRepository Declaration
+
+	// MediaRepository - Storage Interface for the Records of the uploaded Media
+	MediaRepository interface {
+		GetByID(mediaID uint) (*model.Media, error)
+		GetByArticleID(articleID uint) ([]model.Media, error)
+		GetByUserID(userID uint) ([]model.Media, error)
+		Create(media *model.Media) error
+		Delete(media *model.Media) error
+	}
+
+	//==========================================================================
+	// Structure Storage Declaration
+
+	// Storage - The Repositories of all Entities
+	Storage struct {
+		Users      UserRepository
+		Articles   ArticleRepository
+		Sessions   SessionRepository
+		Redirects  RedirectRepository
+		Revisions  RevisionRepository
+		Tags       TagRepository
+		Categories CategoryRepository
+		Comments   CommentRepository
+		Media      MediaRepository
+		// transaction - Runs the Operation on the Repositories of a Transaction
+		transaction func(operation func(storage Storage) error) error
+	}
+
+	//==========================================================================
+	// Structure NotFoundError Declaration
+
+	// NotFoundError - Error for a requested Entity that does not exist
+	NotFoundError struct {
+		Message string
+	}
+
+	//==========================================================================
+	// Structure ConflictError Declaration
+
+	// ConflictError - Error for an Entity which violates a Uniqueness Constraint
+	ConflictError struct {
+		Message string
+	}
+)
+
+func (err *NotFoundError) Error() string {
+	return err.Message
+}
+
+// IsNotFound - Checks whether the error reports a missing Entity
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+
+	return errors.As(err, &notFound)
+}
+
+func (err *ConflictError) Error() string {
+	return err.Message
+}
+
+// IsConflict - Checks whether the error reports a violated Uniqueness Constraint
+func IsConflict(err error) bool {
+	var conflict *ConflictError
+
+	return errors.As(err, &conflict)
+}
+
+// translateError - Reports a violated Unique Index of the Database as ConflictError
+// The Database Connection must be opened with the GORM Option TranslateError
+func translateError(err error, message string) error {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return &ConflictError{message}
+	}
+
+	return err
+}
+
+// Transaction - Runs the Operation on Repositories whose Changes are stored together
+// An Error of the Operation reverts all its Changes. The Repositories kept in Memory
+// run the Operation without a Transaction, so its former Changes are kept.
+func (storage Storage) Transaction(operation func(storage Storage) error) error {
+	if storage.transaction == nil {
+		return operation(storage)
+	}
+
+	return storage.transaction(operation)
+}
+
+// NewGormStorage - Creates the Repositories backed by a GORM Database Connection
+func NewGormStorage(db *gorm.DB) Storage {
+	return Storage{
+		Users:      NewGormUserRepository(db),
+		Articles:   NewGormArticleRepository(db),
+		Sessions:   NewGormSessionRepository(db),
+		Redirects:  NewGormRedirectRepository(db),
+		Revisions:  NewGormRevisionRepository(db),
+		Tags:       NewGormTagRepository(db),
+		Categories: NewGormCategoryRepository(db),
+		Comments:   NewGormCommentRepository(db),
+		Media:      NewGormMediaRepository(db),
+		transaction: func(operation func(storage Storage) error) error {
+			return db.Transaction(func(tx *gorm.DB) error {
+				return operation(NewGormStorage(tx))
+			})
+		},
+	}
+}
+
+// NewMemoryStorage - Creates the Repositories kept in Memory
+func NewMemoryStorage() Storage {
+	users := NewMemoryUserRepository()
+	articles := NewMemoryArticleRepository()
+	tags := NewMemoryTagRepository()
+	media := NewMemoryMediaRepository()
+
+	// The Article Filters look up the Tag Assignments and Purging removes the Media
+	articles.tags = tags
+	articles.media = media
+
+	// Purging a User looks up the Articles and removes the Media
+	users.articles = articles
+	users.media = media
+
+	return Storage{
+		Users:      users,
+		Articles:   articles,
+		Sessions:   NewMemorySessionRepository(),
+		Redirects:  NewMemoryRedirectRepository(),
+		Revisions:  NewMemoryRevisionRepository(),
+		Tags:       tags,
+		Categories: NewMemoryCategoryRepository(),
+		Comments:   NewMemoryCommentRepository(),
+		Media:      media,
+	}
+}
+
+func slugConflictMessage(entity string, slug string) string {
+	return fmt.Sprintf("%s (Slug: '%s'): Slug is already taken!", entity, slug)
+}
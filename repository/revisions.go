@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure GormRevisionRepository Declaration
+
+// GormRevisionRepository - Article Revision Storage backed by a GORM Database Connection
+type GormRevisionRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRevisionRepository(db *gorm.DB) *GormRevisionRepository {
+	return &GormRevisionRepository{db}
+}
+
+func (repo *GormRevisionRepository) GetByArticleID(articleID uint) ([]model.ArticleRevision, error) {
+	var revisions []model.ArticleRevision
+
+	if err := repo.db.Order("number").Find(&revisions, "article_id = ?", articleID).Error; err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (repo *GormRevisionRepository) GetByNumber(articleID uint, number uint) (*model.ArticleRevision, error) {
+	var revisions []model.ArticleRevision
+
+	if err := repo.db.Find(&revisions, "article_id = ? AND number = ?", articleID, number).Error; err != nil {
+		return nil, err
+	}
+
+	if len(revisions) == 0 {
+		return nil, &NotFoundError{revisionNotFoundMessage(articleID, number)}
+	}
+
+	return &revisions[0], nil
+}
+
+// Create - Stores the Revision with the next Revision Number of its Article
+// Concurrent Saves of the same Article collide on the unique Revision Number
+func (repo *GormRevisionRepository) Create(revision *model.ArticleRevision) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var latest uint
+
+		if err := tx.Model(&model.ArticleRevision{}).Where("article_id = ?", revision.ArticleID).
+			Select("COALESCE(MAX(number), 0)").Scan(&latest).Error; err != nil {
+			return err
+		}
+
+		revision.ID = 0
+		revision.Number = latest + 1
+
+		return translateError(tx.Create(revision).Error, revisionConflictMessage(revision))
+	})
+}
+
+func revisionNotFoundMessage(articleID uint, number uint) string {
+	return fmt.Sprintf("Article (ID: '%d'): Revision %d does not exist!", articleID, number)
+}
+
+func revisionConflictMessage(revision *model.ArticleRevision) string {
+	return fmt.Sprintf("Article (ID: '%d'): Revision %d was saved concurrently!", revision.ArticleID, revision.Number)
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+//==========================================================================
+// Structure S3FileStore Declaration
+
+// S3FileStore - File Storage in a Bucket of an S3-compatible Object Storage
+// The Objects are addressed in Path Style and the Requests are signed with AWS Signature Version 4
+type S3FileStore struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+func NewS3FileStore(endpoint string, region string, bucket string, accessKey string, secretKey string) (*S3FileStore, error) {
+	endpointURL, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+
+	if err != nil || endpointURL.Scheme == "" || endpointURL.Host == "" {
+		return nil, fmt.Errorf("S3 Endpoint '%s': Endpoint is invalid!", endpoint)
+	}
+
+	if bucket == "" {
+		return nil, fmt.Errorf("S3 Bucket: Bucket is missing!")
+	}
+
+	return &S3FileStore{endpointURL, region, bucket, accessKey, secretKey, &http.Client{Timeout: 60 * time.Second}}, nil
+}
+
+func (store *S3FileStore) Put(key string, data []byte, contentType string) error {
+	res, err := store.request("PUT", key, data, contentType)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return store.responseError("PUT", key, res)
+	}
+
+	return nil
+}
+
+func (store *S3FileStore) Open(key string) (io.ReadCloser, error) {
+	res, err := store.request("GET", key, nil, "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+
+		return nil, &NotFoundError{fmt.Sprintf("File (Key: '%s'): File does not exist!", key)}
+	}
+
+	if res.StatusCode != http.StatusOK {
+		defer res.Body.Close()
+
+		return nil, store.responseError("GET", key, res)
+	}
+
+	return res.Body, nil
+}
+
+func (store *S3FileStore) Delete(key string) error {
+	res, err := store.request("DELETE", key, nil, "")
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return store.responseError("DELETE", key, res)
+	}
+
+	return nil
+}
+
+// request - Sends the signed Request for the Object of the Key
+func (store *S3FileStore) request(method string, key string, body []byte, contentType string) (*http.Response, error) {
+	objectURL := *store.endpoint
+	objectURL.Path = store.endpoint.Path + "/" + store.bucket + "/" + strings.TrimPrefix(key, "/")
+	objectURL.RawPath = s3EscapePath(objectURL.Path)
+
+	req, err := http.NewRequest(method, objectURL.String(), bytes.NewReader(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	store.sign(req, body, time.Now().UTC())
+
+	return store.client.Do(req)
+}
+
+// sign - Adds the AWS Signature Version 4 to the Request Headers
+func (store *S3FileStore) sign(req *http.Request, body []byte, now time.Time) {
+	payloadHash := sha256.Sum256(body)
+	amzDate := now.Format("20060102T150405Z")
+	scope := amzDate[:8] + "/" + store.region + "/s3/aws4_request"
+
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + req.Header.Get("X-Amz-Content-Sha256") + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		signedHeaders = append([]string{"content-type"}, signedHeaders...)
+		canonicalHeaders = "content-type:" + contentType + "\n" + canonicalHeaders
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		strings.Join(signedHeaders, ";"),
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+store.secretKey), amzDate[:8])
+	signingKey = hmacSHA256(signingKey, store.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+store.accessKey+"/"+scope+
+		", SignedHeaders="+strings.Join(signedHeaders, ";")+", Signature="+signature)
+}
+
+func (store *S3FileStore) responseError(method string, key string, res *http.Response) error {
+	message, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+
+	return fmt.Errorf("S3 %s (Key: '%s'): Request failed with Status '%d'! Message: %s", method, key, res.StatusCode, strings.TrimSpace(string(message)))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
+
+// s3EscapePath - Encodes all Characters of the Path Segments except the unreserved Characters
+func s3EscapePath(path string) string {
+	var escaped strings.Builder
+
+	for _, char := range []byte(path) {
+		if (char >= 'A' && char <= 'Z') || (char >= 'a' && char <= 'z') || (char >= '0' && char <= '9') ||
+			char == '-' || char == '_' || char == '.' || char == '~' || char == '/' {
+			escaped.WriteByte(char)
+		} else {
+			fmt.Fprintf(&escaped, "%%%02X", char)
+		}
+	}
+
+	return escaped.String()
+}
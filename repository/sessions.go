@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure GormSessionRepository Declaration
+
+// GormSessionRepository - Session Storage backed by a GORM Database Connection
+type GormSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSessionRepository(db *gorm.DB) *GormSessionRepository {
+	return &GormSessionRepository{db}
+}
+
+func (repo *GormSessionRepository) GetByID(sessionID uint) (*model.Session, error) {
+	var sessions []model.Session
+
+	if err := repo.db.Find(&sessions, []uint{sessionID}).Error; err != nil {
+		return nil, err
+	}
+
+	if len(sessions) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("Session (ID: '%d'): Session does not exist!", sessionID)}
+	}
+
+	return &sessions[0], nil
+}
+
+func (repo *GormSessionRepository) GetByRefreshHash(refreshHash string) (*model.Session, error) {
+	var sessions []model.Session
+
+	if err := repo.db.Find(&sessions, "refresh_hash = ?", refreshHash).Error; err != nil {
+		return nil, err
+	}
+
+	if len(sessions) == 0 {
+		return nil, &NotFoundError{"Session: Refresh Token is unknown!"}
+	}
+
+	return &sessions[0], nil
+}
+
+func (repo *GormSessionRepository) Create(session *model.Session) error {
+	return repo.db.Create(session).Error
+}
+
+func (repo *GormSessionRepository) Save(session *model.Session) error {
+	return repo.db.Save(session).Error
+}
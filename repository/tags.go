@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure GormTagRepository Declaration
+
+// GormTagRepository - Tag Storage backed by a GORM Database Connection
+type GormTagRepository struct {
+	db *gorm.DB
+}
+
+// articleTagRow - Tag joined with the Article it is assigned to
+type articleTagRow struct {
+	model.Tag
+	ArticleID uint
+}
+
+func NewGormTagRepository(db *gorm.DB) *GormTagRepository {
+	return &GormTagRepository{db}
+}
+
+func (repo *GormTagRepository) GetBySlug(tagSlug string) (*model.Tag, error) {
+	var tags []model.Tag
+
+	if err := repo.db.Find(&tags, "slug = ?", tagSlug).Error; err != nil {
+		return nil, err
+	}
+
+	if len(tags) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("Tag (Slug: '%s'): Tag does not exist!", tagSlug)}
+	}
+
+	return &tags[0], nil
+}
+
+func (repo *GormTagRepository) GetBySlugs(tagSlugs []string) ([]model.Tag, error) {
+	var tags []model.Tag
+
+	if len(tagSlugs) == 0 {
+		return tags, nil
+	}
+
+	err := repo.db.Order("name, id").Find(&tags, "slug IN ?", tagSlugs).Error
+
+	return tags, err
+}
+
+// GetByArticleIDs - Looks up the Tags of all Articles with one Query
+func (repo *GormTagRepository) GetByArticleIDs(articleIDs []uint) (map[uint][]model.Tag, error) {
+	var rows []articleTagRow
+
+	tagMap := make(map[uint][]model.Tag)
+
+	if len(articleIDs) == 0 {
+		return tagMap, nil
+	}
+
+	err := repo.db.Table("tags").Select("tags.*, article_tags.article_id").
+		Joins("JOIN article_tags ON article_tags.tag_id = tags.id").
+		Where("article_tags.article_id IN ?", articleIDs).
+		Order("tags.name, tags.id").Scan(&rows).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		tagMap[row.ArticleID] = append(tagMap[row.ArticleID], row.Tag)
+	}
+
+	return tagMap, nil
+}
+
+func (repo *GormTagRepository) List() ([]model.Tag, error) {
+	var tags []model.Tag
+
+	err := repo.db.Order("name, id").Find(&tags).Error
+
+	return tags, err
+}
+
+func (repo *GormTagRepository) IsSlugTaken(tagSlug string, exceptID uint) (bool, error) {
+	var count int64
+
+	err := repo.db.Model(&model.Tag{}).Where("slug = ? AND id <> ?", tagSlug, exceptID).Count(&count).Error
+
+	return count != 0, err
+}
+
+func (repo *GormTagRepository) Create(tag *model.Tag) error {
+	return translateError(repo.db.Create(tag).Error, slugConflictMessage("Tag", tag.Slug))
+}
+
+func (repo *GormTagRepository) Save(tag *model.Tag) error {
+	return translateError(repo.db.Save(tag).Error, slugConflictMessage("Tag", tag.Slug))
+}
+
+// Delete - Removes the Tag together with its Assignments to Articles
+func (repo *GormTagRepository) Delete(tag *model.Tag) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tag_id = ?", tag.ID).Delete(&model.ArticleTag{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(tag, tag.ID).Error
+	})
+}
+
+// SetArticleTags - Replaces the Tags assigned to the Article
+func (repo *GormTagRepository) SetArticleTags(articleID uint, tagIDs []uint) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("article_id = ?", articleID).Delete(&model.ArticleTag{}).Error; err != nil {
+			return err
+		}
+
+		if len(tagIDs) == 0 {
+			return nil
+		}
+
+		assignments := make([]model.ArticleTag, 0, len(tagIDs))
+
+		for _, tagID := range tagIDs {
+			assignments = append(assignments, model.ArticleTag{ArticleID: articleID, TagID: tagID})
+		}
+
+		return tx.Create(&assignments).Error
+	})
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gin-blog/model"
+)
+
+//==========================================================================
+// Structure GormUserRepository Declaration
+
+// GormUserRepository - User Storage backed by a GORM Database Connection
+type GormUserRepository struct {
+	db *gorm.DB
+}
+
+func NewGormUserRepository(db *gorm.DB) *GormUserRepository {
+	return &GormUserRepository{db}
+}
+
+func (repo *GormUserRepository) GetByID(userID uint) (*model.User, error) {
+	var users []model.User
+
+	if err := repo.db.Find(&users, []uint{userID}).Error; err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("User (ID: '%d'): User does not exist!", userID)}
+	}
+
+	return &users[0], nil
+}
+
+func (repo *GormUserRepository) GetByIDs(userIDs []uint) ([]model.User, error) {
+	var users []model.User
+
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	err := repo.db.Find(&users, userIDs).Error
+
+	return users, err
+}
+
+func (repo *GormUserRepository) GetByLogin(userLogin string) (*model.User, error) {
+	var users []model.User
+
+	if err := repo.db.Find(&users, "login = ?", userLogin).Error; err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("User (Login: '%s'): User does not exist", userLogin)}
+	}
+
+	return &users[0], nil
+}
+
+func (repo *GormUserRepository) GetBySlug(userSlug string) (*model.User, error) {
+	var users []model.User
+
+	if err := repo.db.Find(&users, "slug = ?", userSlug).Error; err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("User (Slug: '%s'): User does not exist", userSlug)}
+	}
+
+	return &users[0], nil
+}
+
+func (repo *GormUserRepository) List() ([]model.User, error) {
+	var users []model.User
+
+	err := repo.db.Order("id").Find(&users).Error
+
+	return users, err
+}
+
+// IsSlugTaken - Checks whether another User uses the Slug
+// Deleted Users keep their Slug reserved
+func (repo *GormUserRepository) IsSlugTaken(userSlug string, exceptID uint) (bool, error) {
+	var count int64
+
+	err := repo.db.Unscoped().Model(&model.User{}).Where("slug = ? AND id <> ?", userSlug, exceptID).Count(&count).Error
+
+	return count != 0, err
+}
+
+func (repo *GormUserRepository) Create(user *model.User) error {
+	return translateError(repo.db.Create(user).Error, slugConflictMessage("User", user.Slug))
+}
+
+func (repo *GormUserRepository) Save(user *model.User) error {
+	return translateError(repo.db.Save(user).Error, slugConflictMessage("User", user.Slug))
+}
+
+func (repo *GormUserRepository) Delete(user *model.User) error {
+	return repo.db.Delete(user, user.ID).Error
+}
+
+// ListDeleted - Lists the Users in the Trash which were deleted before the Time
+// The latest Deletions are listed first
+func (repo *GormUserRepository) ListDeleted(before time.Time) ([]model.User, error) {
+	var users []model.User
+
+	err := repo.db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at DESC").Order("id").Find(&users).Error
+
+	return users, err
+}
+
+func (repo *GormUserRepository) GetDeletedByID(userID uint) (*model.User, error) {
+	var users []model.User
+
+	if err := repo.db.Unscoped().Where("deleted_at IS NOT NULL").Find(&users, []uint{userID}).Error; err != nil {
+		return nil, err
+	}
+
+	if len(users) == 0 {
+		return nil, &NotFoundError{fmt.Sprintf("User (ID: '%d'): User is not in the Trash!", userID)}
+	}
+
+	return &users[0], nil
+}
+
+func (repo *GormUserRepository) Restore(user *model.User) error {
+	err := repo.db.Unscoped().Model(&model.User{}).Where("id = ?", user.ID).Update("deleted_at", nil).Error
+
+	if err == nil {
+		user.DeletedAt = gorm.DeletedAt{}
+	}
+
+	return err
+}
+
+// Purge - Removes the User permanently with the Sessions, the former Slugs and the Media Records
+// Users who still have Articles in the Blog or in the Trash cannot be purged
+func (repo *GormUserRepository) Purge(user *model.User) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+
+		if err := tx.Unscoped().Model(&model.Article{}).Where("user_id = ?", user.ID).Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count != 0 {
+			return &ConflictError{fmt.Sprintf("User (ID: '%d'): User still has %d Articles!", user.ID, count)}
+		}
+
+		if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&model.Session{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("entity = ? AND target_id = ?", model.RedirectUser, user.ID).Delete(&model.SlugRedirect{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("user_id = ?", user.ID).Delete(&model.Media{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(&model.User{}, user.ID).Error
+	})
+}